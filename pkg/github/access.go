@@ -0,0 +1,776 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// repositoryPermissionEnum lists the built-in repository roles accepted by the REST API.
+var repositoryPermissionEnum = []any{"pull", "triage", "push", "maintain", "admin"}
+
+// ListRepositoryCollaborators creates a tool to list the collaborators of a repository.
+func ListRepositoryCollaborators(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "list_repository_collaborators",
+			Description: t("TOOL_LIST_REPOSITORY_COLLABORATORS_DESCRIPTION", "List the collaborators of a repository with their role and permissions. Requires push access to the repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_REPOSITORY_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"affiliation": {
+						Type:        "string",
+						Description: "Filter by affiliation: 'outside' for outside collaborators, 'direct' for collaborators with direct or team access, 'all' for everyone the caller can see",
+						Enum:        []any{"outside", "direct", "all"},
+					},
+					"permission": {
+						Type:        "string",
+						Description: "Only return collaborators with this permission",
+						Enum:        repositoryPermissionEnum,
+					},
+				},
+				Required: []string{"owner", "repo"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			affiliation, err := OptionalParam[string](args, "affiliation")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			permission, err := OptionalParam[string](args, "permission")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list collaborators", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			collaborators := make([]MinimalCollaborator, 0, len(users))
+			for _, user := range users {
+				collaborators = append(collaborators, convertToMinimalCollaborator(user))
+			}
+
+			result, err := utils.NewToolResultJSON(collaborators)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// RepositoryCollaboratorWrite creates a tool to add, update and remove repository collaborators.
+func RepositoryCollaboratorWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "repository_collaborator_write",
+			Description: t("TOOL_REPOSITORY_COLLABORATOR_WRITE_DESCRIPTION", "Add a collaborator to a repository, change the permission of an existing collaborator, or remove a collaborator. Adding a user who is not yet a collaborator sends them an invitation."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_REPOSITORY_COLLABORATOR_WRITE_USER_TITLE", "Manage repository collaborators"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'add': invite a user to collaborate on the repository with 'permission'.
+- 'update': change the permission of an existing collaborator.
+- 'remove': remove a collaborator from the repository.`,
+						Enum: []any{"add", "update", "remove"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"username": {
+						Type:        "string",
+						Description: "GitHub username of the collaborator",
+					},
+					"permission": {
+						Type:        "string",
+						Description: "Permission to grant: pull, triage, push, maintain, admin, or the name of a custom repository role. Defaults to push for 'add'; required for 'update'.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "username"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			username, err := RequiredParam[string](args, "username")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			permission, err := OptionalParam[string](args, "permission")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "add":
+				return addRepositoryCollaborator(ctx, client, owner, repo, username, permission)
+			case "update":
+				if permission == "" {
+					return utils.NewToolResultError("permission is required for update"), nil, nil
+				}
+				isCollaborator, resp, err := client.Repositories.IsCollaborator(ctx, owner, repo, username)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to check collaborator", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				if !isCollaborator {
+					return utils.NewToolResultError(fmt.Sprintf("%s is not a collaborator of %s/%s. Use method 'add' to invite them", username, owner, repo)), nil, nil
+				}
+				return addRepositoryCollaborator(ctx, client, owner, repo, username, permission)
+			case "remove":
+				resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove collaborator", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+					}
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to remove collaborator", resp, body), nil, nil
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("%s removed from %s/%s", username, owner, repo)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: add, update, remove", method)), nil, nil
+			}
+		},
+	)
+}
+
+// addRepositoryCollaborator adds or updates a collaborator. GitHub responds with 201 and an
+// invitation when the user is not yet a collaborator, and 204 when an existing collaborator's
+// permission was changed.
+func addRepositoryCollaborator(ctx context.Context, client *github.Client, owner, repo, username, permission string) (*mcp.CallToolResult, any, error) {
+	var opts *github.RepositoryAddCollaboratorOptions
+	if permission != "" {
+		opts = &github.RepositoryAddCollaboratorOptions{Permission: permission}
+	}
+
+	invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, opts)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add collaborator", resp, err), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNoContent {
+		if permission == "" {
+			return utils.NewToolResultText(fmt.Sprintf("%s is already a collaborator of %s/%s", username, owner, repo)), nil, nil
+		}
+		return utils.NewToolResultText(fmt.Sprintf("permission of %s on %s/%s set to %s", username, owner, repo, permission)), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(convertToMinimalRepositoryInvitation(&github.RepositoryInvitation{
+		ID:          invitation.ID,
+		Repo:        invitation.Repo,
+		Invitee:     invitation.Invitee,
+		Inviter:     invitation.Inviter,
+		Permissions: invitation.Permissions,
+		CreatedAt:   invitation.CreatedAt,
+		HTMLURL:     invitation.HTMLURL,
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// ListRepositoryInvitations creates a tool to list pending repository invitations.
+func ListRepositoryInvitations(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "list_repository_invitations",
+			Description: t("TOOL_LIST_REPOSITORY_INVITATIONS_DESCRIPTION", "List pending invitations to collaborate on a repository. When 'owner' and 'repo' are omitted, lists the invitations received by the authenticated user."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_REPOSITORY_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner. Omit together with 'repo' to list your own invitations.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. Omit together with 'owner' to list your own invitations.",
+					},
+				},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := OptionalParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := OptionalParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if (owner == "") != (repo == "") {
+				return utils.NewToolResultError("owner and repo must be provided together"), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			listOptions := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var (
+				invitations []*github.RepositoryInvitation
+				resp        *github.Response
+			)
+			if owner == "" {
+				invitations, resp, err = client.Users.ListInvitations(ctx, listOptions)
+			} else {
+				invitations, resp, err = client.Repositories.ListInvitations(ctx, owner, repo, listOptions)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list invitations", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalInvitations := make([]MinimalRepositoryInvitation, 0, len(invitations))
+			for _, invitation := range invitations {
+				minimalInvitations = append(minimalInvitations, convertToMinimalRepositoryInvitation(invitation))
+			}
+
+			result, err := utils.NewToolResultJSON(minimalInvitations)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// RepositoryInvitationWrite creates a tool to cancel, accept or decline repository invitations.
+func RepositoryInvitationWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "repository_invitation_write",
+			Description: t("TOOL_REPOSITORY_INVITATION_WRITE_DESCRIPTION", "Cancel a pending invitation sent from a repository, or accept or decline an invitation received by the authenticated user."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_REPOSITORY_INVITATION_WRITE_USER_TITLE", "Manage repository invitations"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'cancel': cancel an invitation sent from 'owner'/'repo'.
+- 'accept': accept an invitation received by the authenticated user.
+- 'decline': decline an invitation received by the authenticated user.`,
+						Enum: []any{"cancel", "accept", "decline"},
+					},
+					"invitation_id": {
+						Type:        "number",
+						Description: "Invitation ID",
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner. Required for 'cancel'.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. Required for 'cancel'.",
+					},
+				},
+				Required: []string{"method", "invitation_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			invitationID, err := RequiredBigInt(args, "invitation_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var (
+				resp    *github.Response
+				message string
+			)
+			switch method {
+			case "cancel":
+				owner, repo, err := RequiredOwnerRepo(args)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				resp, err = client.Repositories.DeleteInvitation(ctx, owner, repo, invitationID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to cancel invitation", resp, err), nil, nil
+				}
+				message = fmt.Sprintf("invitation %d cancelled", invitationID)
+			case "accept":
+				resp, err = client.Users.AcceptInvitation(ctx, invitationID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to accept invitation", resp, err), nil, nil
+				}
+				message = fmt.Sprintf("invitation %d accepted", invitationID)
+			case "decline":
+				resp, err = client.Users.DeclineInvitation(ctx, invitationID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to decline invitation", resp, err), nil, nil
+				}
+				message = fmt.Sprintf("invitation %d declined", invitationID)
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: cancel, accept, decline", method)), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return utils.NewToolResultText(message), nil, nil
+		},
+	)
+}
+
+// ListRepositoryTeams creates a tool to list the teams with access to a repository.
+func ListRepositoryTeams(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "list_repository_teams",
+			Description: t("TOOL_LIST_REPOSITORY_TEAMS_DESCRIPTION", "List the teams that have access to a repository, with the permission granted to each team."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_REPOSITORY_TEAMS_USER_TITLE", "List repository teams"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			teams, resp, err := client.Repositories.ListTeams(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository teams", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalTeams := make([]MinimalRepositoryTeam, 0, len(teams))
+			for _, team := range teams {
+				minimalTeams = append(minimalTeams, convertToMinimalRepositoryTeam(team))
+			}
+
+			result, err := utils.NewToolResultJSON(minimalTeams)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// TeamRepositoryPermissionWrite creates a tool to grant or revoke a team's access to a repository.
+func TeamRepositoryPermissionWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "team_repository_permission_write",
+			Description: t("TOOL_TEAM_REPOSITORY_PERMISSION_WRITE_DESCRIPTION", "Grant a team access to a repository (or change the permission it already has), or revoke the team's access."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_TEAM_REPOSITORY_PERMISSION_WRITE_USER_TITLE", "Manage team repository permissions"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'grant': grant the team 'permission' on the repository.
+- 'revoke': remove the team's access to the repository.`,
+						Enum: []any{"grant", "revoke"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization the team belongs to",
+					},
+					"team_slug": {
+						Type:        "string",
+						Description: "Team slug",
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"permission": {
+						Type:        "string",
+						Description: "Permission to grant: pull, triage, push, maintain, admin, or the name of a custom repository role. Defaults to push for 'grant'.",
+					},
+				},
+				Required: []string{"method", "org", "team_slug", "owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			teamSlug, err := RequiredParam[string](args, "team_slug")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			permission, err := OptionalParam[string](args, "permission")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var (
+				resp    *github.Response
+				message string
+			)
+			switch method {
+			case "grant":
+				if permission == "" {
+					permission = "push"
+				}
+				resp, err = client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{Permission: permission})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to grant team access", resp, err), nil, nil
+				}
+				message = fmt.Sprintf("team %s/%s granted %s on %s/%s", org, teamSlug, permission, owner, repo)
+			case "revoke":
+				resp, err = client.Teams.RemoveTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to revoke team access", resp, err), nil, nil
+				}
+				message = fmt.Sprintf("team %s/%s no longer has access to %s/%s", org, teamSlug, owner, repo)
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: grant, revoke", method)), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return utils.NewToolResultText(message), nil, nil
+		},
+	)
+}
+
+// pushAccessQuery lists repository collaborators with the sources of their permission. It uses
+// the same collaborators(query:) shape as the lockdown repository access check.
+type pushAccessQuery struct {
+	Repository struct {
+		Collaborators struct {
+			Edges []struct {
+				Permission        githubv4.String
+				PermissionSources []struct {
+					Permission githubv4.String
+					Source     struct {
+						Typename     githubv4.String `graphql:"__typename"`
+						Organization struct {
+							Login githubv4.String
+						} `graphql:"... on Organization"`
+						Repository struct {
+							NameWithOwner githubv4.String
+						} `graphql:"... on Repository"`
+						Team struct {
+							CombinedSlug githubv4.String
+						} `graphql:"... on Team"`
+					}
+				}
+				Node struct {
+					Login githubv4.String
+				}
+			}
+			PageInfo struct {
+				HasNextPage     githubv4.Boolean
+				HasPreviousPage githubv4.Boolean
+				StartCursor     githubv4.String
+				EndCursor       githubv4.String
+			}
+		} `graphql:"collaborators(query: $username, first: $first, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// PushAccessSource describes where a user's permission on a repository comes from.
+type PushAccessSource struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
+
+// PushAccessUser is a user that can push to a repository.
+type PushAccessUser struct {
+	Login      string             `json:"login"`
+	Permission string             `json:"permission"`
+	Sources    []PushAccessSource `json:"sources,omitempty"`
+}
+
+// ListPushAccess creates a tool that resolves which users can push to a repository.
+func ListPushAccess(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataAccess,
+		mcp.Tool{
+			Name:        "list_push_access",
+			Description: t("TOOL_LIST_PUSH_ACCESS_DESCRIPTION", "Resolve who can push to a repository. Combines direct collaborator, team and organization base permissions, and reports the source of each user's access. Branch protection and rulesets may further restrict pushes to specific branches; use 'get_branch_rules' to check those."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_PUSH_ACCESS_USER_TITLE", "List users with push access"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithCursorPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"username": {
+						Type:        "string",
+						Description: "Only check this user",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			username, err := OptionalParam[string](args, "username")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"name":  githubv4.String(repo),
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if username != "" {
+				vars["username"] = githubv4.String(username)
+			} else {
+				vars["username"] = (*githubv4.String)(nil)
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+
+			var q pushAccessQuery
+			if err := gqlClient.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to resolve push access", err), nil, nil
+			}
+
+			users := []PushAccessUser{}
+			for _, edge := range q.Repository.Collaborators.Edges {
+				login := string(edge.Node.Login)
+				if username != "" && !strings.EqualFold(login, username) {
+					continue
+				}
+				if !lockdown.HasPushPermission(string(edge.Permission)) {
+					continue
+				}
+
+				user := PushAccessUser{
+					Login:      login,
+					Permission: string(edge.Permission),
+				}
+				for _, source := range edge.PermissionSources {
+					if !lockdown.HasPushPermission(string(source.Permission)) {
+						continue
+					}
+					pushSource := PushAccessSource{
+						Type:       string(source.Source.Typename),
+						Permission: string(source.Permission),
+					}
+					switch pushSource.Type {
+					case "Organization":
+						pushSource.Name = string(source.Source.Organization.Login)
+					case "Repository":
+						pushSource.Name = string(source.Source.Repository.NameWithOwner)
+					case "Team":
+						pushSource.Name = string(source.Source.Team.CombinedSlug)
+					}
+					user.Sources = append(user.Sources, pushSource)
+				}
+				users = append(users, user)
+			}
+
+			response := map[string]any{
+				"users": users,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Repository.Collaborators.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Repository.Collaborators.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Repository.Collaborators.PageInfo.StartCursor),
+					"endCursor":       string(q.Repository.Collaborators.PageInfo.EndCursor),
+				},
+			}
+			if username != "" && len(users) == 0 {
+				response["message"] = fmt.Sprintf("%s cannot push to %s/%s", username, owner, repo)
+			}
+
+			result, err := utils.NewToolResultJSON(response)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
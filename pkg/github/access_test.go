@@ -0,0 +1,788 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryCollaborators(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListRepositoryCollaborators(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "list_repository_collaborators", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "affiliation")
+	assert.Contains(t, schema.Properties, "permission")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	mockUsers := []*github.User{
+		{
+			Login:       github.Ptr("octocat"),
+			ID:          github.Ptr(int64(1)),
+			HTMLURL:     github.Ptr("https://github.com/octocat"),
+			RoleName:    github.Ptr("admin"),
+			Permissions: map[string]bool{"admin": true, "push": true, "pull": true},
+		},
+		{
+			Login:    github.Ptr("hubot"),
+			ID:       github.Ptr(int64(2)),
+			RoleName: github.Ptr("write"),
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		mockedClient          *http.Client
+		requestArgs           map[string]interface{}
+		expectError           bool
+		expectedErrMsg        string
+		expectedCollaborators []MinimalCollaborator
+	}{
+		{
+			name: "lists collaborators with filters",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "direct",
+						"permission":  "push",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUsers),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"affiliation": "direct",
+				"permission":  "push",
+			},
+			expectedCollaborators: []MinimalCollaborator{
+				convertToMinimalCollaborator(mockUsers[0]),
+				convertToMinimalCollaborator(mockUsers[1]),
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have push access to view repository collaborators."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var collaborators []MinimalCollaborator
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &collaborators))
+			assert.Equal(t, tc.expectedCollaborators, collaborators)
+		})
+	}
+}
+
+func Test_RepositoryCollaboratorWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := RepositoryCollaboratorWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "repository_collaborator_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "username"})
+
+	mockInvitation := &github.CollaboratorInvitation{
+		ID:          github.Ptr(int64(99)),
+		Repo:        &github.Repository{FullName: github.Ptr("owner/repo")},
+		Invitee:     &github.User{Login: github.Ptr("octocat")},
+		Inviter:     &github.User{Login: github.Ptr("hubot")},
+		Permissions: github.Ptr("write"),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+	}
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedText       string
+		expectedInvitation *MinimalRepositoryInvitation
+	}{
+		{
+			name: "add sends invitation",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]interface{}{
+						"permission": "push",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockInvitation),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "add",
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "octocat",
+				"permission": "push",
+			},
+			expectedInvitation: &MinimalRepositoryInvitation{
+				ID:          99,
+				Repository:  "owner/repo",
+				Invitee:     "octocat",
+				Inviter:     "hubot",
+				Permissions: "write",
+				HTMLURL:     "https://github.com/owner/repo/invitations",
+			},
+		},
+		{
+			name: "update changes permission of existing collaborator",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(GetReposCollaboratorsByOwnerByRepoByUsername, noContent),
+				WithRequestMatchHandler(
+					PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]interface{}{
+						"permission": "maintain",
+					}).andThen(noContent),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "octocat",
+				"permission": "maintain",
+			},
+			expectedText: "permission of octocat on owner/repo set to maintain",
+		},
+		{
+			name: "update rejects non collaborator",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "octocat",
+				"permission": "maintain",
+			},
+			expectError:    true,
+			expectedErrMsg: "octocat is not a collaborator of owner/repo",
+		},
+		{
+			name:         "update requires permission",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method":   "update",
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "permission is required for update",
+		},
+		{
+			name: "remove collaborator",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteReposCollaboratorsByOwnerByRepoByUsername, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":   "remove",
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "octocat",
+			},
+			expectedText: "octocat removed from owner/repo",
+		},
+		{
+			name: "add fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":   "add",
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add collaborator",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedInvitation != nil {
+				var invitation MinimalRepositoryInvitation
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &invitation))
+				assert.Equal(t, *tc.expectedInvitation, invitation)
+				return
+			}
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListRepositoryInvitations(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "list_repository_invitations", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, schema.Required)
+
+	mockInvitations := []*github.RepositoryInvitation{
+		{
+			ID:          github.Ptr(int64(1)),
+			Repo:        &github.Repository{FullName: github.Ptr("owner/repo")},
+			Invitee:     &github.User{Login: github.Ptr("octocat")},
+			Inviter:     &github.User{Login: github.Ptr("hubot")},
+			Permissions: github.Ptr("read"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists repository invitations",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposInvitationsByOwnerByRepo, mockInvitations),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "lists invitations of the authenticated user",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetUserRepositoryInvitations, mockInvitations),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name:         "owner without repo",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner and repo must be provided together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var invitations []MinimalRepositoryInvitation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &invitations))
+			require.Len(t, invitations, 1)
+			assert.Equal(t, convertToMinimalRepositoryInvitation(mockInvitations[0]), invitations[0])
+		})
+	}
+}
+
+func Test_RepositoryInvitationWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := RepositoryInvitationWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "repository_invitation_write", tool.Name)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "invitation_id"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "cancel invitation",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteReposInvitationsByOwnerByRepoByInvitationID, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":        "cancel",
+				"owner":         "owner",
+				"repo":          "repo",
+				"invitation_id": float64(1),
+			},
+			expectedText: "invitation 1 cancelled",
+		},
+		{
+			name: "accept invitation",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(PatchUserRepositoryInvitationsByInvitationID, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":        "accept",
+				"invitation_id": float64(2),
+			},
+			expectedText: "invitation 2 accepted",
+		},
+		{
+			name: "decline invitation",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteUserRepositoryInvitationsByInvitationID, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":        "decline",
+				"invitation_id": float64(3),
+			},
+			expectedText: "invitation 3 declined",
+		},
+		{
+			name:         "cancel requires repository",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method":        "cancel",
+				"invitation_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: owner",
+		},
+		{
+			name: "accept fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchUserRepositoryInvitationsByInvitationID,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":        "accept",
+				"invitation_id": float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to accept invitation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListRepositoryTeams(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListRepositoryTeams(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_teams", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	mockTeams := []*github.Team{
+		{
+			ID:         github.Ptr(int64(1)),
+			Slug:       github.Ptr("maintainers"),
+			Name:       github.Ptr("Maintainers"),
+			Permission: github.Ptr("maintain"),
+		},
+	}
+
+	client := github.NewClient(NewMockedHTTPClient(
+		WithRequestMatch(GetReposTeamsByOwnerByRepo, mockTeams),
+	))
+	deps := BaseDeps{
+		Client: client,
+	}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var teams []MinimalRepositoryTeam
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &teams))
+	assert.Equal(t, []MinimalRepositoryTeam{{ID: 1, Slug: "maintainers", Name: "Maintainers", Permission: "maintain"}}, teams)
+}
+
+func Test_TeamRepositoryPermissionWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := TeamRepositoryPermissionWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "team_repository_permission_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "org", "team_slug", "owner", "repo"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "grant defaults to push",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"permission": "push",
+					}).andThen(noContent),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":    "grant",
+				"org":       "org",
+				"team_slug": "devs",
+				"owner":     "org",
+				"repo":      "repo",
+			},
+			expectedText: "team org/devs granted push on org/repo",
+		},
+		{
+			name: "revoke",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":    "revoke",
+				"org":       "org",
+				"team_slug": "devs",
+				"owner":     "org",
+				"repo":      "repo",
+			},
+			expectedText: "team org/devs no longer has access to org/repo",
+		},
+		{
+			name: "grant fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "grant",
+				"org":        "org",
+				"team_slug":  "devs",
+				"owner":      "org",
+				"repo":       "repo",
+				"permission": "admin",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to grant team access",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListPushAccess(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListPushAccess(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "list_push_access", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "username")
+	assert.Contains(t, schema.Properties, "after")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	mockPushAccessResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"collaborators": map[string]any{
+				"edges": []any{
+					map[string]any{
+						"permission": "ADMIN",
+						"permissionSources": []any{
+							map[string]any{
+								"permission": "ADMIN",
+								"source":     map[string]any{"__typename": "Repository", "nameWithOwner": "owner/repo"},
+							},
+							map[string]any{
+								"permission": "READ",
+								"source":     map[string]any{"__typename": "Organization", "login": "owner"},
+							},
+						},
+						"node": map[string]any{"login": "octocat"},
+					},
+					map[string]any{
+						"permission": "WRITE",
+						"permissionSources": []any{
+							map[string]any{
+								"permission": "WRITE",
+								"source":     map[string]any{"__typename": "Team", "combinedSlug": "owner/devs"},
+							},
+						},
+						"node": map[string]any{"login": "hubot"},
+					},
+					map[string]any{
+						"permission": "READ",
+						"permissionSources": []any{
+							map[string]any{
+								"permission": "READ",
+								"source":     map[string]any{"__typename": "Organization", "login": "owner"},
+							},
+						},
+						"node": map[string]any{"login": "reader"},
+					},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "start",
+					"endCursor":       "end",
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		variables       map[string]any
+		requestArgs     map[string]any
+		expectedUsers   []PushAccessUser
+		expectedMessage string
+	}{
+		{
+			name: "lists users with push access",
+			variables: map[string]any{
+				"owner":    githubv4.String("owner"),
+				"name":     githubv4.String("repo"),
+				"username": (*githubv4.String)(nil),
+				"first":    githubv4.Int(30),
+				"after":    (*githubv4.String)(nil),
+			},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedUsers: []PushAccessUser{
+				{
+					Login:      "octocat",
+					Permission: "ADMIN",
+					Sources:    []PushAccessSource{{Type: "Repository", Name: "owner/repo", Permission: "ADMIN"}},
+				},
+				{
+					Login:      "hubot",
+					Permission: "WRITE",
+					Sources:    []PushAccessSource{{Type: "Team", Name: "owner/devs", Permission: "WRITE"}},
+				},
+			},
+		},
+		{
+			name: "reports when user cannot push",
+			variables: map[string]any{
+				"owner":    githubv4.String("owner"),
+				"name":     githubv4.String("repo"),
+				"username": githubv4.String("reader"),
+				"first":    githubv4.Int(30),
+				"after":    (*githubv4.String)(nil),
+			},
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "reader",
+			},
+			expectedUsers:   []PushAccessUser{},
+			expectedMessage: "reader cannot push to owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(pushAccessQuery{}, tc.variables, mockPushAccessResponse)
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher)),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var response struct {
+				Users    []PushAccessUser `json:"users"`
+				Message  string           `json:"message"`
+				PageInfo struct {
+					EndCursor string `json:"endCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedUsers, response.Users)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			assert.Equal(t, "end", response.PageInfo.EndCursor)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/go-github/v79/github"
+)
+
+// Outcomes of one entry of a bulk operation.
+const (
+	bulkStatusPlanned = "planned"
+	bulkStatusUpdated = "updated"
+	bulkStatusFailed  = "failed"
+	bulkStatusSkipped = "skipped"
+)
+
+// bulkMinRateRemaining stops starting new entries of a bulk operation once fewer REST API
+// requests remain in the rate limit window, so that the user is not locked out for the rest of
+// the window.
+const bulkMinRateRemaining = 50
+
+// bulkOutcome is the outcome of one entry of a bulk operation.
+type bulkOutcome struct {
+	Status string
+	Error  string
+}
+
+// bulkRun is the outcome of every entry of a bulk operation, in entry order, and how many
+// entries ended with each outcome.
+type bulkRun struct {
+	Outcomes  []bulkOutcome
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// runBulk calls apply for the entries 0 to n-1, at most concurrency at a time. apply passes the
+// response and error of every REST API call to checkRateLimit. Once the rate limit is exhausted
+// or nearly so, the entries that have not started yet are skipped.
+func runBulk(n, concurrency int, apply func(i int, checkRateLimit func(*github.Response, error)) error) bulkRun {
+	run := bulkRun{Outcomes: make([]bulkOutcome, n)}
+
+	var rateLimited atomic.Bool
+	// checkRateLimit records when the rate limit is exhausted or about to be.
+	checkRateLimit := func(resp *github.Response, err error) {
+		var rateLimitErr *github.RateLimitError
+		var abuseErr *github.AbuseRateLimitError
+		if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+			rateLimited.Store(true)
+			return
+		}
+		if resp != nil && resp.Rate.Limit > 0 && resp.Rate.Remaining < bulkMinRateRemaining {
+			rateLimited.Store(true)
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range run.Outcomes {
+		outcome := &run.Outcomes[i]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if rateLimited.Load() {
+				outcome.Status = bulkStatusSkipped
+				outcome.Error = "not changed because the API rate limit is exhausted or nearly exhausted; retry later"
+				return
+			}
+			if err := apply(i, checkRateLimit); err != nil {
+				outcome.Status = bulkStatusFailed
+				outcome.Error = err.Error()
+				return
+			}
+			outcome.Status = bulkStatusUpdated
+		}()
+	}
+	wg.Wait()
+
+	for _, outcome := range run.Outcomes {
+		switch outcome.Status {
+		case bulkStatusUpdated:
+			run.Succeeded++
+		case bulkStatusFailed:
+			run.Failed++
+		case bulkStatusSkipped:
+			run.Skipped++
+		}
+	}
+	return run
+}
@@ -0,0 +1,33 @@
+package github
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_runBulk(t *testing.T) {
+	// With one entry at a time, the response of entry 1 leaves too few requests for entry 2.
+	run := runBulk(3, 1, func(i int, checkRateLimit func(*github.Response, error)) error {
+		switch i {
+		case 0:
+			return errors.New("boom")
+		case 1:
+			checkRateLimit(&github.Response{Rate: github.Rate{Limit: 5000, Remaining: bulkMinRateRemaining - 1}}, nil)
+		default:
+			t.Errorf("entry %d should have been skipped", i)
+		}
+		return nil
+	})
+
+	assert.Equal(t, []bulkOutcome{
+		{Status: bulkStatusFailed, Error: "boom"},
+		{Status: bulkStatusUpdated},
+		{Status: bulkStatusSkipped, Error: "not changed because the API rate limit is exhausted or nearly exhausted; retry later"},
+	}, run.Outcomes)
+	assert.Equal(t, 1, run.Succeeded)
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, 1, run.Skipped)
+}
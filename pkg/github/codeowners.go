@@ -0,0 +1,285 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// codeownersPaths are the locations GitHub looks for a CODEOWNERS file in, in order of precedence.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a line of a CODEOWNERS file.
+type codeownersRule struct {
+	pattern string
+	owners  []string
+	line    int
+	re      *regexp.Regexp
+}
+
+// parseCodeowners parses the rules of a CODEOWNERS file. Lines GitHub considers invalid are
+// skipped, just like GitHub ignores them; use the CODEOWNERS errors API to report them.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, rest := splitCodeownersPattern(line)
+		re, err := codeownersPatternRegexp(pattern)
+		if err != nil {
+			continue
+		}
+		rule := codeownersRule{pattern: pattern, line: i + 1, re: re}
+		for _, owner := range strings.Fields(rest) {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			rule.owners = append(rule.owners, owner)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// splitCodeownersPattern splits a CODEOWNERS line into its pattern, where a backslash escapes the
+// next character, and the rest of the line.
+func splitCodeownersPattern(line string) (string, string) {
+	var pattern strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line):
+			i++
+			pattern.WriteByte(line[i])
+		case c == ' ' || c == '\t':
+			return pattern.String(), line[i:]
+		default:
+			pattern.WriteByte(c)
+		}
+	}
+	return pattern.String(), ""
+}
+
+// codeownersPatternRegexp converts a CODEOWNERS pattern to a regular expression matching file
+// paths. Patterns follow the gitignore rules, except that negation and character ranges are not
+// supported and a trailing "/*" only matches the files directly in a directory.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, errors.New("negated patterns are not supported")
+	}
+	if strings.ContainsAny(pattern, "[]") {
+		return nil, errors.New("character ranges are not supported")
+	}
+
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil, errors.New("empty pattern")
+	}
+	// Patterns containing a slash other than a trailing one are relative to the repository root.
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	directory := strings.HasSuffix(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				expr.WriteString(".*")
+			} else {
+				expr.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		for _, c := range segment {
+			switch c {
+			case '*':
+				expr.WriteString("[^/]*")
+			case '?':
+				expr.WriteString("[^/]")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		}
+		if !last {
+			expr.WriteString("/")
+		}
+	}
+
+	last := segments[len(segments)-1]
+	switch {
+	case last == "**" || (last == "*" && anchored):
+		expr.WriteString("$")
+	case directory:
+		expr.WriteString("/.*$")
+	default:
+		// A pattern matching a directory also matches everything in it.
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
+
+// matchCodeowners returns the rule that decides the owners of a file, which is the last matching
+// rule, or nil if no rule matches.
+func matchCodeowners(rules []codeownersRule, path string) *codeownersRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(path) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// CodeOwnersRule is a CODEOWNERS rule with the changed files it decides the owners of.
+type CodeOwnersRule struct {
+	Pattern string   `json:"pattern"`
+	Line    int      `json:"line"`
+	Owners  []string `json:"owners"`
+	Files   []string `json:"files"`
+}
+
+// PullRequestCodeOwners are the code owners of the files changed by a pull request.
+type PullRequestCodeOwners struct {
+	Path          string                    `json:"codeowners_path"`
+	Ref           string                    `json:"ref"`
+	Message       string                    `json:"message,omitempty"`
+	Owners        []string                  `json:"owners"`
+	Reviewers     []string                  `json:"reviewers"`
+	TeamReviewers []string                  `json:"team_reviewers"`
+	Rules         []CodeOwnersRule          `json:"rules"`
+	UnownedFiles  []string                  `json:"unowned_files,omitempty"`
+	Errors        []*github.CodeownersError `json:"errors,omitempty"`
+}
+
+// addReviewer adds a code owner to the reviewers or team reviewers that can be requested. The
+// author of a pull request cannot review it, teams of other organizations cannot be requested
+// and neither can owners identified by email address.
+func (c *PullRequestCodeOwners) addReviewer(owner, repoOwner, author string) {
+	name, ok := strings.CutPrefix(owner, "@")
+	if !ok {
+		return
+	}
+	if org, team, isTeam := strings.Cut(name, "/"); isTeam {
+		if strings.EqualFold(org, repoOwner) && !slices.Contains(c.TeamReviewers, team) {
+			c.TeamReviewers = append(c.TeamReviewers, team)
+		}
+		return
+	}
+	if !strings.EqualFold(name, author) && !slices.Contains(c.Reviewers, name) {
+		c.Reviewers = append(c.Reviewers, name)
+	}
+}
+
+// getCodeownersFile returns the path and content of the CODEOWNERS file in effect at a ref, or
+// an empty path if there is none.
+func getCodeownersFile(ctx context.Context, client *github.Client, owner, repo, ref string) (string, string, *github.Response, error) {
+	for _, path := range codeownersPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return "", "", resp, err
+		}
+		_ = resp.Body.Close()
+		if file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return "", "", resp, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, content, resp, nil
+	}
+	return "", "", nil, nil
+}
+
+// GetPullRequestCodeOwners resolves the code owners of every file changed by a pull request from
+// the CODEOWNERS file of its base branch.
+func GetPullRequestCodeOwners(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+	}
+	_ = resp.Body.Close()
+	ref := pr.GetBase().GetRef()
+
+	result := PullRequestCodeOwners{
+		Ref:           ref,
+		Owners:        []string{},
+		Reviewers:     []string{},
+		TeamReviewers: []string{},
+		Rules:         []CodeOwnersRule{},
+	}
+	path, content, resp, err := getCodeownersFile(ctx, client, owner, repo, ref)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS file", resp, err), nil
+	}
+	if path == "" {
+		result.Message = fmt.Sprintf("No CODEOWNERS file found in .github/, the repository root or docs/ on %s.", ref)
+		return utils.NewToolResultJSON(result)
+	}
+	result.Path = path
+
+	codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS errors", resp, err), nil
+	}
+	_ = resp.Body.Close()
+	result.Errors = codeownersErrors.Errors
+
+	rules := parseCodeowners(content)
+	ruleIndex := make(map[int]int)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		for _, file := range files {
+			rule := matchCodeowners(rules, file.GetFilename())
+			if rule == nil || len(rule.owners) == 0 {
+				result.UnownedFiles = append(result.UnownedFiles, file.GetFilename())
+				continue
+			}
+			i, ok := ruleIndex[rule.line]
+			if !ok {
+				i = len(result.Rules)
+				ruleIndex[rule.line] = i
+				result.Rules = append(result.Rules, CodeOwnersRule{Pattern: rule.pattern, Line: rule.line, Owners: rule.owners})
+				for _, o := range rule.owners {
+					if !slices.Contains(result.Owners, o) {
+						result.Owners = append(result.Owners, o)
+					}
+					result.addReviewer(o, owner, pr.GetUser().GetLogin())
+				}
+			}
+			result.Rules[i].Files = append(result.Rules[i].Files, file.GetFilename())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return utils.NewToolResultJSON(result)
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CodeownersPatternRegexp(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		matches    []string
+		nonMatches []string
+	}{
+		{
+			pattern: "*",
+			matches: []string{"README.md", "src/main.go"},
+		},
+		{
+			pattern:    "*.js",
+			matches:    []string{"app.js", "src/lib/app.js"},
+			nonMatches: []string{"app.jsx", "app.ts"},
+		},
+		{
+			pattern:    "/build/logs/",
+			matches:    []string{"build/logs/a.log", "build/logs/deep/b.log"},
+			nonMatches: []string{"build/logs", "src/build/logs/a.log"},
+		},
+		{
+			pattern:    "docs/*",
+			matches:    []string{"docs/getting-started.md"},
+			nonMatches: []string{"docs/build-app/troubleshooting.md", "src/docs/a.md"},
+		},
+		{
+			pattern:    "apps/",
+			matches:    []string{"apps/a.go", "src/apps/b/c.go"},
+			nonMatches: []string{"apps", "myapps/a.go"},
+		},
+		{
+			pattern:    "/docs",
+			matches:    []string{"docs", "docs/a.md", "docs/sub/b.md"},
+			nonMatches: []string{"src/docs/a.md", "docs.md"},
+		},
+		{
+			pattern:    "**/logs",
+			matches:    []string{"logs/a", "build/logs/a", "deeply/nested/logs/b/c"},
+			nonMatches: []string{"build/logsx"},
+		},
+		{
+			pattern:    "src/**/test.go",
+			matches:    []string{"src/test.go", "src/a/b/test.go"},
+			nonMatches: []string{"other/src/test.go"},
+		},
+		{
+			pattern:    "src/**",
+			matches:    []string{"src/a", "src/a/b"},
+			nonMatches: []string{"src", "lib/src/a"},
+		},
+		{
+			pattern:    "file?.txt",
+			matches:    []string{"file1.txt", "dir/fileA.txt"},
+			nonMatches: []string{"file.txt", "file10.txt"},
+		},
+		{
+			pattern: "a+b(c).md",
+			matches: []string{"a+b(c).md"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern, func(t *testing.T) {
+			re, err := codeownersPatternRegexp(tc.pattern)
+			require.NoError(t, err)
+			for _, path := range tc.matches {
+				assert.True(t, re.MatchString(path), "%s should match %s", tc.pattern, path)
+			}
+			for _, path := range tc.nonMatches {
+				assert.False(t, re.MatchString(path), "%s should not match %s", tc.pattern, path)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"!docs", "file[0-9].txt", "/"} {
+		_, err := codeownersPatternRegexp(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func Test_ParseCodeowners(t *testing.T) {
+	content := "# Default owners\n" +
+		"*       @octo-org/core\n" +
+		"\n" +
+		"*.go    @gopher dev@example.com # Go code\n" +
+		"!vendor @nobody\n" +
+		"/docs/  @octo-org/docs @writer\n" +
+		"/docs/generated/\n" +
+		"my\\ file.txt @spaces\n"
+
+	rules := parseCodeowners(content)
+	require.Len(t, rules, 5)
+
+	assert.Equal(t, "*", rules[0].pattern)
+	assert.Equal(t, 2, rules[0].line)
+	assert.Equal(t, []string{"@gopher", "dev@example.com"}, rules[1].owners)
+	assert.Equal(t, 6, rules[2].line)
+	assert.Empty(t, rules[3].owners)
+	assert.Equal(t, "my file.txt", rules[4].pattern)
+
+	owners := func(path string) []string {
+		rule := matchCodeowners(rules, path)
+		if rule == nil {
+			return nil
+		}
+		return rule.owners
+	}
+	assert.Equal(t, []string{"@octo-org/core"}, owners("README.md"))
+	assert.Equal(t, []string{"@gopher", "dev@example.com"}, owners("cmd/main.go"))
+	assert.Equal(t, []string{"@octo-org/docs", "@writer"}, owners("docs/main.go"))
+	assert.Empty(t, owners("docs/generated/api.md"))
+	assert.Equal(t, []string{"@spaces"}, owners("my file.txt"))
+
+	assert.Nil(t, matchCodeowners(parseCodeowners("/src/ @a"), "README.md"))
+}
+
+func Test_PullRequestCodeOwnersAddReviewer(t *testing.T) {
+	var c PullRequestCodeOwners
+	for _, owner := range []string{"@octocat", "@OCTO-ORG/core", "@other-org/team", "@author", "dev@example.com", "@octocat", "@octo-org/core"} {
+		c.addReviewer(owner, "octo-org", "Author")
+	}
+	assert.Equal(t, []string{"octocat"}, c.Reviewers)
+	assert.Equal(t, []string{"core"}, c.TeamReviewers)
+}
@@ -0,0 +1,298 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// copilotReviewerLogin is the login name of the Copilot code review bot.
+const copilotReviewerLogin = "copilot-pull-request-reviewer[bot]"
+
+const (
+	// defaultCopilotReviewTimeout is how long to wait for a Copilot review by default. Copilot
+	// usually takes a few minutes to review a pull request.
+	defaultCopilotReviewTimeout = 300
+	maxCopilotReviewTimeout     = 600
+	copilotReviewPollDelay      = 10 * time.Second
+)
+
+// isCopilotReviewer reports whether a login is the Copilot code review bot. In review requests
+// the bot is listed as "Copilot".
+func isCopilotReviewer(login string) bool {
+	return strings.EqualFold(login, copilotReviewerLogin) || strings.EqualFold(login, "Copilot")
+}
+
+// copilotReviewPollConfig returns the polling configuration from context, or polls every
+// copilotReviewPollDelay until the timeout.
+func copilotReviewPollConfig(ctx context.Context, timeout int) PollConfig {
+	if config, ok := ctx.Value(pollConfigKey{}).(PollConfig); ok {
+		return config
+	}
+	return PollConfig{
+		MaxAttempts: int(time.Duration(timeout)*time.Second/copilotReviewPollDelay) + 1,
+		Delay:       copilotReviewPollDelay,
+	}
+}
+
+// CopilotReviewComment is an inline comment of a Copilot review.
+type CopilotReviewComment struct {
+	Line      int    `json:"line,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	Body      string `json:"body"`
+	URL       string `json:"url"`
+}
+
+// CopilotReviewFile groups the inline comments of a Copilot review on one file.
+type CopilotReviewFile struct {
+	Path     string                 `json:"path"`
+	Comments []CopilotReviewComment `json:"comments"`
+}
+
+// CopilotReview is a Copilot review of a pull request with its inline comments grouped by file.
+type CopilotReview struct {
+	ID          int64               `json:"id"`
+	State       string              `json:"state"`
+	Body        string              `json:"body"`
+	CommitID    string              `json:"commit_id"`
+	SubmittedAt string              `json:"submitted_at"`
+	URL         string              `json:"url"`
+	Files       []CopilotReviewFile `json:"files"`
+}
+
+// findCopilotReview returns the latest Copilot review of a pull request, or nil if Copilot has
+// not reviewed it yet or if a new Copilot review was requested and is still pending. requested
+// reports whether a Copilot review is pending.
+func findCopilotReview(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (review *github.PullRequestReview, requested bool, resp *github.Response, err error) {
+	requested, resp, err = isCopilotReviewRequested(ctx, client, owner, repo, pullNumber)
+	if err != nil || requested {
+		return nil, requested, resp, err
+	}
+
+	var latest *github.PullRequestReview
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, false, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, review := range reviews {
+			if isCopilotReviewer(review.GetUser().GetLogin()) {
+				latest = review
+			}
+		}
+		if resp.NextPage == 0 {
+			return latest, false, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// isCopilotReviewRequested reports whether Copilot is among the requested reviewers of a pull
+// request.
+func isCopilotReviewRequested(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (bool, *github.Response, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviewers, resp, err := client.PullRequests.ListReviewers(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return false, resp, err
+		}
+		_ = resp.Body.Close()
+		if slices.ContainsFunc(reviewers.Users, func(u *github.User) bool { return isCopilotReviewer(u.GetLogin()) }) {
+			return true, resp, nil
+		}
+		if resp.NextPage == 0 {
+			return false, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// getCopilotReviewComments returns the inline comments of a review grouped by file.
+func getCopilotReviewComments(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, reviewID int64) ([]CopilotReviewFile, *github.Response, error) {
+	files := []CopilotReviewFile{}
+	fileIndex := make(map[string]int)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		comments, resp, err := client.PullRequests.ListReviewComments(ctx, owner, repo, pullNumber, reviewID, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, comment := range comments {
+			i, ok := fileIndex[comment.GetPath()]
+			if !ok {
+				i = len(files)
+				fileIndex[comment.GetPath()] = i
+				files = append(files, CopilotReviewFile{Path: comment.GetPath()})
+			}
+			files[i].Comments = append(files[i].Comments, CopilotReviewComment{
+				Line:      comment.GetLine(),
+				StartLine: comment.GetStartLine(),
+				Side:      comment.GetSide(),
+				Body:      comment.GetBody(),
+				URL:       comment.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return files, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetCopilotReview creates a tool to wait for and retrieve the Copilot review of a pull request.
+func GetCopilotReview(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"pullNumber": {
+				Type:        "number",
+				Description: "Pull request number",
+			},
+			"timeout": {
+				Type:        "number",
+				Description: fmt.Sprintf("Maximum number of seconds to wait for Copilot to submit its review (default %d, maximum %d). Use 0 to check once without waiting.", defaultCopilotReviewTimeout, maxCopilotReviewTimeout),
+				Minimum:     jsonschema.Ptr(0.0),
+				Maximum:     jsonschema.Ptr(float64(maxCopilotReviewTimeout)),
+			},
+		},
+		Required: []string{"owner", "repo", "pullNumber"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "get_copilot_review",
+			Description: t("TOOL_GET_COPILOT_REVIEW_DESCRIPTION", "Wait for the GitHub Copilot code review of a pull request requested with 'request_copilot_review' and get it once it is submitted: the review body and its inline comments grouped by file. Returns the latest Copilot review if no review is pending."),
+			Icons:       octicons.Icons("copilot"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_COPILOT_REVIEW_USER_TITLE", "Get Copilot review"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, request *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			timeout := defaultCopilotReviewTimeout
+			if _, ok := args["timeout"]; ok {
+				timeout, err = OptionalIntParam(args, "timeout")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+			if timeout < 0 || timeout > maxCopilotReviewTimeout {
+				return utils.NewToolResultError(fmt.Sprintf("timeout must be between 0 and %d seconds", maxCopilotReviewTimeout)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			pollConfig := PollConfig{MaxAttempts: 1}
+			if timeout > 0 {
+				pollConfig = copilotReviewPollConfig(ctx, timeout)
+			}
+
+			// Get progress token from request for sending progress notifications
+			progressToken := request.Params.GetProgressToken()
+
+			var review *github.PullRequestReview
+			for attempt := range pollConfig.MaxAttempts {
+				if attempt > 0 {
+					select {
+					case <-ctx.Done():
+						return utils.NewToolResultErrorFromErr("stopped waiting for the Copilot review", ctx.Err()), nil, nil
+					case <-time.After(pollConfig.Delay):
+					}
+				}
+
+				// Send progress notification if progress token is available
+				if progressToken != nil && request.Session != nil && pollConfig.MaxAttempts > 1 {
+					_ = request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+						ProgressToken: progressToken,
+						Progress:      float64(attempt + 1),
+						Total:         float64(pollConfig.MaxAttempts),
+						Message:       fmt.Sprintf("Waiting for Copilot to review the pull request... (attempt %d/%d)", attempt+1, pollConfig.MaxAttempts),
+					})
+				}
+
+				var requested bool
+				var resp *github.Response
+				review, requested, resp, err = findCopilotReview(ctx, client, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Copilot review", resp, err), nil, nil
+				}
+				if review != nil {
+					break
+				}
+				// Without a pending request no review will arrive, so there is nothing to wait for.
+				if !requested && attempt == 0 {
+					result, err := utils.NewToolResultJSON(map[string]any{
+						"status":  "not_requested",
+						"message": "No Copilot review was requested for this pull request. Use request_copilot_review to request one.",
+					})
+					return result, nil, err
+				}
+			}
+
+			if review == nil {
+				result, err := utils.NewToolResultJSON(map[string]any{
+					"status":  "pending",
+					"message": "Copilot has not submitted its review yet. Call get_copilot_review again later.",
+				})
+				return result, nil, err
+			}
+
+			files, resp, err := getCopilotReviewComments(ctx, client, owner, repo, pullNumber, review.GetID())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Copilot review comments", resp, err), nil, nil
+			}
+
+			result, err := utils.NewToolResultJSON(map[string]any{
+				"status": "submitted",
+				"review": CopilotReview{
+					ID:          review.GetID(),
+					State:       review.GetState(),
+					Body:        review.GetBody(),
+					CommitID:    review.GetCommitID(),
+					SubmittedAt: review.GetSubmittedAt().Format(time.RFC3339),
+					URL:         review.GetHTMLURL(),
+					Files:       files,
+				},
+			})
+			return result, nil, err
+		},
+	)
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCopilotReview(t *testing.T) {
+	serverTool := GetCopilotReview(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_review", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "timeout")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	submittedAt := github.Timestamp{Time: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}
+	reviews := []*github.PullRequestReview{
+		{ID: github.Ptr(int64(1)), User: &github.User{Login: github.Ptr("octocat")}, State: github.Ptr("APPROVED")},
+		{
+			ID:          github.Ptr(int64(10)),
+			User:        &github.User{Login: github.Ptr("copilot-pull-request-reviewer[bot]")},
+			State:       github.Ptr("COMMENTED"),
+			Body:        github.Ptr("Copilot reviewed 2 out of 2 changed files."),
+			CommitID:    github.Ptr("abc123"),
+			SubmittedAt: &submittedAt,
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-10"),
+		},
+	}
+	reviewComments := []*github.PullRequestComment{
+		{Path: github.Ptr("main.go"), Line: github.Ptr(3), Side: github.Ptr("RIGHT"), Body: github.Ptr("Handle the error."), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r1")},
+		{Path: github.Ptr("README.md"), Line: github.Ptr(8), StartLine: github.Ptr(6), Side: github.Ptr("RIGHT"), Body: github.Ptr("Typo."), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r2")},
+		{Path: github.Ptr("main.go"), Line: github.Ptr(10), Side: github.Ptr("RIGHT"), Body: github.Ptr("Unused variable."), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r3")},
+	}
+	copilotRequested := &github.Reviewers{Users: []*github.User{{Login: github.Ptr("Copilot")}}}
+
+	expectedReview := map[string]any{
+		"id":           float64(10),
+		"state":        "COMMENTED",
+		"body":         "Copilot reviewed 2 out of 2 changed files.",
+		"commit_id":    "abc123",
+		"submitted_at": "2024-01-02T15:04:05Z",
+		"url":          "https://github.com/owner/repo/pull/42#pullrequestreview-10",
+		"files": []any{
+			map[string]any{
+				"path": "main.go",
+				"comments": []any{
+					map[string]any{"line": float64(3), "side": "RIGHT", "body": "Handle the error.", "url": "https://github.com/owner/repo/pull/42#discussion_r1"},
+					map[string]any{"line": float64(10), "side": "RIGHT", "body": "Unused variable.", "url": "https://github.com/owner/repo/pull/42#discussion_r3"},
+				},
+			},
+			map[string]any{
+				"path": "README.md",
+				"comments": []any{
+					map[string]any{"line": float64(8), "start_line": float64(6), "side": "RIGHT", "body": "Typo.", "url": "https://github.com/owner/repo/pull/42#discussion_r2"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		pendingChecks      int32
+		withoutReview      bool
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedStatus     string
+		expectedChecks     int32
+	}{
+		{
+			name:           "review already submitted",
+			requestArgs:    map[string]any{},
+			expectedStatus: "submitted",
+			expectedChecks: 1,
+		},
+		{
+			name:           "waits until the review is submitted",
+			requestArgs:    map[string]any{},
+			pendingChecks:  2,
+			expectedStatus: "submitted",
+			expectedChecks: 3,
+		},
+		{
+			name:           "review still pending after waiting",
+			requestArgs:    map[string]any{},
+			pendingChecks:  10,
+			expectedStatus: "pending",
+			expectedChecks: 5,
+		},
+		{
+			name:           "timeout of zero checks once",
+			requestArgs:    map[string]any{"timeout": float64(0)},
+			pendingChecks:  10,
+			expectedStatus: "pending",
+			expectedChecks: 1,
+		},
+		{
+			name:           "no review requested",
+			requestArgs:    map[string]any{},
+			withoutReview:  true,
+			expectedStatus: "not_requested",
+			expectedChecks: 1,
+		},
+		{
+			name:               "timeout too long",
+			requestArgs:        map[string]any{"timeout": float64(3600)},
+			expectToolError:    true,
+			expectedToolErrMsg: "timeout must be between 0 and 600 seconds",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var checks atomic.Int32
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber: func(w http.ResponseWriter, r *http.Request) {
+					if checks.Add(1) <= tc.pendingChecks {
+						mockResponse(t, http.StatusOK, copilotRequested)(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.Reviewers{})(w, r)
+				},
+				GetReposPullsReviewsByOwnerByRepoByPullNumber: func(w http.ResponseWriter, r *http.Request) {
+					if tc.withoutReview {
+						mockResponse(t, http.StatusOK, reviews[:1])(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, reviews)(w, r)
+				},
+				GetReposPullsReviewsCommentsByOwnerByRepoByPullNumberByReviewID: expectPath(t, "/repos/owner/repo/pulls/42/reviews/10/comments").andThen(
+					mockResponse(t, http.StatusOK, reviewComments),
+				),
+			}))
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			args := map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			ctx := ContextWithPollConfig(context.Background(), PollConfig{MaxAttempts: 5})
+			result, err := handler(ContextWithDeps(ctx, deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedToolErrMsg, textContent.Text)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedChecks, checks.Load())
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedStatus, response["status"])
+			switch tc.expectedStatus {
+			case "submitted":
+				assert.Equal(t, expectedReview, response["review"])
+			case "not_requested":
+				assert.Contains(t, response["message"], "No Copilot review was requested")
+			default:
+				assert.Contains(t, response["message"], "Copilot has not submitted its review yet")
+			}
+		})
+	}
+}
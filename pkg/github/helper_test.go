@@ -21,23 +21,91 @@ const (
 	// User endpoints
 	GetUser                        = "GET /user"
 	GetUserStarred                 = "GET /user/starred"
+	GetUsersByUsername             = "GET /users/{username}"
 	GetUsersGistsByUsername        = "GET /users/{username}/gists"
 	GetUsersStarredByUsername      = "GET /users/{username}/starred"
 	PutUserStarredByOwnerByRepo    = "PUT /user/starred/{owner}/{repo}"
 	DeleteUserStarredByOwnerByRepo = "DELETE /user/starred/{owner}/{repo}"
 
 	// Repository endpoints
-	GetReposByOwnerByRepo                = "GET /repos/{owner}/{repo}"
-	GetReposBranchesByOwnerByRepo        = "GET /repos/{owner}/{repo}/branches"
-	GetReposTagsByOwnerByRepo            = "GET /repos/{owner}/{repo}/tags"
-	GetReposCommitsByOwnerByRepo         = "GET /repos/{owner}/{repo}/commits"
-	GetReposCommitsByOwnerByRepoByRef    = "GET /repos/{owner}/{repo}/commits/{ref}"
-	GetReposContentsByOwnerByRepoByPath  = "GET /repos/{owner}/{repo}/contents/{path}"
-	PutReposContentsByOwnerByRepoByPath  = "PUT /repos/{owner}/{repo}/contents/{path}"
-	PostReposForksByOwnerByRepo          = "POST /repos/{owner}/{repo}/forks"
-	GetReposSubscriptionByOwnerByRepo    = "GET /repos/{owner}/{repo}/subscription"
-	PutReposSubscriptionByOwnerByRepo    = "PUT /repos/{owner}/{repo}/subscription"
-	DeleteReposSubscriptionByOwnerByRepo = "DELETE /repos/{owner}/{repo}/subscription"
+	GetReposByOwnerByRepo                  = "GET /repos/{owner}/{repo}"
+	GetReposBranchesByOwnerByRepo          = "GET /repos/{owner}/{repo}/branches"
+	GetReposTagsByOwnerByRepo              = "GET /repos/{owner}/{repo}/tags"
+	GetReposCommitsByOwnerByRepo           = "GET /repos/{owner}/{repo}/commits"
+	GetReposCommitsByOwnerByRepoByRef      = "GET /repos/{owner}/{repo}/commits/{ref}"
+	GetReposCompareByOwnerByRepoByBasehead = "GET /repos/{owner}/{repo}/compare/{basehead}"
+	GetReposContentsByOwnerByRepoByPath    = "GET /repos/{owner}/{repo}/contents/{path}"
+	PutReposContentsByOwnerByRepoByPath    = "PUT /repos/{owner}/{repo}/contents/{path}"
+	GetReposCodeownersErrorsByOwnerByRepo  = "GET /repos/{owner}/{repo}/codeowners/errors"
+	PostReposForksByOwnerByRepo            = "POST /repos/{owner}/{repo}/forks"
+	GetReposSubscriptionByOwnerByRepo      = "GET /repos/{owner}/{repo}/subscription"
+	PutReposSubscriptionByOwnerByRepo      = "PUT /repos/{owner}/{repo}/subscription"
+	DeleteReposSubscriptionByOwnerByRepo   = "DELETE /repos/{owner}/{repo}/subscription"
+
+	// Repository administration endpoints
+	PatchReposByOwnerByRepo                        = "PATCH /repos/{owner}/{repo}"
+	PutReposTopicsByOwnerByRepo                    = "PUT /repos/{owner}/{repo}/topics"
+	PostReposTransferByOwnerByRepo                 = "POST /repos/{owner}/{repo}/transfer"
+	PostReposGenerateByTemplateOwnerByTemplateRepo = "POST /repos/{template_owner}/{template_repo}/generate"
+
+	// Ruleset and branch protection endpoints
+	GetReposRulesBranchesByOwnerByRepoByBranch         = "GET /repos/{owner}/{repo}/rules/branches/{branch}"
+	GetReposRulesetsByOwnerByRepo                      = "GET /repos/{owner}/{repo}/rulesets"
+	PostReposRulesetsByOwnerByRepo                     = "POST /repos/{owner}/{repo}/rulesets"
+	GetReposRulesetsByOwnerByRepoByRulesetID           = "GET /repos/{owner}/{repo}/rulesets/{ruleset_id}"
+	PutReposRulesetsByOwnerByRepoByRulesetID           = "PUT /repos/{owner}/{repo}/rulesets/{ruleset_id}"
+	DeleteReposRulesetsByOwnerByRepoByRulesetID        = "DELETE /repos/{owner}/{repo}/rulesets/{ruleset_id}"
+	GetOrgsRulesetsByOrg                               = "GET /orgs/{org}/rulesets"
+	GetOrgsRulesetsByOrgByRulesetID                    = "GET /orgs/{org}/rulesets/{ruleset_id}"
+	GetReposBranchesProtectionByOwnerByRepoByBranch    = "GET /repos/{owner}/{repo}/branches/{branch}/protection"
+	PutReposBranchesProtectionByOwnerByRepoByBranch    = "PUT /repos/{owner}/{repo}/branches/{branch}/protection"
+	DeleteReposBranchesProtectionByOwnerByRepoByBranch = "DELETE /repos/{owner}/{repo}/branches/{branch}/protection"
+
+	// Repository access endpoints
+	GetReposCollaboratorsByOwnerByRepo                = "GET /repos/{owner}/{repo}/collaborators"
+	GetReposCollaboratorsByOwnerByRepoByUsername      = "GET /repos/{owner}/{repo}/collaborators/{username}"
+	PutReposCollaboratorsByOwnerByRepoByUsername      = "PUT /repos/{owner}/{repo}/collaborators/{username}"
+	DeleteReposCollaboratorsByOwnerByRepoByUsername   = "DELETE /repos/{owner}/{repo}/collaborators/{username}"
+	GetReposInvitationsByOwnerByRepo                  = "GET /repos/{owner}/{repo}/invitations"
+	DeleteReposInvitationsByOwnerByRepoByInvitationID = "DELETE /repos/{owner}/{repo}/invitations/{invitation_id}"
+	GetUserRepositoryInvitations                      = "GET /user/repository_invitations"
+	PatchUserRepositoryInvitationsByInvitationID      = "PATCH /user/repository_invitations/{invitation_id}"
+	DeleteUserRepositoryInvitationsByInvitationID     = "DELETE /user/repository_invitations/{invitation_id}"
+	GetReposTeamsByOwnerByRepo                        = "GET /repos/{owner}/{repo}/teams"
+	PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo     = "PUT /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}"
+	DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo  = "DELETE /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}"
+
+	// Organization management endpoints
+	GetOrgsOutsideCollaboratorsByOrg                    = "GET /orgs/{org}/outside_collaborators"
+	PostOrgsInvitationsByOrg                            = "POST /orgs/{org}/invitations"
+	PutOrgsMembershipsByOrgByUsername                   = "PUT /orgs/{org}/memberships/{username}"
+	DeleteOrgsMembershipsByOrgByUsername                = "DELETE /orgs/{org}/memberships/{username}"
+	GetOrgsTeamsByOrg                                   = "GET /orgs/{org}/teams"
+	PostOrgsTeamsByOrg                                  = "POST /orgs/{org}/teams"
+	GetOrgsTeamsByOrgByTeamSlug                         = "GET /orgs/{org}/teams/{team_slug}"
+	PatchOrgsTeamsByOrgByTeamSlug                       = "PATCH /orgs/{org}/teams/{team_slug}"
+	DeleteOrgsTeamsByOrgByTeamSlug                      = "DELETE /orgs/{org}/teams/{team_slug}"
+	GetOrgsTeamsTeamsByOrgByTeamSlug                    = "GET /orgs/{org}/teams/{team_slug}/teams"
+	PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername    = "PUT /orgs/{org}/teams/{team_slug}/memberships/{username}"
+	DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername = "DELETE /orgs/{org}/teams/{team_slug}/memberships/{username}"
+	GetOrgsPropertiesSchemaByOrg                        = "GET /orgs/{org}/properties/schema"
+	GetOrgsPropertiesValuesByOrg                        = "GET /orgs/{org}/properties/values"
+
+	// Webhook endpoints
+	GetReposHooksByOwnerByRepo                                        = "GET /repos/{owner}/{repo}/hooks"
+	PostReposHooksByOwnerByRepo                                       = "POST /repos/{owner}/{repo}/hooks"
+	GetReposHooksByOwnerByRepoByHookID                                = "GET /repos/{owner}/{repo}/hooks/{hook_id}"
+	PatchReposHooksByOwnerByRepoByHookID                              = "PATCH /repos/{owner}/{repo}/hooks/{hook_id}"
+	DeleteReposHooksByOwnerByRepoByHookID                             = "DELETE /repos/{owner}/{repo}/hooks/{hook_id}"
+	PatchReposHooksConfigByOwnerByRepoByHookID                        = "PATCH /repos/{owner}/{repo}/hooks/{hook_id}/config"
+	GetReposHooksDeliveriesByOwnerByRepoByHookID                      = "GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries"
+	GetReposHooksDeliveriesByOwnerByRepoByHookIDByDeliveryID          = "GET /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id}"
+	PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIDByDeliveryID = "POST /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id}/attempts"
+	GetOrgsHooksByOrg                                                 = "GET /orgs/{org}/hooks"
+	PostOrgsHooksByOrg                                                = "POST /orgs/{org}/hooks"
+	DeleteOrgsHooksByOrgByHookID                                      = "DELETE /orgs/{org}/hooks/{hook_id}"
+	GetOrgsHooksDeliveriesByOrgByHookIDByDeliveryID                   = "GET /orgs/{org}/hooks/{hook_id}/deliveries/{delivery_id}"
+	PostOrgsHooksDeliveriesAttemptsByOrgByHookIDByDeliveryID          = "POST /orgs/{org}/hooks/{hook_id}/deliveries/{delivery_id}/attempts"
 
 	// Git endpoints
 	GetReposGitTreesByOwnerByRepoByTree        = "GET /repos/{owner}/{repo}/git/trees/{tree}"
@@ -48,31 +116,69 @@ const (
 	PostReposGitCommitsByOwnerByRepo           = "POST /repos/{owner}/{repo}/git/commits"
 	GetReposGitTagsByOwnerByRepoByTagSHA       = "GET /repos/{owner}/{repo}/git/tags/{tag_sha}"
 	PostReposGitTreesByOwnerByRepo             = "POST /repos/{owner}/{repo}/git/trees"
+	GetReposGitBlobsByOwnerByRepoByFileSHA     = "GET /repos/{owner}/{repo}/git/blobs/{file_sha}"
 	GetReposCommitsStatusByOwnerByRepoByRef    = "GET /repos/{owner}/{repo}/commits/{ref}/status"
 	GetReposCommitsStatusesByOwnerByRepoByRef  = "GET /repos/{owner}/{repo}/commits/{ref}/statuses"
 
 	// Issues endpoints
-	GetReposIssuesByOwnerByRepoByIssueNumber                    = "GET /repos/{owner}/{repo}/issues/{issue_number}"
-	GetReposIssuesCommentsByOwnerByRepoByIssueNumber            = "GET /repos/{owner}/{repo}/issues/{issue_number}/comments"
-	PostReposIssuesByOwnerByRepo                                = "POST /repos/{owner}/{repo}/issues"
-	PostReposIssuesCommentsByOwnerByRepoByIssueNumber           = "POST /repos/{owner}/{repo}/issues/{issue_number}/comments"
-	PatchReposIssuesByOwnerByRepoByIssueNumber                  = "PATCH /repos/{owner}/{repo}/issues/{issue_number}"
-	GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber           = "GET /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
-	PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber          = "POST /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
-	DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber         = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/sub_issue"
-	PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber = "PATCH /repos/{owner}/{repo}/issues/{issue_number}/sub_issues/priority"
+	GetReposIssuesByOwnerByRepoByIssueNumber                             = "GET /repos/{owner}/{repo}/issues/{issue_number}"
+	GetReposIssuesCommentsByOwnerByRepoByIssueNumber                     = "GET /repos/{owner}/{repo}/issues/{issue_number}/comments"
+	GetReposIssuesTimelineByOwnerByRepoByIssueNumber                     = "GET /repos/{owner}/{repo}/issues/{issue_number}/timeline"
+	PostReposIssuesByOwnerByRepo                                         = "POST /repos/{owner}/{repo}/issues"
+	PostReposIssuesCommentsByOwnerByRepoByIssueNumber                    = "POST /repos/{owner}/{repo}/issues/{issue_number}/comments"
+	PatchReposIssuesByOwnerByRepoByIssueNumber                           = "PATCH /repos/{owner}/{repo}/issues/{issue_number}"
+	GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber                    = "GET /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
+	GetReposIssuesParentByOwnerByRepoByIssueNumber                       = "GET /repos/{owner}/{repo}/issues/{issue_number}/parent"
+	PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber                   = "POST /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
+	DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber                  = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/sub_issue"
+	PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber          = "PATCH /repos/{owner}/{repo}/issues/{issue_number}/sub_issues/priority"
+	GetReposIssuesByOwnerByRepo                                          = "GET /repos/{owner}/{repo}/issues"
+	PostReposIssuesLabelsByOwnerByRepoByIssueNumber                      = "POST /repos/{owner}/{repo}/issues/{issue_number}/labels"
+	DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName              = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/labels/{name}"
+	PutReposIssuesLockByOwnerByRepoByIssueNumber                         = "PUT /repos/{owner}/{repo}/issues/{issue_number}/lock"
+	DeleteReposIssuesLockByOwnerByRepoByIssueNumber                      = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/lock"
+	GetReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber        = "GET /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by"
+	GetReposIssuesDependenciesBlockingByOwnerByRepoByIssueNumber         = "GET /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocking"
+	PostReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber       = "POST /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by"
+	DeleteReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumberByID = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by/{issue_id}"
+	GetReposIssuesCommentsByOwnerByRepoByCommentID                       = "GET /repos/{owner}/{repo}/issues/comments/{comment_id}"
+	PatchReposIssuesCommentsByOwnerByRepoByCommentID                     = "PATCH /repos/{owner}/{repo}/issues/comments/{comment_id}"
+	DeleteReposIssuesCommentsByOwnerByRepoByCommentID                    = "DELETE /repos/{owner}/{repo}/issues/comments/{comment_id}"
+
+	// Reaction endpoints
+	GetReposIssuesReactionsByOwnerByRepoByIssueNumber                   = "GET /repos/{owner}/{repo}/issues/{issue_number}/reactions"
+	PostReposIssuesReactionsByOwnerByRepoByIssueNumber                  = "POST /repos/{owner}/{repo}/issues/{issue_number}/reactions"
+	DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionID    = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/reactions/{reaction_id}"
+	GetReposIssuesCommentsReactionsByOwnerByRepoByCommentID             = "GET /repos/{owner}/{repo}/issues/comments/{comment_id}/reactions"
+	PostReposIssuesCommentsReactionsByOwnerByRepoByCommentID            = "POST /repos/{owner}/{repo}/issues/comments/{comment_id}/reactions"
+	DeleteReposIssuesCommentsReactionsByOwnerByRepoByCommentIDByReactID = "DELETE /repos/{owner}/{repo}/issues/comments/{comment_id}/reactions/{reaction_id}"
+	PostReposPullsCommentsReactionsByOwnerByRepoByCommentID             = "POST /repos/{owner}/{repo}/pulls/comments/{comment_id}/reactions"
+
+	// Milestone endpoints
+	GetReposMilestonesByOwnerByRepo                     = "GET /repos/{owner}/{repo}/milestones"
+	PostReposMilestonesByOwnerByRepo                    = "POST /repos/{owner}/{repo}/milestones"
+	GetReposMilestonesByOwnerByRepoByMilestoneNumber    = "GET /repos/{owner}/{repo}/milestones/{milestone_number}"
+	PatchReposMilestonesByOwnerByRepoByMilestoneNumber  = "PATCH /repos/{owner}/{repo}/milestones/{milestone_number}"
+	DeleteReposMilestonesByOwnerByRepoByMilestoneNumber = "DELETE /repos/{owner}/{repo}/milestones/{milestone_number}"
 
 	// Pull request endpoints
-	GetReposPullsByOwnerByRepo                                = "GET /repos/{owner}/{repo}/pulls"
-	GetReposPullsByOwnerByRepoByPullNumber                    = "GET /repos/{owner}/{repo}/pulls/{pull_number}"
-	GetReposPullsFilesByOwnerByRepoByPullNumber               = "GET /repos/{owner}/{repo}/pulls/{pull_number}/files"
-	GetReposPullsReviewsByOwnerByRepoByPullNumber             = "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews"
-	PostReposPullsByOwnerByRepo                               = "POST /repos/{owner}/{repo}/pulls"
-	PatchReposPullsByOwnerByRepoByPullNumber                  = "PATCH /repos/{owner}/{repo}/pulls/{pull_number}"
-	PutReposPullsMergeByOwnerByRepoByPullNumber               = "PUT /repos/{owner}/{repo}/pulls/{pull_number}/merge"
-	PutReposPullsUpdateBranchByOwnerByRepoByPullNumber        = "PUT /repos/{owner}/{repo}/pulls/{pull_number}/update-branch"
-	PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber = "POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers"
-	PostReposPullsCommentsByOwnerByRepoByPullNumber           = "POST /repos/{owner}/{repo}/pulls/{pull_number}/comments"
+	GetReposPullsByOwnerByRepo                                      = "GET /repos/{owner}/{repo}/pulls"
+	GetReposPullsByOwnerByRepoByPullNumber                          = "GET /repos/{owner}/{repo}/pulls/{pull_number}"
+	GetReposPullsFilesByOwnerByRepoByPullNumber                     = "GET /repos/{owner}/{repo}/pulls/{pull_number}/files"
+	GetReposPullsCommitsByOwnerByRepoByPullNumber                   = "GET /repos/{owner}/{repo}/pulls/{pull_number}/commits"
+	GetReposPullsReviewsCommentsByOwnerByRepoByPullNumberByReviewID = "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews/{review_id}/comments"
+	GetReposPullsReviewsByOwnerByRepoByPullNumber                   = "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews"
+	PostReposPullsByOwnerByRepo                                     = "POST /repos/{owner}/{repo}/pulls"
+	PatchReposPullsByOwnerByRepoByPullNumber                        = "PATCH /repos/{owner}/{repo}/pulls/{pull_number}"
+	PutReposPullsMergeByOwnerByRepoByPullNumber                     = "PUT /repos/{owner}/{repo}/pulls/{pull_number}/merge"
+	PutReposPullsUpdateBranchByOwnerByRepoByPullNumber              = "PUT /repos/{owner}/{repo}/pulls/{pull_number}/update-branch"
+	GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber        = "GET /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers"
+	PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber       = "POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers"
+	DeleteReposPullsRequestedReviewersByOwnerByRepoByPullNumber     = "DELETE /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers"
+	PostReposPullsCommentsByOwnerByRepoByPullNumber                 = "POST /repos/{owner}/{repo}/pulls/{pull_number}/comments"
+	GetReposPullsCommentsByOwnerByRepoByCommentID                   = "GET /repos/{owner}/{repo}/pulls/comments/{comment_id}"
+	PatchReposPullsCommentsByOwnerByRepoByCommentID                 = "PATCH /repos/{owner}/{repo}/pulls/comments/{comment_id}"
+	DeleteReposPullsCommentsByOwnerByRepoByCommentID                = "DELETE /repos/{owner}/{repo}/pulls/comments/{comment_id}"
 
 	// Notifications endpoints
 	GetNotifications                                 = "GET /notifications"
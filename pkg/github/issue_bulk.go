@@ -0,0 +1,534 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// maxBulkIssues is the maximum number of issues a single bulk_issue_write call can change.
+	maxBulkIssues = 100
+	// bulkIssueConcurrency is the number of issues that are changed at the same time.
+	bulkIssueConcurrency = 4
+)
+
+// BulkIssueResult is the outcome of changing one issue of a bulk operation.
+type BulkIssueResult struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title,omitempty"`
+	Status  string   `json:"status"`
+	Changes []string `json:"changes"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// BulkIssueWriteResult is the output of a bulk operation on issues.
+type BulkIssueWriteResult struct {
+	DryRun    bool              `json:"dry_run"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Results   []BulkIssueResult `json:"results"`
+}
+
+// bulkIssueOperations is the set of changes applied to every issue of a bulk operation.
+type bulkIssueOperations struct {
+	AddLabels    []string
+	RemoveLabels []string
+	Assignees    []string
+	Milestone    int
+	IssueType    string
+	Comment      string
+	Close        bool
+	StateReason  string
+	DuplicateOf  int
+	Lock         bool
+	LockReason   string
+}
+
+// changes describes the operations in the order they are applied.
+func (o bulkIssueOperations) changes() []string {
+	var changes []string
+	if len(o.Assignees) > 0 {
+		changes = append(changes, fmt.Sprintf("set assignees to %s", strings.Join(o.Assignees, ", ")))
+	}
+	if o.Milestone != 0 {
+		changes = append(changes, fmt.Sprintf("set milestone %d", o.Milestone))
+	}
+	if o.IssueType != "" {
+		changes = append(changes, fmt.Sprintf("set type %s", o.IssueType))
+	}
+	if len(o.AddLabels) > 0 {
+		changes = append(changes, fmt.Sprintf("add labels %s", strings.Join(o.AddLabels, ", ")))
+	}
+	if len(o.RemoveLabels) > 0 {
+		changes = append(changes, fmt.Sprintf("remove labels %s", strings.Join(o.RemoveLabels, ", ")))
+	}
+	if o.Comment != "" {
+		changes = append(changes, "add comment")
+	}
+	if o.Close {
+		if o.StateReason == "duplicate" {
+			changes = append(changes, fmt.Sprintf("close as duplicate of #%d", o.DuplicateOf))
+		} else {
+			changes = append(changes, fmt.Sprintf("close as %s", o.StateReason))
+		}
+	}
+	if o.Lock {
+		if o.LockReason != "" {
+			changes = append(changes, fmt.Sprintf("lock as %s", o.LockReason))
+		} else {
+			changes = append(changes, "lock")
+		}
+	}
+	return changes
+}
+
+// BulkIssueWrite creates a tool to apply the same changes to many issues of a repository.
+func BulkIssueWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "bulk_issue_write",
+			Description: t("TOOL_BULK_ISSUE_WRITE_DESCRIPTION", "Apply the same changes to up to 100 issues of a repository in one call, selected by 'issue_numbers' or by a search 'query'. Changes are applied in this order: assignees, milestone, type, add labels, remove labels, comment, close, lock. Issues are changed concurrently and the call stops starting new issues when the API rate limit runs low. Returns the outcome for each issue. Use 'dry_run' first to preview which issues a query selects."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_BULK_ISSUE_WRITE_USER_TITLE", "Change issues in bulk"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_numbers": {
+						Type:        "array",
+						Description: "Numbers of the issues to change. Either 'issue_numbers' or 'query' is required.",
+						Items: &jsonschema.Schema{
+							Type: "number",
+						},
+					},
+					"query": {
+						Type:        "string",
+						Description: "Search query using GitHub issues search syntax selecting the issues to change, e.g. 'is:open label:needs-triage'. It is scoped to the repository and to issues. Either 'issue_numbers' or 'query' is required.",
+					},
+					"add_labels": {
+						Type:        "array",
+						Description: "Labels to add to each issue",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"remove_labels": {
+						Type:        "array",
+						Description: "Labels to remove from each issue",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"assignees": {
+						Type:        "array",
+						Description: "Usernames to assign to each issue, replacing the current assignees",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"milestone": {
+						Type:        "number",
+						Description: "Milestone number to set on each issue",
+					},
+					"type": {
+						Type:        "string",
+						Description: "Issue type to set on each issue. Use list_issue_types to get valid type values for the organization.",
+					},
+					"comment": {
+						Type:        "string",
+						Description: "Comment to add to each issue",
+					},
+					"state_reason": {
+						Type:        "string",
+						Description: "Close each issue with this reason",
+						Enum:        []any{"completed", "not_planned", "duplicate"},
+					},
+					"duplicate_of": {
+						Type:        "number",
+						Description: "Issue number that the issues are duplicates of. Required when state_reason is 'duplicate'.",
+					},
+					"lock": {
+						Type:        "boolean",
+						Description: "Lock the conversation of each issue",
+					},
+					"lock_reason": {
+						Type:        "string",
+						Description: "Reason for locking the issues. Only used with 'lock'.",
+						Enum:        []any{"off-topic", "too heated", "resolved", "spam"},
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "Only list the issues that would be changed and the planned changes, without changing anything",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumbers, err := OptionalIntArrayParam(args, "issue_numbers")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			query, err := OptionalParam[string](args, "query")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if (len(issueNumbers) == 0) == (query == "") {
+				return utils.NewToolResultError("exactly one of issue_numbers or query is required"), nil, nil
+			}
+			if len(issueNumbers) > maxBulkIssues {
+				return utils.NewToolResultError(fmt.Sprintf("at most %d issues can be changed in one call, got %d", maxBulkIssues, len(issueNumbers))), nil, nil
+			}
+			ops, err := bulkIssueOperationsFromArgs(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			dryRun, err := OptionalParam[bool](args, "dry_run")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var issues []*github.Issue
+			if query != "" {
+				var errResult *mcp.CallToolResult
+				issues, errResult = searchBulkIssues(ctx, client, owner, repo, query)
+				if errResult != nil {
+					return errResult, nil, nil
+				}
+			} else {
+				for _, number := range issueNumbers {
+					issues = append(issues, &github.Issue{Number: github.Ptr(number)})
+				}
+			}
+
+			var gqlClient *githubv4.Client
+			if ops.Close && !dryRun {
+				gqlClient, err = deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GraphQL client", err), nil, nil
+				}
+			}
+
+			output := runBulkIssueWrite(ctx, client, gqlClient, owner, repo, issues, ops, dryRun)
+			if dryRun && query == "" {
+				previewBulkIssueNumbers(ctx, client, owner, repo, &output)
+			}
+			result, err := utils.NewToolResultJSON(output)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		})
+}
+
+// bulkIssueOperationsFromArgs reads and validates the changes of a bulk operation.
+func bulkIssueOperationsFromArgs(args map[string]any) (bulkIssueOperations, error) {
+	var ops bulkIssueOperations
+	var err error
+	if ops.AddLabels, err = OptionalStringArrayParam(args, "add_labels"); err != nil {
+		return ops, err
+	}
+	if ops.RemoveLabels, err = OptionalStringArrayParam(args, "remove_labels"); err != nil {
+		return ops, err
+	}
+	if ops.Assignees, err = OptionalStringArrayParam(args, "assignees"); err != nil {
+		return ops, err
+	}
+	if ops.Milestone, err = OptionalIntParam(args, "milestone"); err != nil {
+		return ops, err
+	}
+	if ops.IssueType, err = OptionalParam[string](args, "type"); err != nil {
+		return ops, err
+	}
+	if ops.Comment, err = OptionalParam[string](args, "comment"); err != nil {
+		return ops, err
+	}
+	if ops.StateReason, err = OptionalParam[string](args, "state_reason"); err != nil {
+		return ops, err
+	}
+	if ops.DuplicateOf, err = OptionalIntParam(args, "duplicate_of"); err != nil {
+		return ops, err
+	}
+	if ops.Lock, err = OptionalParam[bool](args, "lock"); err != nil {
+		return ops, err
+	}
+	if ops.LockReason, err = OptionalParam[string](args, "lock_reason"); err != nil {
+		return ops, err
+	}
+
+	ops.Close = ops.StateReason != ""
+	if ops.StateReason == "duplicate" && ops.DuplicateOf == 0 {
+		return ops, fmt.Errorf("duplicate_of must be provided when state_reason is 'duplicate'")
+	}
+	if ops.DuplicateOf != 0 && ops.StateReason != "duplicate" {
+		return ops, fmt.Errorf("duplicate_of can only be used when state_reason is 'duplicate'")
+	}
+	if ops.LockReason != "" && !ops.Lock {
+		return ops, fmt.Errorf("lock_reason can only be used with lock")
+	}
+	if len(ops.changes()) == 0 {
+		return ops, fmt.Errorf("at least one change is required: add_labels, remove_labels, assignees, milestone, type, comment, state_reason or lock")
+	}
+	return ops, nil
+}
+
+// searchBulkIssues returns the issues of the repository matching query. It returns a tool result
+// if the search fails or matches more issues than a bulk operation can change.
+func searchBulkIssues(ctx context.Context, client *github.Client, owner, repo, query string) ([]*github.Issue, *mcp.CallToolResult) {
+	if hasFilter(query, "repo") || hasFilter(query, "org") || hasFilter(query, "user") {
+		return nil, utils.NewToolResultError("query must not contain repo:, org: or user: qualifiers; it is scoped to the owner and repo parameters")
+	}
+	scopedQuery := fmt.Sprintf("repo:%s/%s is:issue %s", owner, repo, query)
+
+	var issues []*github.Issue
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	result, resp, err := client.Search.Issues(ctx, scopedQuery, opts)
+	if err != nil {
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search issues", resp, err)
+	}
+	_ = resp.Body.Close()
+	if result.GetTotal() > maxBulkIssues {
+		return nil, utils.NewToolResultError(fmt.Sprintf("query matches %d issues, but at most %d can be changed in one call. Narrow the query and repeat the call for the rest", result.GetTotal(), maxBulkIssues))
+	}
+	for _, issue := range result.Issues {
+		if !issue.IsPullRequest() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// runBulkIssueWrite applies ops to issues with bounded concurrency and returns the outcome for
+// each issue in the order of issues.
+func runBulkIssueWrite(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, repo string, issues []*github.Issue, ops bulkIssueOperations, dryRun bool) BulkIssueWriteResult {
+	output := BulkIssueWriteResult{
+		DryRun:  dryRun,
+		Total:   len(issues),
+		Results: make([]BulkIssueResult, len(issues)),
+	}
+	changes := ops.changes()
+	for i, issue := range issues {
+		output.Results[i] = BulkIssueResult{
+			Number:  issue.GetNumber(),
+			Title:   sanitize.Sanitize(issue.GetTitle()),
+			Status:  bulkStatusPlanned,
+			Changes: changes,
+		}
+	}
+	if dryRun {
+		return output
+	}
+
+	run := runBulk(len(issues), bulkIssueConcurrency, func(i int, checkRateLimit func(*github.Response, error)) error {
+		return applyBulkIssueOperations(ctx, client, gqlClient, owner, repo, output.Results[i].Number, ops, checkRateLimit)
+	})
+	for i, outcome := range run.Outcomes {
+		output.Results[i].Status = outcome.Status
+		output.Results[i].Error = outcome.Error
+	}
+	output.Succeeded, output.Failed, output.Skipped = run.Succeeded, run.Failed, run.Skipped
+	return output
+}
+
+// previewBulkIssueNumbers fetches the issues of a dry run selected by number, so that the preview
+// shows their titles and skips numbers that do not exist or belong to pull requests.
+func previewBulkIssueNumbers(ctx context.Context, client *github.Client, owner, repo string, output *BulkIssueWriteResult) {
+	run := runBulk(len(output.Results), bulkIssueConcurrency, func(i int, checkRateLimit func(*github.Response, error)) error {
+		result := &output.Results[i]
+		issue, resp, err := client.Issues.Get(ctx, owner, repo, result.Number)
+		checkRateLimit(resp, err)
+		if resp != nil && resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+				return fmt.Errorf("issue #%d does not exist", result.Number)
+			}
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		if issue.IsPullRequest() {
+			return fmt.Errorf("#%d is a pull request, not an issue", result.Number)
+		}
+		result.Title = sanitize.Sanitize(issue.GetTitle())
+		return nil
+	})
+	for i, outcome := range run.Outcomes {
+		if outcome.Status == bulkStatusUpdated {
+			continue
+		}
+		output.Results[i].Status = bulkStatusSkipped
+		output.Results[i].Error = outcome.Error
+		output.Skipped++
+	}
+}
+
+// applyBulkIssueOperations applies ops to a single issue, stopping at the first change that fails.
+func applyBulkIssueOperations(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, repo string, issueNumber int, ops bulkIssueOperations, checkRateLimit func(*github.Response, error)) error {
+	closeBody := func(resp *github.Response) {
+		if resp != nil && resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	issueRequest := &github.IssueRequest{}
+	if len(ops.Assignees) > 0 {
+		issueRequest.Assignees = &ops.Assignees
+	}
+	if ops.Milestone != 0 {
+		issueRequest.Milestone = &ops.Milestone
+	}
+	if ops.IssueType != "" {
+		issueRequest.Type = github.Ptr(ops.IssueType)
+	}
+	if *issueRequest != (github.IssueRequest{}) {
+		_, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
+		checkRateLimit(resp, err)
+		closeBody(resp)
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+	}
+
+	if len(ops.AddLabels) > 0 {
+		_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issueNumber, ops.AddLabels)
+		checkRateLimit(resp, err)
+		closeBody(resp)
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
+	for _, label := range ops.RemoveLabels {
+		if err := removeIssueLabel(ctx, client, owner, repo, issueNumber, label, checkRateLimit); err != nil {
+			return err
+		}
+	}
+
+	if ops.Comment != "" {
+		_, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{Body: github.Ptr(ops.Comment)})
+		checkRateLimit(resp, err)
+		closeBody(resp)
+		if err != nil {
+			return fmt.Errorf("failed to add comment: %w", err)
+		}
+	}
+
+	if ops.Close {
+		if err := closeIssueWithStateReason(ctx, gqlClient, owner, repo, issueNumber, ops.StateReason, ops.DuplicateOf); err != nil {
+			return err
+		}
+	}
+
+	if ops.Lock {
+		resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, &github.LockIssueOptions{LockReason: ops.LockReason})
+		checkRateLimit(resp, err)
+		closeBody(resp)
+		if err != nil {
+			return fmt.Errorf("failed to lock issue: %w", err)
+		}
+	}
+	return nil
+}
+
+// removeIssueLabel removes a label from an issue. A label that is not on the issue counts as
+// removed, but only once the issue itself is known to exist.
+func removeIssueLabel(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, label string, checkRateLimit func(*github.Response, error)) error {
+	// go-github does not escape the label name, so labels such as "kind/bug" would hit another path.
+	u := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", url.PathEscape(owner), url.PathEscape(repo), issueNumber, url.PathEscape(label))
+	req, err := client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(ctx, req, nil)
+	checkRateLimit(resp, err)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err == nil {
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to remove label %s: %w", label, err)
+	}
+
+	// The API also responds with 404 when the issue does not exist.
+	issue, getResp, getErr := client.Issues.Get(ctx, owner, repo, issueNumber)
+	checkRateLimit(getResp, getErr)
+	if getResp != nil && getResp.Body != nil {
+		_ = getResp.Body.Close()
+	}
+	if getErr != nil {
+		return fmt.Errorf("failed to remove label %s: %w", label, getErr)
+	}
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l.GetName(), label) {
+			return fmt.Errorf("failed to remove label %s: %w", label, err)
+		}
+	}
+	return nil
+}
+
+// closeIssueWithStateReason closes an issue through the GraphQL API, which unlike the REST API
+// supports closing an issue as a duplicate of another one.
+func closeIssueWithStateReason(ctx context.Context, gqlClient *githubv4.Client, owner, repo string, issueNumber int, stateReason string, duplicateOf int) error {
+	issueID, duplicateIssueID, err := fetchIssueIDs(ctx, gqlClient, owner, repo, issueNumber, duplicateOf)
+	if err != nil {
+		return err
+	}
+
+	var mutation struct {
+		CloseIssue struct {
+			Issue struct {
+				ID githubv4.ID
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}
+	stateReasonValue := getCloseStateReason(stateReason)
+	closeInput := CloseIssueInput{
+		IssueID:     issueID,
+		StateReason: &stateReasonValue,
+	}
+	if stateReason == "duplicate" {
+		closeInput.DuplicateIssueID = &duplicateIssueID
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, closeInput, nil); err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	return nil
+}
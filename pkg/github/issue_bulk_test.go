@@ -0,0 +1,391 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BulkIssueWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := BulkIssueWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "bulk_issue_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "issue_numbers")
+	assert.Contains(t, schema.Properties, "query")
+	assert.Contains(t, schema.Properties, "dry_run")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	searchResult := &github.IssuesSearchResult{
+		Total: github.Ptr(3),
+		Issues: []*github.Issue{
+			{Number: github.Ptr(1), Title: github.Ptr("Crash on startup")},
+			{Number: github.Ptr(2), Title: github.Ptr("Crash when saving")},
+			{
+				Number:           github.Ptr(3),
+				Title:            github.Ptr("Fix crash"),
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/3")},
+			},
+		},
+	}
+
+	// failIssue2 fails every request made for issue 2.
+	failIssue2 := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/issues/2/") {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+				return
+			}
+			handler(w, r)
+		}
+	}
+	// lowRateLimit responds successfully with nearly no requests left in the rate limit window.
+	lowRateLimit := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("X-RateLimit-Reset", "1893456000")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		mockedGQL      *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		verify         func(t *testing.T, result BulkIssueWriteResult)
+	}{
+		{
+			name: "dry run lists the issues matching a query",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchIssues: expectQueryParams(t, map[string]string{
+					"q":        "repo:owner/repo is:issue is:open crash",
+					"per_page": "100",
+				}).andThen(mockResponse(t, http.StatusOK, searchResult)),
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"query":      "is:open crash",
+				"add_labels": []any{"crash"},
+				"dry_run":    true,
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				assert.True(t, result.DryRun)
+				assert.Equal(t, 2, result.Total)
+				require.Len(t, result.Results, 2)
+				assert.Equal(t, 1, result.Results[0].Number)
+				assert.Equal(t, "Crash on startup", result.Results[0].Title)
+				assert.Equal(t, "planned", result.Results[0].Status)
+				assert.Equal(t, []string{"add labels crash"}, result.Results[0].Changes)
+				assert.Equal(t, 2, result.Results[1].Number)
+			},
+		},
+		{
+			name: "dry run checks the issues selected by number",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/repos/owner/repo/issues/1":
+						mockResponse(t, http.StatusOK, searchResult.Issues[0])(w, r)
+					case "/repos/owner/repo/issues/3":
+						mockResponse(t, http.StatusOK, searchResult.Issues[2])(w, r)
+					default:
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}
+				},
+			}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(3), float64(404)},
+				"state_reason":  "completed",
+				"dry_run":       true,
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				assert.True(t, result.DryRun)
+				assert.Equal(t, 2, result.Skipped)
+				require.Len(t, result.Results, 3)
+				assert.Equal(t, "Crash on startup", result.Results[0].Title)
+				assert.Equal(t, "planned", result.Results[0].Status)
+				assert.Equal(t, "skipped", result.Results[1].Status)
+				assert.Equal(t, "#3 is a pull request, not an issue", result.Results[1].Error)
+				assert.Equal(t, "skipped", result.Results[2].Status)
+				assert.Equal(t, "issue #404 does not exist", result.Results[2].Error)
+			},
+		},
+		{
+			name: "applies changes and reports failures per issue",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PostReposIssuesLabelsByOwnerByRepoByIssueNumber: failIssue2(
+					expectRequestBody(t, []any{"triaged"}).andThen(mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triaged")}})),
+				),
+				DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName: func(w http.ResponseWriter, r *http.Request) {
+					// Issue 3 does not have the label.
+					if strings.Contains(r.URL.Path, "/issues/3/") {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Label does not exist"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, []*github.Label{})(w, r)
+				},
+				GetReposIssuesByOwnerByRepoByIssueNumber: expectPath(t, "/repos/owner/repo/issues/3").andThen(
+					mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(3), Labels: []*github.Label{{Name: github.Ptr("bug")}}}),
+				),
+				PostReposIssuesCommentsByOwnerByRepoByIssueNumber: expectRequestBody(t, map[string]any{
+					"body": "Triaged in the weekly meeting",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))})),
+				PutReposIssuesLockByOwnerByRepoByIssueNumber: expectRequestBody(t, map[string]any{
+					"lock_reason": "resolved",
+				}).andThen(mockResponse(t, http.StatusNoContent, nil)),
+			}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2), float64(3)},
+				"add_labels":    []any{"triaged"},
+				"remove_labels": []any{"needs-triage"},
+				"comment":       "Triaged in the weekly meeting",
+				"lock":          true,
+				"lock_reason":   "resolved",
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				assert.False(t, result.DryRun)
+				assert.Equal(t, 3, result.Total)
+				assert.Equal(t, 2, result.Succeeded)
+				assert.Equal(t, 1, result.Failed)
+				require.Len(t, result.Results, 3)
+				assert.Equal(t, "updated", result.Results[0].Status)
+				assert.Equal(t, "failed", result.Results[1].Status)
+				assert.Contains(t, result.Results[1].Error, "failed to add labels")
+				assert.Equal(t, "updated", result.Results[2].Status)
+				assert.Equal(t, []string{"add labels triaged", "remove labels needs-triage", "add comment", "lock as resolved"}, result.Results[0].Changes)
+			},
+		},
+		{
+			name: "removes labels by their escaped name",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"": func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case r.Method == http.MethodDelete && r.URL.EscapedPath() == "/repos/owner/repo/issues/1/labels/kind%2Fbug":
+						mockResponse(t, http.StatusOK, []*github.Label{})(w, r)
+					case r.Method == http.MethodDelete:
+						// Issue 2 does not exist.
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/issues/2":
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					default:
+						t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+				},
+			}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"remove_labels": []any{"kind/bug"},
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				require.Len(t, result.Results, 2)
+				assert.Equal(t, "updated", result.Results[0].Status)
+				assert.Equal(t, "failed", result.Results[1].Status)
+				assert.Contains(t, result.Results[1].Error, "failed to remove label kind/bug")
+			},
+		},
+		{
+			name: "stops starting issues when the rate limit runs low",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PostReposIssuesLabelsByOwnerByRepoByIssueNumber: lowRateLimit,
+			}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2), float64(3), float64(4), float64(5), float64(6)},
+				"add_labels":    []any{"triaged"},
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				assert.Equal(t, 6, result.Total)
+				assert.Equal(t, 6, result.Succeeded+result.Skipped)
+				assert.GreaterOrEqual(t, result.Succeeded, 1)
+				// Issues beyond the concurrency limit only start after another one finished.
+				for _, issue := range result.Results[bulkIssueConcurrency:] {
+					assert.Equal(t, "skipped", issue.Status)
+					assert.Contains(t, issue.Error, "rate limit")
+				}
+			},
+		},
+		{
+			name:         "closes issues as duplicates",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Repository struct {
+							Issue struct {
+								ID githubv4.ID
+							} `graphql:"issue(number: $issueNumber)"`
+							DuplicateIssue struct {
+								ID githubv4.ID
+							} `graphql:"duplicateIssue: issue(number: $duplicateOf)"`
+						} `graphql:"repository(owner: $owner, name: $repo)"`
+					}{},
+					map[string]any{
+						"owner":       githubv4.String("owner"),
+						"repo":        githubv4.String("repo"),
+						"issueNumber": githubv4.Int(7),
+						"duplicateOf": githubv4.Int(5),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"issue":          map[string]any{"id": "I_7"},
+							"duplicateIssue": map[string]any{"id": "I_5"},
+						},
+					}),
+				),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CloseIssue struct {
+							Issue struct {
+								ID githubv4.ID
+							}
+						} `graphql:"closeIssue(input: $input)"`
+					}{},
+					CloseIssueInput{
+						IssueID:          "I_7",
+						StateReason:      github.Ptr(IssueClosedStateReasonDuplicate),
+						DuplicateIssueID: githubv4.NewID("I_5"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"closeIssue": map[string]any{
+							"issue": map[string]any{"id": "I_7"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(7)},
+				"state_reason":  "duplicate",
+				"duplicate_of":  float64(5),
+			},
+			verify: func(t *testing.T, result BulkIssueWriteResult) {
+				assert.Equal(t, 1, result.Succeeded)
+				assert.Equal(t, []string{"close as duplicate of #5"}, result.Results[0].Changes)
+			},
+		},
+		{
+			name: "query matching too many issues",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchIssues: mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(150)}),
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"query":      "is:open",
+				"add_labels": []any{"stale"},
+			},
+			expectError:    true,
+			expectedErrMsg: "query matches 150 issues, but at most 100 can be changed in one call",
+		},
+		{
+			name:         "query cannot target another repository",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"query":      "repo:other/repo is:open",
+				"add_labels": []any{"stale"},
+			},
+			expectError:    true,
+			expectedErrMsg: "query must not contain repo:, org: or user: qualifiers",
+		},
+		{
+			name:         "requires issue_numbers or query",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"add_labels": []any{"stale"},
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of issue_numbers or query is required",
+		},
+		{
+			name:         "requires a change",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one change is required",
+		},
+		{
+			name:         "duplicate requires duplicate_of",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+				"state_reason":  "duplicate",
+			},
+			expectError:    true,
+			expectedErrMsg: "duplicate_of must be provided when state_reason is 'duplicate'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQL)
+			deps := BaseDeps{
+				Client:    client,
+				GQLClient: gqlClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var output BulkIssueWriteResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &output))
+			tc.verify(t, output)
+		})
+	}
+}
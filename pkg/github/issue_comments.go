@@ -0,0 +1,559 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// Comment types accepted by the comment tools. Conversation comments on pull requests are
+// issue comments; review comments are the comments on the diff of a pull request.
+const (
+	commentTypeIssue  = "issue_comment"
+	commentTypeReview = "review_comment"
+)
+
+// reactionContents are the reactions supported by the GitHub API.
+var reactionContents = []any{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// maxReactionPages limits the search for the reaction of the authenticated user to the first
+// 1000 reactions with the same content.
+const maxReactionPages = 10
+
+// minimizeClassifiers are the reasons a comment can be minimized for.
+var minimizeClassifiers = []any{"SPAM", "ABUSE", "OFF_TOPIC", "OUTDATED", "DUPLICATE", "RESOLVED"}
+
+// MinimizedComment is the result of minimizing or unminimizing a comment.
+type MinimizedComment struct {
+	NodeID          string `json:"node_id"`
+	IsMinimized     bool   `json:"is_minimized"`
+	MinimizedReason string `json:"minimized_reason,omitempty"`
+}
+
+// IssueCommentWrite creates a tool to update and delete comments on issues and pull requests.
+func IssueCommentWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "issue_comment_write",
+			Description: t("TOOL_ISSUE_COMMENT_WRITE_DESCRIPTION", "Update or delete a comment on an issue or pull request. Conversation comments on pull requests are issue comments; use comment_type 'review_comment' for comments on the diff of a pull request."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_ISSUE_COMMENT_WRITE_USER_TITLE", "Update or delete comment"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'update': replace the body of the comment with 'body'.
+- 'delete': delete the comment.`,
+						Enum: []any{"update", "delete"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"comment_id": {
+						Type:        "number",
+						Description: "The ID of the comment",
+					},
+					"comment_type": {
+						Type:        "string",
+						Description: "The type of the comment. Defaults to issue_comment.",
+						Enum:        []any{commentTypeIssue, commentTypeReview},
+					},
+					"body": {
+						Type:        "string",
+						Description: "The new body of the comment. Required for 'update'.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "comment_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commentID, err := RequiredBigInt(args, "comment_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commentType, err := optionalCommentType(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			body, err := OptionalParam[string](args, "body")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "update":
+				if body == "" {
+					return utils.NewToolResultError("body is required for update"), nil, nil
+				}
+				var comment MinimalComment
+				if commentType == commentTypeReview {
+					updated, resp, err := client.PullRequests.EditComment(ctx, owner, repo, commentID, &github.PullRequestComment{Body: github.Ptr(body)})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update review comment", resp, err), nil, nil
+					}
+					defer func() { _ = resp.Body.Close() }()
+					comment = convertPullRequestCommentToMinimalComment(updated)
+				} else {
+					updated, resp, err := client.Issues.EditComment(ctx, owner, repo, commentID, &github.IssueComment{Body: github.Ptr(body)})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update issue comment", resp, err), nil, nil
+					}
+					defer func() { _ = resp.Body.Close() }()
+					comment = convertIssueCommentToMinimalComment(updated)
+				}
+
+				result, err := utils.NewToolResultJSON(comment)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "delete":
+				var resp *github.Response
+				if commentType == commentTypeReview {
+					resp, err = client.PullRequests.DeleteComment(ctx, owner, repo, commentID)
+				} else {
+					resp, err = client.Issues.DeleteComment(ctx, owner, repo, commentID)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete %s", strings.ReplaceAll(commentType, "_", " ")), resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("Comment %d deleted from %s/%s", commentID, owner, repo)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: update, delete", method)), nil, nil
+			}
+		},
+	)
+}
+
+// ReactionWrite creates a tool to add and remove reactions on issues, pull requests and comments.
+func ReactionWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "reaction_write",
+			Description: t("TOOL_REACTION_WRITE_DESCRIPTION", "Add or remove a reaction of the authenticated user on an issue, pull request, issue comment or pull request review comment."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REACTION_WRITE_USER_TITLE", "Add or remove reaction"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'add': add the reaction 'content'. Adding a reaction that already exists has no effect.
+- 'remove': remove the reaction 'content' of the authenticated user.`,
+						Enum: []any{"add", "remove"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"subject_type": {
+						Type:        "string",
+						Description: "What to react to. 'issue' also covers pull requests, and 'issue_comment' also covers conversation comments on pull requests.",
+						Enum:        []any{"issue", commentTypeIssue, commentTypeReview},
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "The number of the issue or pull request. Required when subject_type is 'issue'.",
+					},
+					"comment_id": {
+						Type:        "number",
+						Description: "The ID of the comment. Required when subject_type is 'issue_comment' or 'review_comment'.",
+					},
+					"content": {
+						Type:        "string",
+						Description: "The reaction",
+						Enum:        reactionContents,
+					},
+				},
+				Required: []string{"method", "owner", "repo", "subject_type", "content"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			subjectType, err := RequiredParam[string](args, "subject_type")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			content, err := RequiredParam[string](args, "content")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if !isReactionContent(content) {
+				return utils.NewToolResultError(fmt.Sprintf("invalid reaction content: %s", content)), nil, nil
+			}
+
+			subject := reactionSubject{owner: owner, repo: repo, subjectType: subjectType}
+			switch subjectType {
+			case "issue":
+				subject.issueNumber, err = RequiredInt(args, "issue_number")
+			case commentTypeIssue, commentTypeReview:
+				subject.commentID, err = RequiredBigInt(args, "comment_id")
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown subject_type: %s. Supported subject types are: issue, issue_comment, review_comment", subjectType)), nil, nil
+			}
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "add":
+				reaction, resp, err := subject.create(ctx, client, content)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add reaction", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalReaction(reaction))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "remove":
+				user, resp, err := client.Users.Get(ctx, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get authenticated user", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				reactionID, resp, err := subject.findUserReaction(ctx, client, user.GetLogin(), content)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list reactions", resp, err), nil, nil
+				}
+				if reactionID == 0 {
+					return utils.NewToolResultError(fmt.Sprintf("%s has no %s reaction on this %s", user.GetLogin(), content, strings.ReplaceAll(subjectType, "_", " "))), nil, nil
+				}
+
+				resp, err = subject.delete(ctx, client, reactionID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove reaction", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("Reaction %s removed", content)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: add, remove", method)), nil, nil
+			}
+		},
+	)
+}
+
+// MinimizeComment creates a tool to minimize and unminimize comments.
+func MinimizeComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "minimize_comment",
+			Description: t("TOOL_MINIMIZE_COMMENT_DESCRIPTION", "Minimize (hide) a comment with a reason such as spam, abuse or off-topic, or unminimize a previously minimized comment. Identify the comment by its REST ID and type, or by its GraphQL node ID, which also works for discussion and commit comments."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MINIMIZE_COMMENT_USER_TITLE", "Minimize or unminimize comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The operation to perform:
+- 'minimize': hide the comment for 'classifier'.
+- 'unminimize': show a minimized comment again.`,
+						Enum: []any{"minimize", "unminimize"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner. Required with 'comment_id'.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. Required with 'comment_id'.",
+					},
+					"comment_id": {
+						Type:        "number",
+						Description: "The REST ID of the comment. Either comment_id or node_id is required.",
+					},
+					"comment_type": {
+						Type:        "string",
+						Description: "The type of the comment identified by 'comment_id'. Defaults to issue_comment.",
+						Enum:        []any{commentTypeIssue, commentTypeReview},
+					},
+					"node_id": {
+						Type:        "string",
+						Description: "The GraphQL node ID of the comment. Either comment_id or node_id is required.",
+					},
+					"classifier": {
+						Type:        "string",
+						Description: "The reason for minimizing the comment. Required for 'minimize'.",
+						Enum:        minimizeClassifiers,
+					},
+				},
+				Required: []string{"method"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			nodeID, err := OptionalParam[string](args, "node_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			classifier, err := OptionalParam[string](args, "classifier")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			switch method {
+			case "minimize":
+				if classifier == "" {
+					return utils.NewToolResultError("classifier is required for minimize"), nil, nil
+				}
+			case "unminimize":
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: minimize, unminimize", method)), nil, nil
+			}
+
+			if nodeID == "" {
+				if _, ok := args["comment_id"]; !ok {
+					return utils.NewToolResultError("either comment_id or node_id is required"), nil, nil
+				}
+				owner, repo, err := RequiredOwnerRepo(args)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				commentID, err := RequiredBigInt(args, "comment_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				commentType, err := optionalCommentType(args)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				client, err := deps.GetClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+				}
+				var resp *github.Response
+				if commentType == commentTypeReview {
+					var comment *github.PullRequestComment
+					comment, resp, err = client.PullRequests.GetComment(ctx, owner, repo, commentID)
+					nodeID = comment.GetNodeID()
+				} else {
+					var comment *github.IssueComment
+					comment, resp, err = client.Issues.GetComment(ctx, owner, repo, commentID)
+					nodeID = comment.GetNodeID()
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get comment", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GraphQL client", err), nil, nil
+			}
+
+			var minimized MinimizedComment
+			if method == "minimize" {
+				var mutation struct {
+					MinimizeComment struct {
+						MinimizedComment struct {
+							IsMinimized     githubv4.Boolean
+							MinimizedReason githubv4.String
+						}
+					} `graphql:"minimizeComment(input: $input)"`
+				}
+				input := githubv4.MinimizeCommentInput{
+					SubjectID:  githubv4.ID(nodeID),
+					Classifier: githubv4.ReportedContentClassifiers(classifier),
+				}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to minimize comment", err), nil, nil
+				}
+				minimized = MinimizedComment{
+					NodeID:          nodeID,
+					IsMinimized:     bool(mutation.MinimizeComment.MinimizedComment.IsMinimized),
+					MinimizedReason: string(mutation.MinimizeComment.MinimizedComment.MinimizedReason),
+				}
+			} else {
+				var mutation struct {
+					UnminimizeComment struct {
+						UnminimizedComment struct {
+							IsMinimized githubv4.Boolean
+						}
+					} `graphql:"unminimizeComment(input: $input)"`
+				}
+				input := githubv4.UnminimizeCommentInput{
+					SubjectID: githubv4.ID(nodeID),
+				}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unminimize comment", err), nil, nil
+				}
+				minimized = MinimizedComment{
+					NodeID:      nodeID,
+					IsMinimized: bool(mutation.UnminimizeComment.UnminimizedComment.IsMinimized),
+				}
+			}
+
+			result, err := utils.NewToolResultJSON(minimized)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// optionalCommentType returns the comment_type parameter, defaulting to issue comments.
+func optionalCommentType(args map[string]any) (string, error) {
+	commentType, err := OptionalParam[string](args, "comment_type")
+	if err != nil {
+		return "", err
+	}
+	switch commentType {
+	case "":
+		return commentTypeIssue, nil
+	case commentTypeIssue, commentTypeReview:
+		return commentType, nil
+	default:
+		return "", fmt.Errorf("unknown comment_type: %s. Supported comment types are: issue_comment, review_comment", commentType)
+	}
+}
+
+func isReactionContent(content string) bool {
+	for _, c := range reactionContents {
+		if c == content {
+			return true
+		}
+	}
+	return false
+}
+
+// reactionSubject is the issue, pull request or comment a reaction is added to or removed from.
+type reactionSubject struct {
+	owner       string
+	repo        string
+	subjectType string
+	issueNumber int
+	commentID   int64
+}
+
+func (s reactionSubject) create(ctx context.Context, client *github.Client, content string) (*github.Reaction, *github.Response, error) {
+	switch s.subjectType {
+	case commentTypeIssue:
+		return client.Reactions.CreateIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+	case commentTypeReview:
+		return client.Reactions.CreatePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+	default:
+		return client.Reactions.CreateIssueReaction(ctx, s.owner, s.repo, s.issueNumber, content)
+	}
+}
+
+func (s reactionSubject) list(ctx context.Context, client *github.Client, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+	switch s.subjectType {
+	case commentTypeIssue:
+		return client.Reactions.ListIssueCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+	case commentTypeReview:
+		return client.Reactions.ListPullRequestCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+	default:
+		return client.Reactions.ListIssueReactions(ctx, s.owner, s.repo, s.issueNumber, opts)
+	}
+}
+
+func (s reactionSubject) delete(ctx context.Context, client *github.Client, reactionID int64) (*github.Response, error) {
+	switch s.subjectType {
+	case commentTypeIssue:
+		return client.Reactions.DeleteIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, reactionID)
+	case commentTypeReview:
+		return client.Reactions.DeletePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, reactionID)
+	default:
+		return client.Reactions.DeleteIssueReaction(ctx, s.owner, s.repo, s.issueNumber, reactionID)
+	}
+}
+
+// findUserReaction returns the ID of the reaction of the user with the given content, or 0
+// when the user has not reacted with it. The REST API can only delete reactions by ID.
+func (s reactionSubject) findUserReaction(ctx context.Context, client *github.Client, login, content string) (int64, *github.Response, error) {
+	opts := &github.ListReactionOptions{
+		Content:     content,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for page := 0; page < maxReactionPages; page++ {
+		reactions, resp, err := s.list(ctx, client, opts)
+		if err != nil {
+			return 0, resp, err
+		}
+		_ = resp.Body.Close()
+
+		for _, reaction := range reactions {
+			if strings.EqualFold(reaction.GetUser().GetLogin(), login) {
+				return reaction.GetID(), resp, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return 0, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+	return 0, nil, nil
+}
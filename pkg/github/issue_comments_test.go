@@ -0,0 +1,591 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueCommentWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := IssueCommentWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "issue_comment_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "comment_type")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "comment_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		verify         func(t *testing.T, text string)
+	}{
+		{
+			name: "updates an issue comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposIssuesCommentsByOwnerByRepoByCommentID,
+					expectRequestBody(t, map[string]any{"body": "Fixed typo"}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssueComment{
+							ID:      github.Ptr(int64(42)),
+							NodeID:  github.Ptr("IC_42"),
+							Body:    github.Ptr("Fixed typo"),
+							User:    &github.User{Login: github.Ptr("octocat")},
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/1#issuecomment-42"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(42),
+				"body":       "Fixed typo",
+			},
+			verify: func(t *testing.T, text string) {
+				var comment MinimalComment
+				require.NoError(t, json.Unmarshal([]byte(text), &comment))
+				assert.Equal(t, int64(42), comment.ID)
+				assert.Equal(t, "Fixed typo", comment.Body)
+				assert.Equal(t, "octocat", comment.User.Login)
+			},
+		},
+		{
+			name: "updates a review comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposPullsCommentsByOwnerByRepoByCommentID,
+					expectRequestBody(t, map[string]any{"body": "Use a constant here"}).andThen(
+						mockResponse(t, http.StatusOK, &github.PullRequestComment{
+							ID:   github.Ptr(int64(7)),
+							Body: github.Ptr("Use a constant here"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "update",
+				"owner":        "owner",
+				"repo":         "repo",
+				"comment_id":   float64(7),
+				"comment_type": "review_comment",
+				"body":         "Use a constant here",
+			},
+			verify: func(t *testing.T, text string) {
+				var comment MinimalComment
+				require.NoError(t, json.Unmarshal([]byte(text), &comment))
+				assert.Equal(t, int64(7), comment.ID)
+				assert.Equal(t, "Use a constant here", comment.Body)
+			},
+		},
+		{
+			name: "deletes an issue comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposIssuesCommentsByOwnerByRepoByCommentID,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "delete",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(42),
+			},
+			expectedText: "Comment 42 deleted from owner/repo",
+		},
+		{
+			name: "deletes a review comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposPullsCommentsByOwnerByRepoByCommentID,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "delete",
+				"owner":        "owner",
+				"repo":         "repo",
+				"comment_id":   float64(7),
+				"comment_type": "review_comment",
+			},
+			expectedText: "Comment 7 deleted from owner/repo",
+		},
+		{
+			name:         "update requires body",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "body is required for update",
+		},
+		{
+			name: "delete fails without permission",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposIssuesCommentsByOwnerByRepoByCommentID,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "delete",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete issue comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+			}
+			if tc.verify != nil {
+				tc.verify(t, textContent.Text)
+			}
+		})
+	}
+}
+
+func Test_ReactionWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ReactionWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "reaction_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "subject_type")
+	assert.Contains(t, schema.Properties, "content")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "subject_type", "content"})
+
+	authenticatedUser := &github.User{Login: github.Ptr("octocat")}
+	reactions := []*github.Reaction{
+		{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("hubot")}},
+		{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("octocat")}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		verify         func(t *testing.T, text string)
+	}{
+		{
+			name: "adds a reaction to an issue",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"content": "+1"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reaction{
+							ID:      github.Ptr(int64(2)),
+							Content: github.Ptr("+1"),
+							User:    authenticatedUser,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "add",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"issue_number": float64(1),
+				"content":      "+1",
+			},
+			verify: func(t *testing.T, text string) {
+				var reaction MinimalReaction
+				require.NoError(t, json.Unmarshal([]byte(text), &reaction))
+				assert.Equal(t, int64(2), reaction.ID)
+				assert.Equal(t, "+1", reaction.Content)
+				assert.Equal(t, "octocat", reaction.User.Login)
+			},
+		},
+		{
+			name: "adds a reaction to a review comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposPullsCommentsReactionsByOwnerByRepoByCommentID,
+					expectRequestBody(t, map[string]any{"content": "eyes"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reaction{
+							ID:      github.Ptr(int64(3)),
+							Content: github.Ptr("eyes"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "add",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "review_comment",
+				"comment_id":   float64(7),
+				"content":      "eyes",
+			},
+			verify: func(t *testing.T, text string) {
+				var reaction MinimalReaction
+				require.NoError(t, json.Unmarshal([]byte(text), &reaction))
+				assert.Equal(t, "eyes", reaction.Content)
+			},
+		},
+		{
+			name: "removes the reaction of the authenticated user from an issue comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetUser, authenticatedUser),
+				WithRequestMatchHandler(
+					GetReposIssuesCommentsReactionsByOwnerByRepoByCommentID,
+					expectQueryParams(t, map[string]string{
+						"content":  "+1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, reactions),
+					),
+				),
+				WithRequestMatchHandler(
+					DeleteReposIssuesCommentsReactionsByOwnerByRepoByCommentIDByReactID,
+					func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/issues/comments/42/reactions/2", r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "remove",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"comment_id":   float64(42),
+				"content":      "+1",
+			},
+			expectedText: "Reaction +1 removed",
+		},
+		{
+			name: "remove fails when the user has not reacted",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetUser, &github.User{Login: github.Ptr("monalisa")}),
+				WithRequestMatch(GetReposIssuesReactionsByOwnerByRepoByIssueNumber, reactions),
+			),
+			requestArgs: map[string]any{
+				"method":       "remove",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"issue_number": float64(1),
+				"content":      "+1",
+			},
+			expectError:    true,
+			expectedErrMsg: "monalisa has no +1 reaction on this issue",
+		},
+		{
+			name:         "comment reactions require comment_id",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "add",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"issue_number": float64(1),
+				"content":      "heart",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: comment_id",
+		},
+		{
+			name:         "rejects unknown reactions",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "add",
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"issue_number": float64(1),
+				"content":      "thumbsup",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid reaction content: thumbsup",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+			}
+			if tc.verify != nil {
+				tc.verify(t, textContent.Text)
+			}
+		})
+	}
+}
+
+func Test_MinimizeComment(t *testing.T) {
+	// Verify tool definition once
+	serverTool := MinimizeComment(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "minimize_comment", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "classifier")
+	assert.Contains(t, schema.Properties, "node_id")
+	assert.ElementsMatch(t, schema.Required, []string{"method"})
+
+	minimizeMutation := struct {
+		MinimizeComment struct {
+			MinimizedComment struct {
+				IsMinimized     githubv4.Boolean
+				MinimizedReason githubv4.String
+			}
+		} `graphql:"minimizeComment(input: $input)"`
+	}{}
+	unminimizeMutation := struct {
+		UnminimizeComment struct {
+			UnminimizedComment struct {
+				IsMinimized githubv4.Boolean
+			}
+		} `graphql:"unminimizeComment(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		mockedGQL      *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       MinimizedComment
+	}{
+		{
+			name: "minimizes an issue comment as spam",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposIssuesCommentsByOwnerByRepoByCommentID, &github.IssueComment{
+					ID:     github.Ptr(int64(42)),
+					NodeID: github.Ptr("IC_42"),
+				}),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					minimizeMutation,
+					githubv4.MinimizeCommentInput{
+						SubjectID:  githubv4.ID("IC_42"),
+						Classifier: githubv4.ReportedContentClassifiersSpam,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"minimizeComment": map[string]any{
+							"minimizedComment": map[string]any{
+								"isMinimized":     true,
+								"minimizedReason": "spam",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "minimize",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(42),
+				"classifier": "SPAM",
+			},
+			expected: MinimizedComment{NodeID: "IC_42", IsMinimized: true, MinimizedReason: "spam"},
+		},
+		{
+			name: "minimizes a review comment",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposPullsCommentsByOwnerByRepoByCommentID, &github.PullRequestComment{
+					ID:     github.Ptr(int64(7)),
+					NodeID: github.Ptr("PRRC_7"),
+				}),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					minimizeMutation,
+					githubv4.MinimizeCommentInput{
+						SubjectID:  githubv4.ID("PRRC_7"),
+						Classifier: githubv4.ReportedContentClassifiersOutdated,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"minimizeComment": map[string]any{
+							"minimizedComment": map[string]any{
+								"isMinimized":     true,
+								"minimizedReason": "outdated",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "minimize",
+				"owner":        "owner",
+				"repo":         "repo",
+				"comment_id":   float64(7),
+				"comment_type": "review_comment",
+				"classifier":   "OUTDATED",
+			},
+			expected: MinimizedComment{NodeID: "PRRC_7", IsMinimized: true, MinimizedReason: "outdated"},
+		},
+		{
+			name:         "unminimizes a comment by node ID",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					unminimizeMutation,
+					githubv4.UnminimizeCommentInput{
+						SubjectID: githubv4.ID("DC_1"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unminimizeComment": map[string]any{
+							"unminimizedComment": map[string]any{
+								"isMinimized": false,
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":  "unminimize",
+				"node_id": "DC_1",
+			},
+			expected: MinimizedComment{NodeID: "DC_1", IsMinimized: false},
+		},
+		{
+			name:         "minimize requires classifier",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL:    githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":  "minimize",
+				"node_id": "IC_42",
+			},
+			expectError:    true,
+			expectedErrMsg: "classifier is required for minimize",
+		},
+		{
+			name:         "requires comment_id or node_id",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL:    githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "unminimize",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "either comment_id or node_id is required",
+		},
+		{
+			name: "comment not found",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposIssuesCommentsByOwnerByRepoByCommentID,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":     "minimize",
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(99),
+				"classifier": "ABUSE",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQL)
+			deps := BaseDeps{
+				Client:    client,
+				GQLClient: gqlClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var minimized MinimizedComment
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &minimized))
+			assert.Equal(t, tc.expected, minimized)
+		})
+	}
+}
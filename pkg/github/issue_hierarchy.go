@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Limits for walking sub-issue hierarchies. GitHub allows up to 8 levels of nesting and 100
+// sub-issues per parent, so a single page of sub-issues is always complete.
+const (
+	defaultSubIssueTreeDepth = 3
+	maxSubIssueTreeDepth     = 8
+	defaultSubIssueTreeNodes = 100
+	maxSubIssueTreeNodes     = 500
+	subIssueTreeConcurrency  = 5
+	maxSubIssuesPerIssue     = 100
+)
+
+// SubIssueProgress is the completion of a set of issues. Closed issues count as completed, as
+// they do in the sub-issue progress shown on GitHub.
+type SubIssueProgress struct {
+	Total            int `json:"total"`
+	Completed        int `json:"completed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+func newSubIssueProgress(total, completed int) SubIssueProgress {
+	progress := SubIssueProgress{Total: total, Completed: completed}
+	if total > 0 {
+		progress.PercentCompleted = completed * 100 / total
+	}
+	return progress
+}
+
+// SubIssueNode is an issue in a sub-issue hierarchy.
+type SubIssueNode struct {
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	State       string   `json:"state"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Assignees   []string `json:"assignees"`
+	Repository  string   `json:"repository"`
+	HTMLURL     string   `json:"html_url"`
+
+	// Progress is the completion of every loaded descendant of the issue.
+	Progress  *SubIssueProgress `json:"progress,omitempty"`
+	SubIssues []*SubIssueNode   `json:"sub_issues,omitempty"`
+
+	owner string
+	repo  string
+}
+
+func newSubIssueNode(issue *github.Issue, owner, repo string) *SubIssueNode {
+	if issueOwner, issueRepo := repositoryFromAPIURL(issue.GetRepositoryURL()); issueOwner != "" {
+		owner, repo = issueOwner, issueRepo
+	}
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+	return &SubIssueNode{
+		Number:      issue.GetNumber(),
+		Title:       sanitize.Sanitize(issue.GetTitle()),
+		State:       issue.GetState(),
+		StateReason: issue.GetStateReason(),
+		Type:        issue.GetType().GetName(),
+		Assignees:   assignees,
+		Repository:  fmt.Sprintf("%s/%s", owner, repo),
+		HTMLURL:     issue.GetHTMLURL(),
+		owner:       owner,
+		repo:        repo,
+	}
+}
+
+// rollup sets the progress of the node and its descendants, and returns the number of
+// descendants and how many of them are closed.
+func (n *SubIssueNode) rollup() (int, int) {
+	total, completed := 0, 0
+	for _, child := range n.SubIssues {
+		childTotal, childCompleted := child.rollup()
+		total += childTotal + 1
+		completed += childCompleted
+		if child.State == "closed" {
+			completed++
+		}
+	}
+	if total > 0 {
+		progress := newSubIssueProgress(total, completed)
+		n.Progress = &progress
+	}
+	return total, completed
+}
+
+// SubIssueLevel is the completion of all loaded sub-issues at one depth of a hierarchy. Depth 1
+// holds the direct sub-issues of the root.
+type SubIssueLevel struct {
+	Depth int `json:"depth"`
+	SubIssueProgress
+}
+
+// SubIssueTree is a sub-issue hierarchy rooted at an issue.
+type SubIssueTree struct {
+	Root   *SubIssueNode   `json:"root"`
+	Levels []SubIssueLevel `json:"levels"`
+	Nodes  int             `json:"nodes"`
+	// Truncated reports that the node limit was reached before the requested depth was loaded.
+	Truncated bool `json:"truncated"`
+}
+
+// IssueParentChain is the chain of parents of an issue, from the direct parent up to the root
+// of its hierarchy.
+type IssueParentChain struct {
+	Issue   string          `json:"issue"`
+	Parents []*SubIssueNode `json:"parents"`
+}
+
+// GetSubIssueTree walks the sub-issue hierarchy below an issue, one level at a time, down to
+// depth levels or until maxNodes issues have been loaded.
+func GetSubIssueTree(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int, depth int, maxNodes int) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	featureFlags := deps.GetFlags(ctx)
+	if featureFlags.LockdownMode && cache == nil {
+		return nil, fmt.Errorf("lockdown cache is not configured")
+	}
+
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	root := newSubIssueNode(issue, owner, repo)
+	if featureFlags.LockdownMode {
+		isSafeContent, err := isSafeLogin(ctx, cache, issue.GetUser().GetLogin(), root.owner, root.repo)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+		}
+		if !isSafeContent {
+			return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil
+		}
+	}
+
+	tree := SubIssueTree{Root: root, Levels: []SubIssueLevel{}, Nodes: 1}
+	level := []*SubIssueNode{root}
+	for d := 1; d <= depth && len(level) > 0 && !tree.Truncated; d++ {
+		subIssues := make([][]*github.SubIssue, len(level))
+		responses := make([]*github.Response, len(level))
+		errs := make([]error, len(level))
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, subIssueTreeConcurrency)
+		for i, parent := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				subIssues[i], responses[i], errs[i] = listAllSubIssues(ctx, client, parent.owner, parent.repo, parent.Number)
+			}()
+		}
+		wg.Wait()
+
+		var next []*SubIssueNode
+		closed := 0
+		for i, parent := range level {
+			if errs[i] != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list sub-issues of %s#%d", parent.Repository, parent.Number), responses[i], errs[i]), nil
+			}
+			for _, subIssue := range subIssues[i] {
+				if tree.Nodes >= maxNodes {
+					tree.Truncated = true
+					break
+				}
+				child := newSubIssueNode((*github.Issue)(subIssue), parent.owner, parent.repo)
+				if featureFlags.LockdownMode {
+					isSafeContent, err := isSafeLogin(ctx, cache, (*github.Issue)(subIssue).GetUser().GetLogin(), child.owner, child.repo)
+					if err != nil {
+						return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+					}
+					if !isSafeContent {
+						continue
+					}
+				}
+				parent.SubIssues = append(parent.SubIssues, child)
+				next = append(next, child)
+				tree.Nodes++
+				if child.State == "closed" {
+					closed++
+				}
+			}
+		}
+		if len(next) > 0 {
+			tree.Levels = append(tree.Levels, SubIssueLevel{Depth: d, SubIssueProgress: newSubIssueProgress(len(next), closed)})
+		}
+		level = next
+	}
+	root.rollup()
+
+	return utils.NewToolResultJSON(tree)
+}
+
+// listAllSubIssues returns the sub-issues of an issue.
+func listAllSubIssues(ctx context.Context, client *github.Client, owner, repo string, issueNumber int) ([]*github.SubIssue, *github.Response, error) {
+	opts := &github.IssueListOptions{
+		ListOptions: github.ListOptions{PerPage: maxSubIssuesPerIssue},
+	}
+	subIssues, resp, err := client.SubIssue.ListByIssue(ctx, owner, repo, int64(issueNumber), opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	return subIssues, resp, nil
+}
+
+// GetIssueParentChain returns the parent of an issue, its parent, and so on up to the root of
+// the hierarchy.
+func GetIssueParentChain(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	featureFlags := deps.GetFlags(ctx)
+	if featureFlags.LockdownMode && cache == nil {
+		return nil, fmt.Errorf("lockdown cache is not configured")
+	}
+
+	current := issueRef{owner: owner, repo: repo, number: issueNumber}
+	chain := IssueParentChain{Issue: current.String(), Parents: []*SubIssueNode{}}
+	for range maxSubIssueTreeDepth {
+		u := fmt.Sprintf("repos/%s/%s/issues/%d/parent", url.PathEscape(current.owner), url.PathEscape(current.repo), current.number)
+		req, err := client.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		var parent github.Issue
+		resp, err := client.Do(ctx, req, &parent)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// GitHub answers 404 both for an issue without a parent and for a missing issue.
+			if len(chain.Parents) == 0 {
+				_, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+			break
+		}
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get parent issue", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		node := newSubIssueNode(&parent, current.owner, current.repo)
+		if featureFlags.LockdownMode {
+			isSafeContent, err := isSafeLogin(ctx, cache, parent.GetUser().GetLogin(), node.owner, node.repo)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+			}
+			if !isSafeContent {
+				// Stop at issues from untrusted authors, but keep the ancestors found so far.
+				break
+			}
+		}
+		chain.Parents = append(chain.Parents, node)
+		current = issueRef{owner: node.owner, repo: node.repo, number: node.Number}
+	}
+
+	return utils.NewToolResultJSON(chain)
+}
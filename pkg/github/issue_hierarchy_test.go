@@ -0,0 +1,308 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hierarchyIssue(number int, repo, state, author string) *github.Issue {
+	return &github.Issue{
+		Number:        github.Ptr(number),
+		Title:         github.Ptr(fmt.Sprintf("Issue %d", number)),
+		State:         github.Ptr(state),
+		User:          &github.User{Login: github.Ptr(author)},
+		Assignees:     []*github.User{{Login: github.Ptr("octocat")}},
+		Type:          &github.IssueType{Name: github.Ptr("Task")},
+		RepositoryURL: github.Ptr("https://api.github.com/repos/owner/" + repo),
+		HTMLURL:       github.Ptr(fmt.Sprintf("https://github.com/owner/%s/issues/%d", repo, number)),
+	}
+}
+
+func Test_GetSubIssueTree(t *testing.T) {
+	t.Parallel()
+
+	serverTool := IssueRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_sub_issue_tree")
+	assert.Contains(t, schema.Properties, "depth")
+	assert.Contains(t, schema.Properties, "max_nodes")
+
+	// owner/repo#1 has sub-issues #2 and #3; #2 has owner/api#4 and #5; owner/api#4 has #6.
+	subIssues := map[string][]*github.Issue{
+		"/repos/owner/repo/issues/1/sub_issues": {hierarchyIssue(2, "repo", "open", "maintainer"), hierarchyIssue(3, "repo", "closed", "maintainer")},
+		"/repos/owner/repo/issues/2/sub_issues": {hierarchyIssue(4, "api", "closed", "maintainer"), hierarchyIssue(5, "repo", "open", "testuser")},
+		"/repos/owner/repo/issues/3/sub_issues": {},
+		"/repos/owner/api/issues/4/sub_issues":  {hierarchyIssue(6, "api", "closed", "maintainer")},
+		"/repos/owner/repo/issues/5/sub_issues": {},
+		"/repos/owner/api/issues/6/sub_issues":  {},
+	}
+	handlers := map[string]http.HandlerFunc{
+		GetReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, hierarchyIssue(1, "repo", "open", "maintainer")),
+		GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			issues, ok := subIssues[r.URL.Path]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, issues)(w, r)
+		},
+	}
+
+	numbers := func(nodes []*SubIssueNode) []int {
+		result := make([]int, 0, len(nodes))
+		for _, node := range nodes {
+			result = append(result, node.Number)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name               string
+		handlers           map[string]http.HandlerFunc
+		args               map[string]any
+		lockdownEnabled    bool
+		expectToolError    bool
+		expectedToolErrMsg string
+		check              func(t *testing.T, tree SubIssueTree)
+	}{
+		{
+			name:     "walks the whole hierarchy with rollups",
+			handlers: handlers,
+			check: func(t *testing.T, tree SubIssueTree) {
+				assert.Equal(t, 6, tree.Nodes)
+				assert.False(t, tree.Truncated)
+				assert.Equal(t, []SubIssueLevel{
+					{Depth: 1, SubIssueProgress: SubIssueProgress{Total: 2, Completed: 1, PercentCompleted: 50}},
+					{Depth: 2, SubIssueProgress: SubIssueProgress{Total: 2, Completed: 1, PercentCompleted: 50}},
+					{Depth: 3, SubIssueProgress: SubIssueProgress{Total: 1, Completed: 1, PercentCompleted: 100}},
+				}, tree.Levels)
+
+				root := tree.Root
+				assert.Equal(t, "owner/repo", root.Repository)
+				assert.Equal(t, &SubIssueProgress{Total: 5, Completed: 3, PercentCompleted: 60}, root.Progress)
+				assert.Equal(t, []int{2, 3}, numbers(root.SubIssues))
+
+				epic := root.SubIssues[0]
+				assert.Equal(t, "Task", epic.Type)
+				assert.Equal(t, []string{"octocat"}, epic.Assignees)
+				assert.Equal(t, &SubIssueProgress{Total: 3, Completed: 2, PercentCompleted: 66}, epic.Progress)
+				assert.Equal(t, []int{4, 5}, numbers(epic.SubIssues))
+				assert.Equal(t, "owner/api", epic.SubIssues[0].Repository)
+				assert.Equal(t, []int{6}, numbers(epic.SubIssues[0].SubIssues))
+				assert.Nil(t, root.SubIssues[1].Progress)
+			},
+		},
+		{
+			name:     "stops at the requested depth",
+			handlers: handlers,
+			args:     map[string]any{"depth": float64(1)},
+			check: func(t *testing.T, tree SubIssueTree) {
+				assert.Equal(t, 3, tree.Nodes)
+				assert.Len(t, tree.Levels, 1)
+				assert.Empty(t, tree.Root.SubIssues[0].SubIssues)
+				assert.Equal(t, &SubIssueProgress{Total: 2, Completed: 1, PercentCompleted: 50}, tree.Root.Progress)
+			},
+		},
+		{
+			name:     "stops at the node limit",
+			handlers: handlers,
+			args:     map[string]any{"max_nodes": float64(4)},
+			check: func(t *testing.T, tree SubIssueTree) {
+				assert.Equal(t, 4, tree.Nodes)
+				assert.True(t, tree.Truncated)
+				assert.Equal(t, []int{4}, numbers(tree.Root.SubIssues[0].SubIssues))
+				assert.Len(t, tree.Levels, 2)
+			},
+		},
+		{
+			name:            "lockdown filters sub-issues from users without push access",
+			handlers:        handlers,
+			lockdownEnabled: true,
+			check: func(t *testing.T, tree SubIssueTree) {
+				assert.Equal(t, 5, tree.Nodes)
+				assert.Equal(t, []int{4}, numbers(tree.Root.SubIssues[0].SubIssues))
+			},
+		},
+		{
+			name: "listing sub-issues fails",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber:          mockResponse(t, http.StatusOK, hierarchyIssue(1, "repo", "open", "maintainer")),
+				GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to list sub-issues of owner/repo#1",
+		},
+		{
+			name:               "depth out of range",
+			handlers:           handlers,
+			args:               map[string]any{"depth": float64(9)},
+			expectToolError:    true,
+			expectedToolErrMsg: "depth must be between 1 and 8",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(MockHTTPClientWithHandlers(tc.handlers))
+			gqlClient := githubv4.NewClient(newRepoAccessHTTPClient())
+			deps := BaseDeps{
+				Client:          client,
+				GQLClient:       gqlClient,
+				RepoAccessCache: stubRepoAccessCache(gqlClient, 15*time.Minute),
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			handler := serverTool.Handler(deps)
+
+			args := map[string]any{
+				"method":       "get_sub_issue_tree",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			}
+			for key, value := range tc.args {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var tree SubIssueTree
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &tree))
+			tc.check(t, tree)
+		})
+	}
+}
+
+func Test_GetIssueParentChain(t *testing.T) {
+	t.Parallel()
+
+	serverTool := IssueRead(translations.NullTranslationHelper)
+	assert.Contains(t, serverTool.Tool.InputSchema.(*jsonschema.Schema).Properties["method"].Enum, "get_parent_chain")
+
+	// owner/repo#6 has parent owner/api#4, whose parent is owner/repo#2, whose parent is owner/repo#1.
+	parents := map[string]*github.Issue{
+		"/repos/owner/repo/issues/6/parent": hierarchyIssue(4, "api", "open", "maintainer"),
+		"/repos/owner/api/issues/4/parent":  hierarchyIssue(2, "repo", "open", "maintainer"),
+		"/repos/owner/repo/issues/2/parent": hierarchyIssue(1, "repo", "open", "testuser"),
+	}
+	parentHandler := func(w http.ResponseWriter, r *http.Request) {
+		parent, ok := parents[r.URL.Path]
+		if !ok {
+			mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, parent)(w, r)
+	}
+
+	tests := []struct {
+		name               string
+		issueNumber        int
+		handlers           map[string]http.HandlerFunc
+		lockdownEnabled    bool
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedParents    []string
+	}{
+		{
+			name:        "walks up to the root",
+			issueNumber: 6,
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesParentByOwnerByRepoByIssueNumber: parentHandler,
+			},
+			expectedParents: []string{"owner/api#4", "owner/repo#2", "owner/repo#1"},
+		},
+		{
+			name:        "lockdown stops at parents from users without push access",
+			issueNumber: 6,
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesParentByOwnerByRepoByIssueNumber: parentHandler,
+			},
+			lockdownEnabled: true,
+			expectedParents: []string{"owner/api#4", "owner/repo#2"},
+		},
+		{
+			name:        "issue without parent",
+			issueNumber: 1,
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesParentByOwnerByRepoByIssueNumber: parentHandler,
+				GetReposIssuesByOwnerByRepoByIssueNumber:       mockResponse(t, http.StatusOK, hierarchyIssue(1, "repo", "open", "maintainer")),
+			},
+			expectedParents: []string{},
+		},
+		{
+			name:        "issue not found",
+			issueNumber: 99,
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesParentByOwnerByRepoByIssueNumber: parentHandler,
+				GetReposIssuesByOwnerByRepoByIssueNumber:       mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(MockHTTPClientWithHandlers(tc.handlers))
+			gqlClient := githubv4.NewClient(newRepoAccessHTTPClient())
+			deps := BaseDeps{
+				Client:          client,
+				GQLClient:       gqlClient,
+				RepoAccessCache: stubRepoAccessCache(gqlClient, 15*time.Minute),
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":       "get_parent_chain",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(tc.issueNumber),
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var chain IssueParentChain
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &chain))
+			assert.Equal(t, fmt.Sprintf("owner/repo#%d", tc.issueNumber), chain.Issue)
+
+			refs := make([]string, 0, len(chain.Parents))
+			for _, parent := range chain.Parents {
+				refs = append(refs, fmt.Sprintf("%s#%d", parent.Repository, parent.Number))
+			}
+			assert.Equal(t, tc.expectedParents, refs)
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// Issue dependency relationships. An issue that is blocked by another issue cannot be
+// completed before the other one.
+const (
+	issueDependencyBlockedBy = "blocked_by"
+	issueDependencyBlocking  = "blocking"
+)
+
+// maxIssueDependencies is the number of dependencies requested per relationship. GitHub allows
+// at most 50 dependencies of each kind on an issue.
+const maxIssueDependencies = 100
+
+// IssueDependency is an issue that blocks or is blocked by another issue.
+type IssueDependency struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	Repository string `json:"repository"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// IssueDependencies is the dependency relationships of an issue.
+type IssueDependencies struct {
+	BlockedBy []IssueDependency `json:"blocked_by"`
+	Blocking  []IssueDependency `json:"blocking"`
+}
+
+// IssueLifecycleWrite creates a tool to lock, unlock, pin, unpin and transfer issues.
+func IssueLifecycleWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "issue_lifecycle_write",
+			Description: t("TOOL_ISSUE_LIFECYCLE_WRITE_DESCRIPTION", "Lock or unlock the conversation of an issue or pull request, pin or unpin an issue in its repository, or transfer an issue to another repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ISSUE_LIFECYCLE_WRITE_USER_TITLE", "Lock, pin or transfer issue"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The operation to perform on the issue:
+- 'lock': lock the conversation, optionally with 'lock_reason'. Only collaborators can comment on a locked issue.
+- 'unlock': unlock the conversation.
+- 'pin': pin the issue to the issues page of the repository. A repository can have up to 3 pinned issues.
+- 'unpin': unpin the issue.
+- 'transfer': move the issue to the repository 'target_repo'. Both repositories must be owned by the same user or organization.`,
+						Enum: []any{"lock", "unlock", "pin", "unpin", "transfer"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "The number of the issue",
+					},
+					"lock_reason": {
+						Type:        "string",
+						Description: "For 'lock', the reason for locking the conversation",
+						Enum:        []any{"off-topic", "too heated", "resolved", "spam"},
+					},
+					"target_owner": {
+						Type:        "string",
+						Description: "For 'transfer', the owner of the target repository. Defaults to owner.",
+					},
+					"target_repo": {
+						Type:        "string",
+						Description: "For 'transfer', the name of the target repository. Required for 'transfer'.",
+					},
+					"create_labels_if_missing": {
+						Type:        "boolean",
+						Description: "For 'transfer', create the labels of the issue that do not exist in the target repository. Labels are dropped otherwise.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "issue_number"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			lockReason, err := OptionalParam[string](args, "lock_reason")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			targetOwner, err := OptionalParam[string](args, "target_owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			targetRepo, err := OptionalParam[string](args, "target_repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			createLabels, err := OptionalParam[bool](args, "create_labels_if_missing")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			switch method {
+			case "lock", "unlock":
+				client, err := deps.GetClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+				}
+
+				var resp *github.Response
+				if method == "lock" {
+					var opts *github.LockIssueOptions
+					if lockReason != "" {
+						opts = &github.LockIssueOptions{LockReason: lockReason}
+					}
+					resp, err = client.Issues.Lock(ctx, owner, repo, issueNumber, opts)
+				} else {
+					resp, err = client.Issues.Unlock(ctx, owner, repo, issueNumber)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to %s issue", method), resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("Issue %s/%s#%d %sed", owner, repo, issueNumber, method)), nil, nil
+			case "pin", "unpin":
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GraphQL client", err), nil, nil
+				}
+				issueID, _, err := fetchIssueIDs(ctx, gqlClient, owner, repo, issueNumber, 0)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get issue", err), nil, nil
+				}
+
+				if method == "pin" {
+					var mutation struct {
+						PinIssue struct {
+							Issue struct {
+								ID githubv4.ID
+							}
+						} `graphql:"pinIssue(input: $input)"`
+					}
+					if err := gqlClient.Mutate(ctx, &mutation, githubv4.PinIssueInput{IssueID: issueID}, nil); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to pin issue", err), nil, nil
+					}
+				} else {
+					var mutation struct {
+						UnpinIssue struct {
+							Issue struct {
+								ID githubv4.ID
+							}
+						} `graphql:"unpinIssue(input: $input)"`
+					}
+					if err := gqlClient.Mutate(ctx, &mutation, githubv4.UnpinIssueInput{IssueID: issueID}, nil); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unpin issue", err), nil, nil
+					}
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("Issue %s/%s#%d %sned", owner, repo, issueNumber, method)), nil, nil
+			case "transfer":
+				if targetRepo == "" {
+					return utils.NewToolResultError("target_repo is required for transfer"), nil, nil
+				}
+				if targetOwner == "" {
+					targetOwner = owner
+				}
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GraphQL client", err), nil, nil
+				}
+				result, err := TransferIssue(ctx, gqlClient, owner, repo, issueNumber, targetOwner, targetRepo, createLabels)
+				return result, nil, err
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: lock, unlock, pin, unpin, transfer", method)), nil, nil
+			}
+		},
+	)
+}
+
+// TransferIssue moves an issue to another repository and returns its new location.
+func TransferIssue(ctx context.Context, gqlClient *githubv4.Client, owner, repo string, issueNumber int, targetOwner, targetRepo string, createLabelsIfMissing bool) (*mcp.CallToolResult, error) {
+	var query struct {
+		Repository struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: $issueNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+		TargetRepository struct {
+			ID githubv4.ID
+		} `graphql:"targetRepository: repository(owner: $targetOwner, name: $targetRepo)"`
+	}
+	vars := map[string]any{
+		"owner":       githubv4.String(owner),
+		"repo":        githubv4.String(repo),
+		"issueNumber": githubv4.Int(issueNumber), // #nosec G115 - issue numbers are always small positive integers
+		"targetOwner": githubv4.String(targetOwner),
+		"targetRepo":  githubv4.String(targetRepo),
+	}
+	if err := gqlClient.Query(ctx, &query, vars); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get issue and target repository", err), nil
+	}
+
+	var mutation struct {
+		TransferIssue struct {
+			Issue struct {
+				Number githubv4.Int
+				URL    githubv4.URI
+			}
+		} `graphql:"transferIssue(input: $input)"`
+	}
+	input := githubv4.TransferIssueInput{
+		IssueID:      query.Repository.Issue.ID,
+		RepositoryID: query.TargetRepository.ID,
+	}
+	if createLabelsIfMissing {
+		input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to transfer issue", err), nil
+	}
+
+	return utils.NewToolResultJSON(map[string]any{
+		"number":     int(mutation.TransferIssue.Issue.Number),
+		"repository": fmt.Sprintf("%s/%s", targetOwner, targetRepo),
+		"url":        mutation.TransferIssue.Issue.URL.String(),
+	})
+}
+
+// IssueDependencyWrite creates a tool to add and remove "blocked by" relationships between issues.
+func IssueDependencyWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "issue_dependency_write",
+			Description: t("TOOL_ISSUE_DEPENDENCY_WRITE_DESCRIPTION", "Add or remove a dependency between two issues, marking an issue as blocked by or blocking another issue. The other issue can be in another repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ISSUE_DEPENDENCY_WRITE_USER_TITLE", "Change issue dependencies"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The operation to perform:
+- 'add': add the dependency.
+- 'remove': remove the dependency.`,
+						Enum: []any{"add", "remove"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "The number of the issue",
+					},
+					"relationship": {
+						Type:        "string",
+						Description: "How the issue relates to the other issue: 'blocked_by' when the issue cannot be completed before the other issue, 'blocking' when the other issue cannot be completed before the issue.",
+						Enum:        []any{issueDependencyBlockedBy, issueDependencyBlocking},
+					},
+					"other_issue_number": {
+						Type:        "number",
+						Description: "The number of the other issue",
+					},
+					"other_owner": {
+						Type:        "string",
+						Description: "The owner of the repository of the other issue. Defaults to owner.",
+					},
+					"other_repo": {
+						Type:        "string",
+						Description: "The name of the repository of the other issue. Defaults to repo.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "issue_number", "relationship", "other_issue_number"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			relationship, err := RequiredParam[string](args, "relationship")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			otherNumber, err := RequiredInt(args, "other_issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			otherOwner, err := OptionalParam[string](args, "other_owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			otherRepo, err := OptionalParam[string](args, "other_repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if otherOwner == "" {
+				otherOwner = owner
+			}
+			if otherRepo == "" {
+				otherRepo = repo
+			}
+
+			// Dependencies are stored on the blocked issue as the list of issues blocking it.
+			blocked := issueRef{owner: owner, repo: repo, number: issueNumber}
+			blocking := issueRef{owner: otherOwner, repo: otherRepo, number: otherNumber}
+			switch relationship {
+			case issueDependencyBlockedBy:
+			case issueDependencyBlocking:
+				blocked, blocking = blocking, blocked
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown relationship: %s. Supported relationships are: blocked_by, blocking", relationship)), nil, nil
+			}
+			if blocked == blocking {
+				return utils.NewToolResultError("an issue cannot depend on itself"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			blockingIssue, resp, err := client.Issues.Get(ctx, blocking.owner, blocking.repo, blocking.number)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get issue %s", blocking), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			u := fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/blocked_by", url.PathEscape(blocked.owner), url.PathEscape(blocked.repo), blocked.number)
+			var req *http.Request
+			switch method {
+			case "add":
+				req, err = client.NewRequest(http.MethodPost, u, map[string]int64{"issue_id": blockingIssue.GetID()})
+			case "remove":
+				req, err = client.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", u, blockingIssue.GetID()), nil)
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: add, remove", method)), nil, nil
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			resp, err = client.Do(ctx, req, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to %s dependency", method), resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if method == "add" {
+				return utils.NewToolResultText(fmt.Sprintf("Issue %s is now blocked by %s", blocked, blocking)), nil, nil
+			}
+			return utils.NewToolResultText(fmt.Sprintf("Issue %s is no longer blocked by %s", blocked, blocking)), nil, nil
+		},
+	)
+}
+
+// issueRef identifies an issue across repositories.
+type issueRef struct {
+	owner  string
+	repo   string
+	number int
+}
+
+func (r issueRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.owner, r.repo, r.number)
+}
+
+// GetIssueDependencies returns the issues blocking an issue and the issues it blocks.
+func GetIssueDependencies(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	featureFlags := deps.GetFlags(ctx)
+	if featureFlags.LockdownMode && cache == nil {
+		return nil, fmt.Errorf("lockdown cache is not configured")
+	}
+
+	dependencies := IssueDependencies{
+		BlockedBy: []IssueDependency{},
+		Blocking:  []IssueDependency{},
+	}
+	for _, relationship := range []string{issueDependencyBlockedBy, issueDependencyBlocking} {
+		u := fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/%s?per_page=%d", url.PathEscape(owner), url.PathEscape(repo), issueNumber, relationship, maxIssueDependencies)
+		req, err := client.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		var issues []*github.Issue
+		resp, err := client.Do(ctx, req, &issues)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue dependencies", resp, err), nil
+		}
+		_ = resp.Body.Close()
+
+		for _, issue := range issues {
+			depOwner, depRepo := repositoryFromAPIURL(issue.GetRepositoryURL())
+			if featureFlags.LockdownMode {
+				isSafeContent, err := isSafeLogin(ctx, cache, issue.GetUser().GetLogin(), depOwner, depRepo)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+				}
+				if !isSafeContent {
+					continue
+				}
+			}
+
+			dependency := IssueDependency{
+				Number:     issue.GetNumber(),
+				Title:      sanitize.Sanitize(issue.GetTitle()),
+				State:      issue.GetState(),
+				Repository: fmt.Sprintf("%s/%s", depOwner, depRepo),
+				HTMLURL:    issue.GetHTMLURL(),
+			}
+			if relationship == issueDependencyBlockedBy {
+				dependencies.BlockedBy = append(dependencies.BlockedBy, dependency)
+			} else {
+				dependencies.Blocking = append(dependencies.Blocking, dependency)
+			}
+		}
+	}
+
+	return utils.NewToolResultJSON(dependencies)
+}
+
+// repositoryFromAPIURL returns the owner and name of a repository from its REST API URL, such as
+// https://api.github.com/repos/owner/repo.
+func repositoryFromAPIURL(apiURL string) (string, string) {
+	_, path, ok := strings.Cut(apiURL, "/repos/")
+	if !ok {
+		return "", ""
+	}
+	owner, repo, _ := strings.Cut(path, "/")
+	return owner, repo
+}
@@ -0,0 +1,562 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueLifecycleWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := IssueLifecycleWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "issue_lifecycle_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "lock_reason")
+	assert.Contains(t, schema.Properties, "target_repo")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "issue_number"})
+
+	issueIDQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				Issue struct {
+					ID githubv4.ID
+				} `graphql:"issue(number: $issueNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":       githubv4.String("owner"),
+			"repo":        githubv4.String("repo"),
+			"issueNumber": githubv4.Int(1),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issue": map[string]any{"id": "I_1"},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		mockedGQL      *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "locks an issue with a reason",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"lock_reason": "too heated"}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "lock",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+				"lock_reason":  "too heated",
+			},
+			expectedText: "Issue owner/repo#1 locked",
+		},
+		{
+			name: "unlocks an issue",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "unlock",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			},
+			expectedText: "Issue owner/repo#1 unlocked",
+		},
+		{
+			name:         "pins an issue",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				issueIDQuery,
+				githubv4mock.NewMutationMatcher(
+					struct {
+						PinIssue struct {
+							Issue struct {
+								ID githubv4.ID
+							}
+						} `graphql:"pinIssue(input: $input)"`
+					}{},
+					githubv4.PinIssueInput{IssueID: githubv4.ID("I_1")},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"pinIssue": map[string]any{
+							"issue": map[string]any{"id": "I_1"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "pin",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			},
+			expectedText: "Issue owner/repo#1 pinned",
+		},
+		{
+			name:         "unpins an issue",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				issueIDQuery,
+				githubv4mock.NewMutationMatcher(
+					struct {
+						UnpinIssue struct {
+							Issue struct {
+								ID githubv4.ID
+							}
+						} `graphql:"unpinIssue(input: $input)"`
+					}{},
+					githubv4.UnpinIssueInput{IssueID: githubv4.ID("I_1")},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unpinIssue": map[string]any{
+							"issue": map[string]any{"id": "I_1"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":       "unpin",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			},
+			expectedText: "Issue owner/repo#1 unpinned",
+		},
+		{
+			name:         "transfers an issue",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Repository struct {
+							Issue struct {
+								ID githubv4.ID
+							} `graphql:"issue(number: $issueNumber)"`
+						} `graphql:"repository(owner: $owner, name: $repo)"`
+						TargetRepository struct {
+							ID githubv4.ID
+						} `graphql:"targetRepository: repository(owner: $targetOwner, name: $targetRepo)"`
+					}{},
+					map[string]any{
+						"owner":       githubv4.String("owner"),
+						"repo":        githubv4.String("repo"),
+						"issueNumber": githubv4.Int(1),
+						"targetOwner": githubv4.String("owner"),
+						"targetRepo":  githubv4.String("other"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository":       map[string]any{"issue": map[string]any{"id": "I_1"}},
+						"targetRepository": map[string]any{"id": "R_2"},
+					}),
+				),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						TransferIssue struct {
+							Issue struct {
+								Number githubv4.Int
+								URL    githubv4.URI
+							}
+						} `graphql:"transferIssue(input: $input)"`
+					}{},
+					githubv4.TransferIssueInput{
+						IssueID:               githubv4.ID("I_1"),
+						RepositoryID:          githubv4.ID("R_2"),
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"transferIssue": map[string]any{
+							"issue": map[string]any{
+								"number": 12,
+								"url":    "https://github.com/owner/other/issues/12",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":                   "transfer",
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(1),
+				"target_repo":              "other",
+				"create_labels_if_missing": true,
+			},
+			expectedText: `{"number":12,"repository":"owner/other","url":"https://github.com/owner/other/issues/12"}`,
+		},
+		{
+			name:         "transfer requires target_repo",
+			mockedClient: NewMockedHTTPClient(),
+			mockedGQL:    githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "transfer",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "target_repo is required for transfer",
+		},
+		{
+			name: "lock fails without permission",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			mockedGQL: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":       "lock",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to lock issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQL)
+			deps := BaseDeps{
+				Client:    client,
+				GQLClient: gqlClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_IssueDependencyWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := IssueDependencyWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "issue_dependency_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "relationship")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "issue_number", "relationship", "other_issue_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "adds a blocked by dependency on an issue in another repository",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/api/issues/5").andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{ID: github.Ptr(int64(5005)), Number: github.Ptr(5)}),
+					),
+				),
+				WithRequestMatchHandler(
+					PostReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/1/dependencies/blocked_by").andThen(
+						expectRequestBody(t, map[string]any{"issue_id": float64(5005)}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(1)}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":             "add",
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"relationship":       "blocked_by",
+				"other_issue_number": float64(5),
+				"other_repo":         "api",
+			},
+			expectedText: "Issue owner/repo#1 is now blocked by owner/api#5",
+		},
+		{
+			name: "adds a blocking dependency to the other issue",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/1").andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{ID: github.Ptr(int64(1001)), Number: github.Ptr(1)}),
+					),
+				),
+				WithRequestMatchHandler(
+					PostReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/2/dependencies/blocked_by").andThen(
+						expectRequestBody(t, map[string]any{"issue_id": float64(1001)}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(2)}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":             "add",
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"relationship":       "blocking",
+				"other_issue_number": float64(2),
+			},
+			expectedText: "Issue owner/repo#2 is now blocked by owner/repo#1",
+		},
+		{
+			name: "removes a dependency",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposIssuesByOwnerByRepoByIssueNumber, &github.Issue{ID: github.Ptr(int64(2002)), Number: github.Ptr(2)}),
+				WithRequestMatchHandler(
+					DeleteReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumberByID,
+					expectPath(t, "/repos/owner/repo/issues/1/dependencies/blocked_by/2002").andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(2)}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":             "remove",
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"relationship":       "blocked_by",
+				"other_issue_number": float64(2),
+			},
+			expectedText: "Issue owner/repo#1 is no longer blocked by owner/repo#2",
+		},
+		{
+			name:         "rejects a dependency on itself",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":             "add",
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"relationship":       "blocked_by",
+				"other_issue_number": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "an issue cannot depend on itself",
+		},
+		{
+			name: "other issue not found",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":             "add",
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"relationship":       "blocked_by",
+				"other_issue_number": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue owner/repo#99",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GetIssueDependencies(t *testing.T) {
+	t.Parallel()
+
+	serverTool := IssueRead(translations.NullTranslationHelper)
+	assert.Contains(t, serverTool.Tool.InputSchema.(*jsonschema.Schema).Properties["method"].Enum, "get_dependencies")
+
+	blockedBy := []*github.Issue{
+		{
+			Number:        github.Ptr(5),
+			Title:         github.Ptr("Design the\u200b API"),
+			State:         github.Ptr("open"),
+			User:          &github.User{Login: github.Ptr("maintainer")},
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/api"),
+			HTMLURL:       github.Ptr("https://github.com/owner/api/issues/5"),
+		},
+		{
+			Number:        github.Ptr(6),
+			Title:         github.Ptr("Spam title"),
+			State:         github.Ptr("open"),
+			User:          &github.User{Login: github.Ptr("testuser")},
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/6"),
+		},
+	}
+	blocking := []*github.Issue{
+		{
+			Number:        github.Ptr(9),
+			Title:         github.Ptr("Ship the release"),
+			State:         github.Ptr("closed"),
+			User:          &github.User{Login: github.Ptr("maintainer")},
+			RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/9"),
+		},
+	}
+
+	tests := []struct {
+		name               string
+		handlers           map[string]http.HandlerFunc
+		lockdownEnabled    bool
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedBlockedBy  []int
+		expectedBlocking   []int
+	}{
+		{
+			name: "lists blocked by and blocking issues",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber: expectQueryParams(t, map[string]string{
+					"per_page": "100",
+				}).andThen(mockResponse(t, http.StatusOK, blockedBy)),
+				GetReposIssuesDependenciesBlockingByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, blocking),
+			},
+			expectedBlockedBy: []int{5, 6},
+			expectedBlocking:  []int{9},
+		},
+		{
+			name: "lockdown filters dependencies from users without push access",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, blockedBy),
+				GetReposIssuesDependenciesBlockingByOwnerByRepoByIssueNumber:  mockResponse(t, http.StatusOK, blocking),
+			},
+			lockdownEnabled:   true,
+			expectedBlockedBy: []int{5},
+			expectedBlocking:  []int{9},
+		},
+		{
+			name: "issue not found",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesDependenciesBlockedByByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to list issue dependencies",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(MockHTTPClientWithHandlers(tc.handlers))
+			gqlClient := githubv4.NewClient(newRepoAccessHTTPClient())
+			deps := BaseDeps{
+				Client:          client,
+				GQLClient:       gqlClient,
+				RepoAccessCache: stubRepoAccessCache(gqlClient, 15*time.Minute),
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":       "get_dependencies",
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var dependencies IssueDependencies
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &dependencies))
+
+			blockedByNumbers := make([]int, 0, len(dependencies.BlockedBy))
+			for _, dependency := range dependencies.BlockedBy {
+				blockedByNumbers = append(blockedByNumbers, dependency.Number)
+			}
+			blockingNumbers := make([]int, 0, len(dependencies.Blocking))
+			for _, dependency := range dependencies.Blocking {
+				blockingNumbers = append(blockingNumbers, dependency.Number)
+			}
+			assert.Equal(t, tc.expectedBlockedBy, blockedByNumbers)
+			assert.Equal(t, tc.expectedBlocking, blockingNumbers)
+			assert.Equal(t, "owner/api", dependencies.BlockedBy[0].Repository)
+			assert.Equal(t, "Design the API", dependencies.BlockedBy[0].Title)
+		})
+	}
+}
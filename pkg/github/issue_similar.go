@@ -0,0 +1,455 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultSimilarIssues and maxSimilarIssues bound the number of issues returned.
+	defaultSimilarIssues = 10
+	maxSimilarIssues     = 30
+	// similarIssueSearchResults is the number of candidates requested per derived search query.
+	similarIssueSearchResults = 30
+	// minSimilarIssueScore drops candidates that only share a common word with the issue.
+	minSimilarIssueScore = 0.1
+	// verySimilarIssueScore is the score from which an open issue is reported as a likely
+	// duplicate by issue_write.
+	verySimilarIssueScore = 0.6
+)
+
+// Weights of the title, body and label similarity in the score of a candidate.
+const (
+	similarIssueTitleWeight = 0.6
+	similarIssueBodyWeight  = 0.3
+	similarIssueLabelWeight = 0.1
+)
+
+// similarIssueStopWords are words too common in issues to tell them apart.
+var similarIssueStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true,
+	"all": true, "can": true, "has": true, "had": true, "was": true, "one": true, "our": true,
+	"out": true, "get": true, "use": true, "how": true, "its": true, "may": true, "new": true,
+	"now": true, "see": true, "way": true, "who": true, "did": true, "does": true, "doesn": true,
+	"don": true, "this": true, "that": true, "with": true, "from": true, "have": true, "when": true,
+	"what": true, "will": true, "would": true, "should": true, "could": true, "there": true,
+	"their": true, "they": true, "them": true, "then": true, "than": true, "which": true,
+	"while": true, "into": true, "some": true, "also": true, "only": true, "just": true,
+	"been": true, "being": true, "were": true, "where": true, "here": true, "after": true,
+	"before": true, "about": true, "more": true, "other": true, "like": true, "using": true,
+	"used": true, "issue": true, "issues": true, "please": true, "thanks": true, "thank": true,
+	"any": true, "why": true, "isn": true, "instead": true, "still": true, "same": true,
+}
+
+// SimilarIssue is an issue similar to the one being looked up.
+type SimilarIssue struct {
+	Number       int      `json:"number"`
+	Title        string   `json:"title"`
+	State        string   `json:"state"`
+	StateReason  string   `json:"state_reason,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+	HTMLURL      string   `json:"html_url"`
+	Score        float64  `json:"score"`
+	MatchedTerms []string `json:"matched_terms,omitempty"`
+}
+
+// SimilarIssuesResult is the ranked list of issues similar to a title and body.
+type SimilarIssuesResult struct {
+	Queries       []string       `json:"queries"`
+	FailedQueries int            `json:"failed_queries,omitempty"`
+	Candidates    int            `json:"candidates"`
+	Issues        []SimilarIssue `json:"issues"`
+}
+
+// FindSimilarIssues creates a tool to find issues similar to a title and body or to an issue.
+func FindSimilarIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "find_similar_issues",
+			Description: t("TOOL_FIND_SIMILAR_ISSUES_DESCRIPTION", "Find existing issues of a repository similar to a title and body, or to an existing issue. Runs several searches derived from the text and ranks the results by title, body and label overlap with a similarity score between 0 and 1. Use before creating an issue to find duplicates and related reports."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_FIND_SIMILAR_ISSUES_USER_TITLE", "Find similar issues"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"title": {
+						Type:        "string",
+						Description: "The title of the issue to find similar issues for. Required unless issue_number is provided.",
+					},
+					"body": {
+						Type:        "string",
+						Description: "The body of the issue to find similar issues for",
+					},
+					"labels": {
+						Type:        "array",
+						Description: "The labels of the issue to find similar issues for. Issues sharing labels rank higher.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "An existing issue to find similar issues for, instead of title, body and labels",
+					},
+					"state": {
+						Type:        "string",
+						Description: "Only return issues in this state. Defaults to all.",
+						Enum:        []any{"open", "closed", "all"},
+					},
+					"limit": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of issues to return (default %d, max %d)", defaultSimilarIssues, maxSimilarIssues),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxSimilarIssues)),
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			title, err := OptionalParam[string](args, "title")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			body, err := OptionalParam[string](args, "body")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			labels, err := OptionalStringArrayParam(args, "labels")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := OptionalIntParam(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := OptionalParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			limit, err := OptionalIntParamWithDefault(args, "limit", defaultSimilarIssues)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if limit < 1 || limit > maxSimilarIssues {
+				return utils.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxSimilarIssues)), nil, nil
+			}
+			if issueNumber == 0 && title == "" {
+				return utils.NewToolResultError("either title or issue_number is required"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			if issueNumber != 0 {
+				issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				title = issue.GetTitle()
+				body = issue.GetBody()
+				labels = nil
+				for _, label := range issue.Labels {
+					labels = append(labels, label.GetName())
+				}
+			}
+
+			result, resp, err := findSimilarIssues(ctx, client, owner, repo, title, body, labels, state, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search issues", resp, err), nil, nil
+			}
+			if len(result.Issues) > limit {
+				result.Issues = result.Issues[:limit]
+			}
+
+			toolResult, err := utils.NewToolResultJSON(result)
+			if err != nil {
+				return nil, nil, err
+			}
+			return toolResult, nil, nil
+		},
+	)
+}
+
+// findSimilarIssues searches the issues of a repository with queries derived from a title, body
+// and labels, and ranks the candidates by similarity. The issue excludeNumber is left out of the
+// results. An error is only returned when every query fails.
+func findSimilarIssues(ctx context.Context, client *github.Client, owner, repo, title, body string, labels []string, state string, excludeNumber int) (SimilarIssuesResult, *github.Response, error) {
+	titleTerms := similarIssueTerms(title)
+	bodyTerms := similarIssueTerms(body)
+	queries := similarIssueQueries(titleTerms, bodyTerms, labels)
+	result := SimilarIssuesResult{Queries: queries, Issues: []SimilarIssue{}}
+	if len(queries) == 0 {
+		return result, nil, nil
+	}
+
+	qualifiers := fmt.Sprintf("repo:%s/%s is:issue", owner, repo)
+	if state == "open" || state == "closed" {
+		qualifiers += " state:" + state
+	}
+
+	type searchResult struct {
+		issues []*github.Issue
+		resp   *github.Response
+		err    error
+	}
+	results := make([]searchResult, len(queries))
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: similarIssueSearchResults}}
+			found, resp, err := client.Search.Issues(ctx, qualifiers+" "+query, opts)
+			if err != nil {
+				results[i] = searchResult{resp: resp, err: err}
+				return
+			}
+			_ = resp.Body.Close()
+			results[i] = searchResult{issues: found.Issues, resp: resp}
+		}(i, query)
+	}
+	wg.Wait()
+
+	candidates := make(map[int]*github.Issue)
+	var firstErr searchResult
+	for _, r := range results {
+		if r.err != nil {
+			if firstErr.err == nil {
+				firstErr = r
+			}
+			result.FailedQueries++
+			continue
+		}
+		for _, issue := range r.issues {
+			if !issue.IsPullRequest() && issue.GetNumber() != excludeNumber {
+				candidates[issue.GetNumber()] = issue
+			}
+		}
+	}
+	if result.FailedQueries == len(queries) {
+		return result, firstErr.resp, firstErr.err
+	}
+	result.Candidates = len(candidates)
+
+	allTerms := mergeStrings(titleTerms, bodyTerms)
+	for _, issue := range candidates {
+		candidateTitleTerms := similarIssueTerms(issue.GetTitle())
+		candidateTerms := mergeStrings(candidateTitleTerms, similarIssueTerms(issue.GetBody()))
+		var candidateLabels []string
+		for _, label := range issue.Labels {
+			candidateLabels = append(candidateLabels, label.GetName())
+		}
+
+		score := similarIssueScore(titleTerms, allTerms, labels, candidateTitleTerms, candidateTerms, candidateLabels)
+		if score < minSimilarIssueScore {
+			continue
+		}
+		result.Issues = append(result.Issues, SimilarIssue{
+			Number:       issue.GetNumber(),
+			Title:        sanitize.Sanitize(issue.GetTitle()),
+			State:        issue.GetState(),
+			StateReason:  issue.GetStateReason(),
+			Labels:       candidateLabels,
+			HTMLURL:      issue.GetHTMLURL(),
+			Score:        score,
+			MatchedTerms: intersectStrings(titleTerms, candidateTitleTerms),
+		})
+	}
+
+	sort.Slice(result.Issues, func(i, j int) bool {
+		if result.Issues[i].Score != result.Issues[j].Score {
+			return result.Issues[i].Score > result.Issues[j].Score
+		}
+		return result.Issues[i].Number > result.Issues[j].Number
+	})
+	return result, nil, nil
+}
+
+// similarIssueTerms returns the distinct lowercase words of text that are useful to compare
+// issues, in order of first appearance.
+func similarIssueTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(words))
+	var terms []string
+	for _, word := range words {
+		if len([]rune(word)) < 3 || similarIssueStopWords[word] || isNumeric(word) || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// similarIssueQueries derives search queries from the terms of the title and body: a strict
+// query on the title, looser queries that find issues wording the title differently, a query on
+// the body and a query restricted to the labels.
+func similarIssueQueries(titleTerms, bodyTerms []string, labels []string) []string {
+	var queries []string
+	add := func(query string) {
+		if !slices.Contains(queries, query) {
+			queries = append(queries, query)
+		}
+	}
+
+	if len(titleTerms) > 0 {
+		add(strings.Join(firstN(titleTerms, 5), " ") + " in:title")
+		add(strings.Join(firstN(titleTerms, 3), " "))
+		if len(titleTerms) > 3 {
+			add(strings.Join(titleTerms[len(titleTerms)-3:], " "))
+		}
+	}
+
+	var bodyOnlyTerms []string
+	for _, term := range bodyTerms {
+		if !slices.Contains(titleTerms, term) {
+			bodyOnlyTerms = append(bodyOnlyTerms, term)
+		}
+	}
+	if len(bodyOnlyTerms) > 0 {
+		add(strings.Join(firstN(bodyOnlyTerms, 4), " ") + " in:body")
+	}
+
+	if len(labels) > 0 && len(titleTerms) > 0 {
+		var labelQualifiers []string
+		for _, label := range labels {
+			labelQualifiers = append(labelQualifiers, fmt.Sprintf("label:%q", label))
+		}
+		add(strings.Join(firstN(titleTerms, 2), " ") + " " + strings.Join(labelQualifiers, " "))
+	}
+	return queries
+}
+
+// similarIssueScore scores a candidate between 0 and 1 by the overlap of its title, its title and
+// body, and its labels with those of the issue. Label overlap only counts when the issue has labels.
+func similarIssueScore(titleTerms, allTerms, labels, candidateTitleTerms, candidateTerms, candidateLabels []string) float64 {
+	score := similarIssueTitleWeight*jaccard(titleTerms, candidateTitleTerms) +
+		similarIssueBodyWeight*overlap(allTerms, candidateTerms)
+	weights := similarIssueTitleWeight + similarIssueBodyWeight
+	if len(labels) > 0 {
+		score += similarIssueLabelWeight * jaccard(labels, candidateLabels)
+		weights += similarIssueLabelWeight
+	}
+	return math.Round(score/weights*100) / 100
+}
+
+// jaccard returns the size of the intersection of a and b divided by the size of their union.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := len(intersectStrings(a, b))
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// overlap returns the size of the intersection of a and b divided by the size of the smaller
+// one, so that a short report is not penalized for matching a long one.
+func overlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return float64(len(intersectStrings(a, b))) / float64(min(len(a), len(b)))
+}
+
+// intersectStrings returns the strings of a that are also in b, case-insensitively.
+func intersectStrings(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[strings.ToLower(s)] = true
+	}
+	var common []string
+	for _, s := range a {
+		if set[strings.ToLower(s)] {
+			common = append(common, s)
+		}
+	}
+	return common
+}
+
+func firstN(list []string, n int) []string {
+	if len(list) > n {
+		return list[:n]
+	}
+	return list
+}
+
+// SimilarIssuesWarning is returned by issue_write instead of creating an issue when very similar
+// open issues exist.
+type SimilarIssuesWarning struct {
+	Created       bool           `json:"created"`
+	Message       string         `json:"message"`
+	SimilarIssues []SimilarIssue `json:"similar_issues"`
+}
+
+// checkSimilarOpenIssues returns a warning result when open issues very similar to the title, body
+// and labels exist, and nil otherwise.
+func checkSimilarOpenIssues(ctx context.Context, client *github.Client, owner, repo, title, body string, labels []string) (*mcp.CallToolResult, error) {
+	similar, resp, err := findSimilarIssues(ctx, client, owner, repo, title, body, labels, "open", 0)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search for similar issues", resp, err), nil
+	}
+
+	var verySimilar []SimilarIssue
+	for _, issue := range similar.Issues {
+		if issue.Score >= verySimilarIssueScore {
+			verySimilar = append(verySimilar, issue)
+		}
+	}
+	if len(verySimilar) == 0 {
+		return nil, nil
+	}
+
+	return utils.NewToolResultJSON(SimilarIssuesWarning{
+		Created:       false,
+		Message:       fmt.Sprintf("The issue was not created because %d very similar open issue(s) exist. Comment on an existing issue instead, or call issue_write again without check_similar to create it anyway.", len(verySimilar)),
+		SimilarIssues: verySimilar,
+	})
+}
@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_similarIssueTerms(t *testing.T) {
+	assert.Equal(t,
+		[]string{"crash", "opening", "settings", "page", "macos"},
+		similarIssueTerms("Crash when opening the Settings page on macOS 14 (crash!)"),
+	)
+	assert.Empty(t, similarIssueTerms("It is on the way"))
+}
+
+func Test_similarIssueQueries(t *testing.T) {
+	queries := similarIssueQueries(
+		[]string{"crash", "opening", "settings", "page", "macos"},
+		[]string{"crash", "stack", "trace", "shows", "nil", "pointer"},
+		[]string{"bug"},
+	)
+	assert.Equal(t, []string{
+		"crash opening settings page macos in:title",
+		"crash opening settings",
+		"settings page macos",
+		"stack trace shows nil in:body",
+		`crash opening label:"bug"`,
+	}, queries)
+
+	assert.Empty(t, similarIssueQueries(nil, nil, []string{"bug"}))
+}
+
+func Test_similarIssueScore(t *testing.T) {
+	title := []string{"crash", "opening", "settings"}
+	all := []string{"crash", "opening", "settings", "macos"}
+
+	identical := similarIssueScore(title, all, []string{"bug"}, title, all, []string{"bug"})
+	assert.InDelta(t, 1.0, identical, 0.001)
+
+	unrelated := similarIssueScore(title, all, nil, []string{"dark", "mode"}, []string{"dark", "mode"}, nil)
+	assert.InDelta(t, 0.0, unrelated, 0.001)
+
+	// Half of the title terms match and every body term of the candidate is shared.
+	partial := similarIssueScore(title, all, nil, []string{"crash", "settings", "save", "button"}, []string{"crash", "settings"}, nil)
+	assert.InDelta(t, (0.6*2.0/5.0+0.3*1.0)/0.9, partial, 0.01)
+}
+
+func Test_FindSimilarIssues(t *testing.T) {
+	// Verify tool definition once
+	serverTool := FindSimilarIssues(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "find_similar_issues", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "issue_number")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	candidates := []*github.Issue{
+		{
+			Number:  github.Ptr(10),
+			Title:   github.Ptr("App crashes when opening settings"),
+			Body:    github.Ptr("Opening the settings page crashes the app on macOS."),
+			State:   github.Ptr("open"),
+			Labels:  []*github.Label{{Name: github.Ptr("bug")}},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/10"),
+		},
+		{
+			Number:      github.Ptr(11),
+			Title:       github.Ptr("Crash opening settings page"),
+			Body:        github.Ptr("Stack trace attached."),
+			State:       github.Ptr("closed"),
+			StateReason: github.Ptr("completed"),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/11"),
+		},
+		{
+			Number:  github.Ptr(12),
+			Title:   github.Ptr("Add dark mode"),
+			Body:    github.Ptr("Support a dark theme for the settings."),
+			State:   github.Ptr("open"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/12"),
+		},
+		{
+			Number:           github.Ptr(13),
+			Title:            github.Ptr("Fix crash opening settings page"),
+			State:            github.Ptr("open"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/13")},
+		},
+	}
+
+	// searchHandler answers every derived query with the candidates and records the queries.
+	newSearchHandler := func(queries *[]string, mu *sync.Mutex) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			*queries = append(*queries, r.URL.Query().Get("q"))
+			mu.Unlock()
+			mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(len(candidates)), Issues: candidates})(w, r)
+		}
+	}
+
+	t.Run("ranks issues similar to a title", func(t *testing.T) {
+		var queries []string
+		var mu sync.Mutex
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetSearchIssues: newSearchHandler(&queries, &mu),
+		}))
+		deps := BaseDeps{Client: client}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"title": "Crash when opening the settings page",
+			"state": "open",
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response SimilarIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, []string{"crash opening settings page in:title", "crash opening settings", "opening settings page"}, response.Queries)
+		assert.Len(t, queries, 3)
+		for _, query := range queries {
+			assert.True(t, strings.HasPrefix(query, "repo:owner/repo is:issue state:open "), query)
+		}
+
+		assert.Equal(t, 3, response.Candidates)
+		// The dark mode issue only shares "settings" and falls below the minimum score.
+		require.Len(t, response.Issues, 2)
+		assert.Equal(t, 11, response.Issues[0].Number)
+		assert.Equal(t, 1.0, response.Issues[0].Score)
+		assert.Equal(t, "completed", response.Issues[0].StateReason)
+		assert.Equal(t, []string{"crash", "opening", "settings", "page"}, response.Issues[0].MatchedTerms)
+		assert.Equal(t, 10, response.Issues[1].Number)
+		assert.Equal(t, []string{"bug"}, response.Issues[1].Labels)
+	})
+
+	t.Run("finds issues similar to an existing issue", func(t *testing.T) {
+		var queries []string
+		var mu sync.Mutex
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, candidates[0]),
+			GetSearchIssues:                          newSearchHandler(&queries, &mu),
+		}))
+		deps := BaseDeps{Client: client}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(10),
+			"limit":        float64(1),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response SimilarIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Contains(t, response.Queries, `app crashes label:"bug"`)
+		require.Len(t, response.Issues, 1)
+		assert.Equal(t, 11, response.Issues[0].Number)
+	})
+
+	t.Run("fails when every search fails", func(t *testing.T) {
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetSearchIssues: mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+		}))
+		deps := BaseDeps{Client: client}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"title": "Crash on startup",
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Contains(t, textContent.Text, "failed to search issues")
+	})
+
+	t.Run("requires title or issue_number", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "either title or issue_number is required")
+	})
+}
+
+func Test_CreateIssueCheckSimilar(t *testing.T) {
+	serverTool := IssueWrite(translations.NullTranslationHelper)
+	assert.Contains(t, serverTool.Tool.InputSchema.(*jsonschema.Schema).Properties, "check_similar")
+
+	existing := &github.Issue{
+		Number:  github.Ptr(10),
+		Title:   github.Ptr("Crash when opening settings"),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/10"),
+	}
+
+	t.Run("returns very similar open issues instead of creating", func(t *testing.T) {
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetSearchIssues: mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(1), Issues: []*github.Issue{existing}}),
+			PostReposIssuesByOwnerByRepo: func(w http.ResponseWriter, _ *http.Request) {
+				t.Error("issue should not be created")
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		}))
+		deps := BaseDeps{Client: client, GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient())}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":        "create",
+			"owner":         "owner",
+			"repo":          "repo",
+			"title":         "Crash opening settings",
+			"check_similar": true,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var warning SimilarIssuesWarning
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &warning))
+		assert.False(t, warning.Created)
+		require.Len(t, warning.SimilarIssues, 1)
+		assert.Equal(t, 10, warning.SimilarIssues[0].Number)
+	})
+
+	t.Run("creates the issue when no similar open issue exists", func(t *testing.T) {
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetSearchIssues: mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(1), Issues: []*github.Issue{existing}}),
+			PostReposIssuesByOwnerByRepo: mockResponse(t, http.StatusCreated, &github.Issue{
+				ID:      github.Ptr(int64(99)),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/issues/11"),
+			}),
+		}))
+		deps := BaseDeps{Client: client, GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient())}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":        "create",
+			"owner":         "owner",
+			"repo":          "repo",
+			"title":         "Add dark mode to settings",
+			"check_similar": true,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response MinimalResponse
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, "https://github.com/owner/repo/issues/11", response.URL)
+	})
+}
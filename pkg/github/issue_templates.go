@@ -0,0 +1,645 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+// issueTemplateDir is the directory GitHub reads issue templates and issue forms from.
+const issueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// issueFormNoResponse is what GitHub renders for an issue form field left empty.
+const issueFormNoResponse = "_No response_"
+
+// Kinds of issue templates.
+const (
+	issueTemplateKindMarkdown = "markdown"
+	issueTemplateKindForm     = "form"
+)
+
+// IssueTemplate is an issue template or issue form of a repository.
+type IssueTemplate struct {
+	Name        string               `json:"name"`
+	Filename    string               `json:"filename"`
+	Kind        string               `json:"kind"`
+	Description string               `json:"description,omitempty"`
+	Title       string               `json:"title,omitempty"`
+	Labels      []string             `json:"labels,omitempty"`
+	Assignees   []string             `json:"assignees,omitempty"`
+	Type        string               `json:"type,omitempty"`
+	Body        string               `json:"body,omitempty"`
+	Fields      []IssueTemplateField `json:"fields,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+// IssueTemplateField is an element of an issue form. Markdown elements only hold instructions
+// and are not rendered into the issue body.
+type IssueTemplateField struct {
+	ID          string                `json:"id,omitempty"`
+	Type        string                `json:"type"`
+	Label       string                `json:"label,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Placeholder string                `json:"placeholder,omitempty"`
+	Value       string                `json:"value,omitempty"`
+	Render      string                `json:"render,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Multiple    bool                  `json:"multiple,omitempty"`
+	Options     []IssueTemplateOption `json:"options,omitempty"`
+	Default     string                `json:"default,omitempty"`
+}
+
+// IssueTemplateOption is an option of a dropdown or checkboxes element of an issue form.
+type IssueTemplateOption struct {
+	Label    string `json:"label"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// IssueTemplateConfig is the template chooser configuration of a repository.
+type IssueTemplateConfig struct {
+	BlankIssuesEnabled *bool                      `json:"blank_issues_enabled,omitempty" yaml:"blank_issues_enabled"`
+	ContactLinks       []IssueTemplateContactLink `json:"contact_links,omitempty" yaml:"contact_links"`
+}
+
+// IssueTemplateContactLink is an external link shown in the template chooser.
+type IssueTemplateContactLink struct {
+	Name  string `json:"name" yaml:"name"`
+	URL   string `json:"url" yaml:"url"`
+	About string `json:"about,omitempty" yaml:"about"`
+}
+
+// IssueTemplates is the issue templates of a repository and their configuration.
+type IssueTemplates struct {
+	Templates []IssueTemplate      `json:"templates"`
+	Config    *IssueTemplateConfig `json:"config,omitempty"`
+}
+
+// ListIssueTemplates creates a tool to list the issue templates and issue forms of a repository.
+func ListIssueTemplates(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "list_issue_templates",
+			Description: t("TOOL_LIST_ISSUE_TEMPLATES_DESCRIPTION", "List the issue templates and issue forms of a repository with their default title, labels, assignees and type, the fields of each form, and the template chooser configuration. Use the template name with issue_write to create an issue from a template."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ISSUE_TEMPLATES_USER_TITLE", "List issue templates"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+			rawClient, err := deps.GetRawClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub raw content client", err), nil, nil
+			}
+
+			templates, resp, err := getIssueTemplates(ctx, client, rawClient, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue templates", resp, err), nil, nil
+			}
+
+			result, err := utils.NewToolResultJSON(templates)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// getIssueTemplates reads the issue templates of a repository. Templates that cannot be read or
+// parsed are returned with an error instead of failing the whole listing.
+func getIssueTemplates(ctx context.Context, client *github.Client, rawClient *raw.Client, owner, repo string) (IssueTemplates, *github.Response, error) {
+	templates := IssueTemplates{Templates: []IssueTemplate{}}
+
+	_, files, resp, err := client.Repositories.GetContents(ctx, owner, repo, issueTemplateDir, nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return templates, resp, nil
+		}
+		return templates, resp, err
+	}
+	_ = resp.Body.Close()
+
+	for _, file := range files {
+		name := file.GetName()
+		ext := strings.ToLower(path.Ext(name))
+		if file.GetType() != "file" || (ext != ".md" && ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		content, err := getRawFile(ctx, rawClient, owner, repo, file.GetPath())
+		if strings.TrimSuffix(strings.ToLower(name), ext) == "config" && ext != ".md" {
+			if err == nil {
+				var config IssueTemplateConfig
+				if yaml.Unmarshal([]byte(content), &config) == nil {
+					templates.Config = &config
+				}
+			}
+			continue
+		}
+		if err != nil {
+			templates.Templates = append(templates.Templates, IssueTemplate{Name: name, Filename: name, Error: err.Error()})
+			continue
+		}
+
+		template, err := parseIssueTemplate(name, content)
+		if err != nil {
+			template.Error = err.Error()
+		}
+		templates.Templates = append(templates.Templates, template)
+	}
+	return templates, resp, nil
+}
+
+// getRawFile reads a file of the default branch of a repository.
+func getRawFile(ctx context.Context, rawClient *raw.Client, owner, repo, filePath string) (string, error) {
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, filePath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read %s: unexpected status %d", filePath, resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return string(content), nil
+}
+
+// issueTemplateStringList is a list of strings written either as a YAML list or as a
+// comma-separated string, as GitHub accepts both for labels and assignees.
+type issueTemplateStringList []string
+
+func (l *issueTemplateStringList) UnmarshalYAML(value *yaml.Node) error {
+	var items []string
+	switch value.Kind {
+	case yaml.ScalarNode:
+		items = strings.Split(value.Value, ",")
+	case yaml.SequenceNode:
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("line %d: expected a string or a list of strings", value.Line)
+	}
+
+	*l = nil
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// issueFormOption is a dropdown option, written as a string, or a checkbox, written as a
+// mapping with a label.
+type issueFormOption IssueTemplateOption
+
+func (o *issueFormOption) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		o.Label = value.Value
+		return nil
+	}
+	var option struct {
+		Label    string `yaml:"label"`
+		Required bool   `yaml:"required"`
+	}
+	if err := value.Decode(&option); err != nil {
+		return err
+	}
+	o.Label = option.Label
+	o.Required = option.Required
+	return nil
+}
+
+// issueTemplateHeader is the metadata shared by the front matter of markdown templates and the
+// top level of issue forms.
+type issueTemplateHeader struct {
+	Name        string                  `yaml:"name"`
+	About       string                  `yaml:"about"`
+	Description string                  `yaml:"description"`
+	Title       string                  `yaml:"title"`
+	Labels      issueTemplateStringList `yaml:"labels"`
+	Assignees   issueTemplateStringList `yaml:"assignees"`
+	Type        string                  `yaml:"type"`
+}
+
+type issueFormFile struct {
+	issueTemplateHeader `yaml:",inline"`
+	Body                []issueFormElement `yaml:"body"`
+}
+
+type issueFormElement struct {
+	Type       string `yaml:"type"`
+	ID         string `yaml:"id"`
+	Attributes struct {
+		Label       string            `yaml:"label"`
+		Description string            `yaml:"description"`
+		Placeholder string            `yaml:"placeholder"`
+		Value       string            `yaml:"value"`
+		Render      string            `yaml:"render"`
+		Multiple    bool              `yaml:"multiple"`
+		Options     []issueFormOption `yaml:"options"`
+		Default     *int              `yaml:"default"`
+	} `yaml:"attributes"`
+	Validations struct {
+		Required bool `yaml:"required"`
+	} `yaml:"validations"`
+}
+
+// parseIssueTemplate parses a markdown issue template or a YAML issue form.
+func parseIssueTemplate(filename, content string) (IssueTemplate, error) {
+	template := IssueTemplate{Name: filename, Filename: filename}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if strings.ToLower(path.Ext(filename)) == ".md" {
+		template.Kind = issueTemplateKindMarkdown
+		frontMatter, body, ok := splitFrontMatter(content)
+		template.Body = body
+		if !ok {
+			return template, fmt.Errorf("missing front matter")
+		}
+		var header issueTemplateHeader
+		if err := yaml.Unmarshal([]byte(frontMatter), &header); err != nil {
+			return template, fmt.Errorf("invalid front matter: %w", err)
+		}
+		template.applyHeader(header, header.About)
+		return template, nil
+	}
+
+	template.Kind = issueTemplateKindForm
+	var form issueFormFile
+	if err := yaml.Unmarshal([]byte(content), &form); err != nil {
+		return template, fmt.Errorf("invalid issue form: %w", err)
+	}
+	template.applyHeader(form.issueTemplateHeader, form.Description)
+
+	for i, element := range form.Body {
+		field := IssueTemplateField{
+			ID:          element.ID,
+			Type:        element.Type,
+			Label:       element.Attributes.Label,
+			Description: element.Attributes.Description,
+			Placeholder: element.Attributes.Placeholder,
+			Value:       element.Attributes.Value,
+			Render:      element.Attributes.Render,
+			Required:    element.Validations.Required,
+			Multiple:    element.Attributes.Multiple,
+		}
+		for _, option := range element.Attributes.Options {
+			field.Options = append(field.Options, IssueTemplateOption(option))
+		}
+		if field.Type != "markdown" && field.Label == "" {
+			return template, fmt.Errorf("body[%d]: label is required", i)
+		}
+		switch field.Type {
+		case "markdown", "input", "textarea":
+		case "dropdown", "checkboxes":
+			if len(field.Options) == 0 {
+				return template, fmt.Errorf("body[%d]: options are required", i)
+			}
+			if def := element.Attributes.Default; def != nil {
+				if *def < 0 || *def >= len(field.Options) {
+					return template, fmt.Errorf("body[%d]: default is not a valid option index", i)
+				}
+				field.Default = field.Options[*def].Label
+			}
+		default:
+			return template, fmt.Errorf("body[%d]: unknown element type %q", i, field.Type)
+		}
+		template.Fields = append(template.Fields, field)
+	}
+	return template, nil
+}
+
+func (t *IssueTemplate) applyHeader(header issueTemplateHeader, description string) {
+	if header.Name != "" {
+		t.Name = header.Name
+	}
+	t.Description = description
+	t.Title = header.Title
+	t.Labels = header.Labels
+	t.Assignees = header.Assignees
+	t.Type = header.Type
+}
+
+// splitFrontMatter splits a markdown template into its YAML front matter and its body.
+func splitFrontMatter(content string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return "", content, false
+	}
+	if frontMatter, body, ok := strings.Cut(rest, "\n---\n"); ok {
+		return frontMatter, strings.TrimPrefix(body, "\n"), true
+	}
+	if frontMatter, ok := strings.CutSuffix(rest, "\n---"); ok {
+		return frontMatter, "", true
+	}
+	return "", content, false
+}
+
+// findIssueTemplate finds a template by name or by filename, with or without its extension.
+func findIssueTemplate(templates []IssueTemplate, name string) *IssueTemplate {
+	for i, template := range templates {
+		filename := template.Filename
+		if strings.EqualFold(template.Name, name) ||
+			strings.EqualFold(filename, name) ||
+			strings.EqualFold(strings.TrimSuffix(filename, path.Ext(filename)), name) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+// issueFromTemplate is the title, body and metadata of an issue created from a template.
+type issueFromTemplate struct {
+	title     string
+	body      string
+	labels    []string
+	assignees []string
+	issueType string
+}
+
+// apply combines a template with the values provided for a new issue. The title is prefixed with
+// the title of the template, the labels and assignees of the template are added to the provided
+// ones, and the type of the template is used unless a type is provided.
+func (t *IssueTemplate) apply(title, body string, fieldValues map[string]any, labels, assignees []string, issueType string) (issueFromTemplate, error) {
+	if t.Error != "" {
+		return issueFromTemplate{}, fmt.Errorf("issue template %q cannot be used: %s", t.Name, t.Error)
+	}
+
+	issue := issueFromTemplate{
+		title:     title,
+		labels:    mergeStrings(t.Labels, labels),
+		assignees: mergeStrings(t.Assignees, assignees),
+		issueType: issueType,
+	}
+	if issue.issueType == "" {
+		issue.issueType = t.Type
+	}
+	if t.Title != "" && !strings.HasPrefix(title, t.Title) {
+		issue.title = t.Title + title
+	}
+	if strings.TrimSpace(issue.title) == "" {
+		return issueFromTemplate{}, fmt.Errorf("title is required")
+	}
+
+	if t.Kind == issueTemplateKindMarkdown {
+		if len(fieldValues) > 0 {
+			return issueFromTemplate{}, fmt.Errorf("template_fields can only be used with issue forms, %q is a markdown template", t.Name)
+		}
+		issue.body = body
+		if issue.body == "" {
+			issue.body = t.Body
+		}
+		return issue, nil
+	}
+
+	if body != "" {
+		return issueFromTemplate{}, fmt.Errorf("body cannot be used with issue form %q, provide template_fields instead", t.Name)
+	}
+	renderedBody, err := renderIssueForm(t.Fields, fieldValues)
+	if err != nil {
+		return issueFromTemplate{}, err
+	}
+	issue.body = renderedBody
+	return issue, nil
+}
+
+// renderIssueForm validates the values of the fields of an issue form and renders them into an
+// issue body the way GitHub renders a submitted form: a heading per field followed by its value.
+// Fields are matched by id, or by label for fields without an id.
+func renderIssueForm(fields []IssueTemplateField, values map[string]any) (string, error) {
+	used := make(map[string]bool, len(values))
+	var keys []string
+	var sections []string
+	for _, field := range fields {
+		if field.Type == "markdown" {
+			continue
+		}
+		key := field.ID
+		if key == "" {
+			key = field.Label
+		}
+		keys = append(keys, key)
+
+		value, ok := values[key]
+		if ok {
+			used[key] = true
+		} else if value, ok = values[field.Label]; ok {
+			used[field.Label] = true
+		}
+
+		rendered, err := renderIssueFormField(field, value, ok)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", key, err)
+		}
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", field.Label, rendered))
+	}
+
+	for key := range values {
+		if !used[key] {
+			return "", fmt.Errorf("unknown field %q. Fields of the form are: %s", key, strings.Join(keys, ", "))
+		}
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+func renderIssueFormField(field IssueTemplateField, value any, provided bool) (string, error) {
+	switch field.Type {
+	case "input", "textarea":
+		text := field.Value
+		if provided {
+			s, ok := value.(string)
+			if !ok {
+				return "", fmt.Errorf("expected a string")
+			}
+			text = s
+		}
+		if strings.TrimSpace(text) == "" {
+			if field.Required {
+				return "", fmt.Errorf("a value is required")
+			}
+			return issueFormNoResponse, nil
+		}
+		if field.Render != "" {
+			return fmt.Sprintf("```%s\n%s\n```", field.Render, text), nil
+		}
+		return text, nil
+	case "dropdown":
+		var selected []string
+		if provided {
+			var err error
+			selected, err = issueFormSelection(value)
+			if err != nil {
+				return "", err
+			}
+		} else if field.Default != "" {
+			selected = []string{field.Default}
+		}
+		if len(selected) > 1 && !field.Multiple {
+			return "", fmt.Errorf("only one option can be selected")
+		}
+		for _, option := range selected {
+			if !hasIssueTemplateOption(field.Options, option) {
+				return "", fmt.Errorf("%q is not an option. Options are: %s", option, joinIssueTemplateOptions(field.Options))
+			}
+		}
+		if len(selected) == 0 {
+			if field.Required {
+				return "", fmt.Errorf("an option must be selected")
+			}
+			return issueFormNoResponse, nil
+		}
+		return strings.Join(selected, ", "), nil
+	case "checkboxes":
+		var checked []string
+		if provided {
+			var err error
+			checked, err = issueFormSelection(value)
+			if err != nil {
+				return "", err
+			}
+		}
+		for _, option := range checked {
+			if !hasIssueTemplateOption(field.Options, option) {
+				return "", fmt.Errorf("%q is not an option. Options are: %s", option, joinIssueTemplateOptions(field.Options))
+			}
+		}
+		lines := make([]string, 0, len(field.Options))
+		for _, option := range field.Options {
+			mark := " "
+			if slices.Contains(checked, option.Label) {
+				mark = "X"
+			} else if option.Required {
+				return "", fmt.Errorf("%q must be checked", option.Label)
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s", mark, option.Label))
+		}
+		return strings.Join(lines, "\n"), nil
+	default:
+		return "", fmt.Errorf("unknown field type %q", field.Type)
+	}
+}
+
+// issueFormSelection returns the options selected in a dropdown or checkboxes field, given as a
+// single string or a list of strings.
+func issueFormSelection(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []any:
+		selected := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings")
+			}
+			selected = append(selected, s)
+		}
+		return selected, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list of strings")
+	}
+}
+
+func hasIssueTemplateOption(options []IssueTemplateOption, label string) bool {
+	for _, option := range options {
+		if option.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+func joinIssueTemplateOptions(options []IssueTemplateOption) string {
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		labels = append(labels, option.Label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// mergeStrings returns the strings of a followed by the strings of b that are not in a.
+func mergeStrings(a, b []string) []string {
+	merged := make([]string, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	for _, s := range b {
+		if !slices.Contains(merged, s) {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// createIssueFromTemplate creates an issue from an issue template or issue form of the repository.
+func createIssueFromTemplate(ctx context.Context, client *github.Client, rawClient *raw.Client, owner, repo, templateName string, fieldValues map[string]any, title, body string, assignees, labels []string, milestoneNum int, issueType string) (*mcp.CallToolResult, error) {
+	templates, resp, err := getIssueTemplates(ctx, client, rawClient, owner, repo)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue templates", resp, err), nil
+	}
+
+	template := findIssueTemplate(templates.Templates, templateName)
+	if template == nil {
+		names := make([]string, 0, len(templates.Templates))
+		for _, template := range templates.Templates {
+			names = append(names, template.Name)
+		}
+		if len(names) == 0 {
+			return utils.NewToolResultError(fmt.Sprintf("issue template %q not found: %s/%s has no issue templates", templateName, owner, repo)), nil
+		}
+		return utils.NewToolResultError(fmt.Sprintf("issue template %q not found. Available templates are: %s", templateName, strings.Join(names, ", "))), nil
+	}
+
+	issue, err := template.apply(title, body, fieldValues, labels, assignees, issueType)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil
+	}
+	return CreateIssue(ctx, client, owner, repo, issue.title, issue.body, issue.assignees, issue.labels, milestoneNum, issue.issueType)
+}
@@ -0,0 +1,452 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bugReportForm = `name: Bug Report
+description: File a bug report
+title: "[Bug]: "
+labels: ["bug", "triage"]
+assignees:
+  - octocat
+type: Bug
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this bug report!
+  - type: input
+    id: version
+    attributes:
+      label: Version
+      placeholder: v1.0.0
+    validations:
+      required: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Relevant log output
+      render: shell
+  - type: dropdown
+    id: browsers
+    attributes:
+      label: Browsers
+      multiple: true
+      options:
+        - Firefox
+        - Chrome
+        - Safari
+  - type: dropdown
+    id: severity
+    attributes:
+      label: Severity
+      options:
+        - Low
+        - High
+      default: 0
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow the Code of Conduct
+          required: true
+        - label: I searched for existing issues
+`
+
+const featureRequestTemplate = `---
+name: Feature request
+about: Suggest an idea
+title: "[Feature] "
+labels: enhancement, needs-triage
+assignees: ''
+---
+
+## Problem
+
+Describe the problem.
+`
+
+const issueTemplateConfig = `blank_issues_enabled: false
+contact_links:
+  - name: Community Support
+    url: https://github.com/orgs/community/discussions
+    about: Please ask and answer questions here.
+`
+
+// mockIssueTemplatesClient returns a client serving the issue templates directory and the raw
+// content of its files.
+func mockIssueTemplatesClient(t *testing.T, extraHandlers map[string]http.HandlerFunc) *http.Client {
+	files := map[string]string{
+		"bug_report.yml":  bugReportForm,
+		"feature.md":      featureRequestTemplate,
+		"config.yml":      issueTemplateConfig,
+		"broken.yaml":     "name: [unclosed",
+		"notes-image.png": "",
+	}
+	handlers := map[string]http.HandlerFunc{
+		"GET /repos/owner/repo/contents/.github/ISSUE_TEMPLATE": mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("bug_report.yml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/bug_report.yml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("config.yml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/config.yml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("feature.md"), Path: github.Ptr(".github/ISSUE_TEMPLATE/feature.md")},
+			{Type: github.Ptr("file"), Name: github.Ptr("broken.yaml"), Path: github.Ptr(".github/ISSUE_TEMPLATE/broken.yaml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("notes-image.png"), Path: github.Ptr(".github/ISSUE_TEMPLATE/notes-image.png")},
+		}),
+		GetRawReposContentsByOwnerByRepoByPath: func(w http.ResponseWriter, r *http.Request) {
+			name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			assert.NotEqual(t, "notes-image.png", name)
+			content, ok := files[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(content))
+		},
+	}
+	for pattern, handler := range extraHandlers {
+		handlers[pattern] = handler
+	}
+	return MockHTTPClientWithHandlers(handlers)
+}
+
+func Test_ListIssueTemplates(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListIssueTemplates(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_issue_templates", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.(*jsonschema.Schema).Required, []string{"owner", "repo"})
+
+	t.Run("lists forms, markdown templates and the chooser config", func(t *testing.T) {
+		client := github.NewClient(mockIssueTemplatesClient(t, nil))
+		deps := BaseDeps{
+			Client:    client,
+			RawClient: raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}),
+		}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var templates IssueTemplates
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &templates))
+		require.Len(t, templates.Templates, 3)
+
+		form := templates.Templates[0]
+		assert.Equal(t, "Bug Report", form.Name)
+		assert.Equal(t, "bug_report.yml", form.Filename)
+		assert.Equal(t, issueTemplateKindForm, form.Kind)
+		assert.Equal(t, "File a bug report", form.Description)
+		assert.Equal(t, "[Bug]: ", form.Title)
+		assert.Equal(t, []string{"bug", "triage"}, form.Labels)
+		assert.Equal(t, []string{"octocat"}, form.Assignees)
+		assert.Equal(t, "Bug", form.Type)
+		require.Len(t, form.Fields, 6)
+		assert.Equal(t, "markdown", form.Fields[0].Type)
+		assert.Equal(t, IssueTemplateField{ID: "version", Type: "input", Label: "Version", Placeholder: "v1.0.0", Required: true}, form.Fields[1])
+		assert.Equal(t, "Low", form.Fields[4].Default)
+		assert.Equal(t, []IssueTemplateOption{
+			{Label: "I agree to follow the Code of Conduct", Required: true},
+			{Label: "I searched for existing issues"},
+		}, form.Fields[5].Options)
+
+		markdown := templates.Templates[1]
+		assert.Equal(t, "Feature request", markdown.Name)
+		assert.Equal(t, issueTemplateKindMarkdown, markdown.Kind)
+		assert.Equal(t, "Suggest an idea", markdown.Description)
+		assert.Equal(t, []string{"enhancement", "needs-triage"}, markdown.Labels)
+		assert.Empty(t, markdown.Assignees)
+		assert.Equal(t, "## Problem\n\nDescribe the problem.\n", markdown.Body)
+
+		broken := templates.Templates[2]
+		assert.Equal(t, "broken.yaml", broken.Name)
+		assert.Contains(t, broken.Error, "invalid issue form")
+
+		require.NotNil(t, templates.Config)
+		require.NotNil(t, templates.Config.BlankIssuesEnabled)
+		assert.False(t, *templates.Config.BlankIssuesEnabled)
+		require.Len(t, templates.Config.ContactLinks, 1)
+		assert.Equal(t, "Community Support", templates.Config.ContactLinks[0].Name)
+	})
+
+	t.Run("repository without templates", func(t *testing.T) {
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			"GET /repos/owner/repo/contents/.github/ISSUE_TEMPLATE": mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+		}))
+		deps := BaseDeps{
+			Client:    client,
+			RawClient: raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}),
+		}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.JSONEq(t, `{"templates": []}`, textContent.Text)
+	})
+}
+
+func Test_CreateIssueFromTemplate(t *testing.T) {
+	serverTool := IssueWrite(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "template")
+	assert.Contains(t, schema.Properties, "template_fields")
+
+	createdIssue := &github.Issue{
+		ID:      github.Ptr(int64(1)),
+		Number:  github.Ptr(42),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "renders an issue form and applies its defaults",
+			handlers: map[string]http.HandlerFunc{
+				PostReposIssuesByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"title": "[Bug]: Crash on startup",
+					"body": "### Version\n\nv2.1.0\n\n" +
+						"### Relevant log output\n\n```shell\npanic: boom\n```\n\n" +
+						"### Browsers\n\nFirefox, Safari\n\n" +
+						"### Severity\n\nLow\n\n" +
+						"### Code of Conduct\n\n- [X] I agree to follow the Code of Conduct\n- [ ] I searched for existing issues",
+					"labels":    []any{"bug", "triage", "p1"},
+					"assignees": []any{"octocat"},
+					"type":      "Bug",
+				}).andThen(mockResponse(t, http.StatusCreated, createdIssue)),
+			},
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on startup",
+				"labels":   []any{"p1", "bug"},
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"version":  "v2.1.0",
+					"logs":     "panic: boom",
+					"browsers": []any{"Firefox", "Safari"},
+					"Code of Conduct": []any{
+						"I agree to follow the Code of Conduct",
+					},
+				},
+			},
+		},
+		{
+			name: "renders empty optional fields like the web form",
+			handlers: map[string]http.HandlerFunc{
+				PostReposIssuesByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"title": "[Bug]: Crash",
+					"body": "### Version\n\n1.0\n\n" +
+						"### Relevant log output\n\n_No response_\n\n" +
+						"### Browsers\n\n_No response_\n\n" +
+						"### Severity\n\nHigh\n\n" +
+						"### Code of Conduct\n\n- [X] I agree to follow the Code of Conduct\n- [X] I searched for existing issues",
+					"labels":    []any{"bug", "triage"},
+					"assignees": []any{"octocat"},
+					"type":      "Task",
+				}).andThen(mockResponse(t, http.StatusCreated, createdIssue)),
+			},
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "[Bug]: Crash",
+				"type":     "Task",
+				"template": "Bug Report",
+				"template_fields": map[string]any{
+					"version":  "1.0",
+					"severity": "High",
+					"terms":    []any{"I agree to follow the Code of Conduct", "I searched for existing issues"},
+				},
+			},
+		},
+		{
+			name: "uses the body of a markdown template",
+			handlers: map[string]http.HandlerFunc{
+				PostReposIssuesByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"title":     "[Feature] Dark mode",
+					"body":      "## Problem\n\nDescribe the problem.\n",
+					"labels":    []any{"enhancement", "needs-triage"},
+					"assignees": []any{},
+				}).andThen(mockResponse(t, http.StatusCreated, createdIssue)),
+			},
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Dark mode",
+				"template": "feature.md",
+			},
+		},
+		{
+			name: "rejects a missing required field",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"terms": []any{"I agree to follow the Code of Conduct"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: `field "version": a value is required`,
+		},
+		{
+			name: "rejects an unchecked required checkbox",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"version": "1.0",
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: `field "terms": "I agree to follow the Code of Conduct" must be checked`,
+		},
+		{
+			name: "rejects an unknown dropdown option",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"version":  "1.0",
+					"severity": "Critical",
+					"terms":    []any{"I agree to follow the Code of Conduct"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: `field "severity": "Critical" is not an option. Options are: Low, High`,
+		},
+		{
+			name: "rejects unknown fields",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"version": "1.0",
+					"terms":   []any{"I agree to follow the Code of Conduct"},
+					"os":      "linux",
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown field "os". Fields of the form are: version, logs, browsers, severity, terms`,
+		},
+		{
+			name: "rejects body with an issue form",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"body":     "It crashes",
+				"template": "bug_report",
+			},
+			expectError:    true,
+			expectedErrMsg: `body cannot be used with issue form "Bug Report", provide template_fields instead`,
+		},
+		{
+			name: "unknown template",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "question",
+			},
+			expectError:    true,
+			expectedErrMsg: `issue template "question" not found. Available templates are: Bug Report, Feature request, broken.yaml`,
+		},
+		{
+			name: "broken template",
+			requestArgs: map[string]any{
+				"method":   "create",
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash",
+				"template": "broken",
+			},
+			expectError:    true,
+			expectedErrMsg: `issue template "broken.yaml" cannot be used: invalid issue form`,
+		},
+		{
+			name: "template_fields require template",
+			requestArgs: map[string]any{
+				"method":          "create",
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Crash",
+				"template_fields": map[string]any{"version": "1.0"},
+			},
+			expectError:    true,
+			expectedErrMsg: "template_fields can only be used with template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockIssueTemplatesClient(t, tc.handlers))
+			deps := BaseDeps{
+				Client:    client,
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient()),
+				RawClient: raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var response MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "https://github.com/owner/repo/issues/42", response.URL)
+		})
+	}
+}
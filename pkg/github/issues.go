@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	ghcontext "github.com/github/github-mcp-server/pkg/context"
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/octicons"
 	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/github/github-mcp-server/pkg/scopes"
@@ -243,8 +245,12 @@ Options are:
 2. get_comments - Get issue comments.
 3. get_sub_issues - Get sub-issues of the issue.
 4. get_labels - Get labels assigned to the issue.
+5. get_timeline - Get the timeline of the issue, oldest first: comments, label, assignee and milestone changes, cross-references from other issues and pull requests, renames, state changes (closed, reopened, transferred) and the pull request or commit that closed it. Use with pagination parameters to control the number of results returned.
+6. get_dependencies - Get the issues blocking the issue (blocked_by) and the issues it blocks (blocking), to order work.
+7. get_sub_issue_tree - Get the nested sub-issue hierarchy below the issue, down to 'depth' levels and at most 'max_nodes' issues, with the state, assignees and type of each issue and completion rollups per issue and per level.
+8. get_parent_chain - Get the parent of the issue, its parent, and so on up to the root of the hierarchy.
 `,
-				Enum: []any{"get", "get_comments", "get_sub_issues", "get_labels"},
+				Enum: []any{"get", "get_comments", "get_sub_issues", "get_labels", "get_timeline", "get_dependencies", "get_sub_issue_tree", "get_parent_chain"},
 			},
 			"owner": {
 				Type:        "string",
@@ -258,6 +264,18 @@ Options are:
 				Type:        "number",
 				Description: "The number of the issue",
 			},
+			"depth": {
+				Type:        "number",
+				Description: fmt.Sprintf("Number of sub-issue levels to load for get_sub_issue_tree (default %d)", defaultSubIssueTreeDepth),
+				Minimum:     jsonschema.Ptr(1.0),
+				Maximum:     jsonschema.Ptr(float64(maxSubIssueTreeDepth)),
+			},
+			"max_nodes": {
+				Type:        "number",
+				Description: fmt.Sprintf("Maximum number of issues to load for get_sub_issue_tree (default %d)", defaultSubIssueTreeNodes),
+				Minimum:     jsonschema.Ptr(1.0),
+				Maximum:     jsonschema.Ptr(float64(maxSubIssueTreeNodes)),
+			},
 		},
 		Required: []string{"method", "owner", "repo", "issue_number"},
 	}
@@ -318,6 +336,32 @@ Options are:
 			case "get_labels":
 				result, err := GetIssueLabels(ctx, gqlClient, owner, repo, issueNumber)
 				return result, nil, err
+			case "get_timeline":
+				result, err := GetIssueTimeline(ctx, client, deps, owner, repo, issueNumber, pagination)
+				return result, nil, err
+			case "get_dependencies":
+				result, err := GetIssueDependencies(ctx, client, deps, owner, repo, issueNumber)
+				return result, nil, err
+			case "get_sub_issue_tree":
+				depth, err := OptionalIntParamWithDefault(args, "depth", defaultSubIssueTreeDepth)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if depth < 1 || depth > maxSubIssueTreeDepth {
+					return utils.NewToolResultError(fmt.Sprintf("depth must be between 1 and %d", maxSubIssueTreeDepth)), nil, nil
+				}
+				maxNodes, err := OptionalIntParamWithDefault(args, "max_nodes", defaultSubIssueTreeNodes)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if maxNodes < 1 || maxNodes > maxSubIssueTreeNodes {
+					return utils.NewToolResultError(fmt.Sprintf("max_nodes must be between 1 and %d", maxSubIssueTreeNodes)), nil, nil
+				}
+				result, err := GetSubIssueTree(ctx, client, deps, owner, repo, issueNumber, depth, maxNodes)
+				return result, nil, err
+			case "get_parent_chain":
+				result, err := GetIssueParentChain(ctx, client, deps, owner, repo, issueNumber)
+				return result, nil, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -538,6 +582,214 @@ func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string,
 
 }
 
+// GetIssueTimeline returns the normalized timeline events of an issue. In lockdown mode, the
+// bodies of comments, reviews and commits, renamed titles and the titles of cross-referencing
+// issues and closing pull requests by users without push access are removed.
+func GetIssueTimeline(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	flags := deps.GetFlags(ctx)
+
+	opts := &github.ListOptions{
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+	}
+
+	events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, opts)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get issue timeline",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue timeline", resp, body), nil
+	}
+
+	if flags.LockdownMode && cache == nil {
+		return nil, fmt.Errorf("lockdown cache is not configured")
+	}
+
+	// The REST API only tells which commit closed an issue, so pull requests are resolved with GraphQL.
+	var closers map[string]issueCloser
+	if slices.ContainsFunc(events, func(event *github.Timeline) bool { return event.GetEvent() == "closed" }) {
+		gqlClient, err := deps.GetGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+		}
+		closers, err = getIssueClosers(ctx, gqlClient, owner, repo, issueNumber)
+		if err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get the pull requests that closed the issue", err), nil
+		}
+	}
+
+	minimalEvents := make([]MinimalIssueTimelineEvent, 0, len(events))
+	for _, event := range events {
+		minimalEvent := convertToMinimalIssueTimelineEvent(event)
+		var closer issueCloser
+		if minimalEvent.Event == "closed" {
+			if c, ok := closers[minimalEvent.CreatedAt]; ok {
+				closer = c
+				minimalEvent.ClosedBy = &closer.PullRequest
+			}
+		}
+		if flags.LockdownMode {
+			if err := lockdownIssueTimelineEvent(ctx, cache, &minimalEvent, event, owner, repo); err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+			}
+			if minimalEvent.ClosedBy != nil {
+				closerOwner, closerRepo, _ := strings.Cut(minimalEvent.ClosedBy.Repository, "/")
+				if closerOwner == "" || closerRepo == "" {
+					closerOwner, closerRepo = owner, repo
+				}
+				if err := lockdownIssueTimelineSource(ctx, cache, minimalEvent.ClosedBy, closer.Author, closerOwner, closerRepo); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+				}
+			}
+		}
+		minimalEvents = append(minimalEvents, minimalEvent)
+	}
+
+	response := map[string]any{
+		"events":   minimalEvents,
+		"has_more": resp.NextPage != 0,
+	}
+
+	return utils.NewToolResultJSON(response)
+}
+
+// lockdownIssueTimelineEvent removes the text of a timeline event that was written by a user
+// without push access, or by an author that cannot be resolved to a user.
+func lockdownIssueTimelineEvent(ctx context.Context, cache *lockdown.RepoAccessCache, minimalEvent *MinimalIssueTimelineEvent, event *github.Timeline, owner, repo string) error {
+	if minimalEvent.Body != "" || minimalEvent.Rename != nil {
+		isSafeContent, err := isSafeLogin(ctx, cache, minimalEvent.Actor, owner, repo)
+		if err != nil {
+			return err
+		}
+		if !isSafeContent {
+			if minimalEvent.Body != "" {
+				minimalEvent.Body = ""
+				minimalEvent.BodyHidden = true
+			}
+			if minimalEvent.Rename != nil {
+				minimalEvent.Rename = &MinimalIssueTimelineRename{Hidden: true}
+			}
+		}
+	}
+
+	if source := minimalEvent.Source; source != nil {
+		issue := event.GetSource().GetIssue()
+		sourceOwner, sourceRepo := repositoryFromAPIURL(issue.GetRepositoryURL())
+		if sourceOwner == "" {
+			sourceOwner, sourceRepo, _ = strings.Cut(issue.GetRepository().GetFullName(), "/")
+		}
+		if sourceOwner == "" || sourceRepo == "" {
+			sourceOwner, sourceRepo = owner, repo
+		}
+		return lockdownIssueTimelineSource(ctx, cache, source, issue.GetUser().GetLogin(), sourceOwner, sourceRepo)
+	}
+	return nil
+}
+
+// lockdownIssueTimelineSource removes the title of a referenced issue or pull request whose author
+// has no push access to the repository it belongs to.
+func lockdownIssueTimelineSource(ctx context.Context, cache *lockdown.RepoAccessCache, source *MinimalIssueTimelineEventSource, author, owner, repo string) error {
+	if source.Title == "" {
+		return nil
+	}
+	isSafeContent, err := isSafeLogin(ctx, cache, author, owner, repo)
+	if err != nil {
+		return err
+	}
+	if !isSafeContent {
+		source.Title = ""
+		source.TitleHidden = true
+	}
+	return nil
+}
+
+// issueCloser is a pull request that closed an issue, and the login of its author.
+type issueCloser struct {
+	PullRequest MinimalIssueTimelineEventSource
+	Author      string
+}
+
+// getIssueClosers returns the pull requests that closed an issue, keyed by the time of the closed
+// event. Closed events of the last 100 closings are considered.
+func getIssueClosers(ctx context.Context, client *githubv4.Client, owner, repo string, issueNumber int) (map[string]issueCloser, error) {
+	var query struct {
+		Repository struct {
+			Issue struct {
+				TimelineItems struct {
+					Nodes []struct {
+						ClosedEvent struct {
+							CreatedAt githubv4.DateTime
+							Closer    struct {
+								PullRequest struct {
+									Number     int
+									Title      string
+									State      string
+									Merged     bool
+									URL        string
+									Author     struct{ Login string }
+									Repository struct{ NameWithOwner string }
+								} `graphql:"... on PullRequest"`
+							}
+						} `graphql:"... on ClosedEvent"`
+					}
+				} `graphql:"timelineItems(last: 100, itemTypes: [CLOSED_EVENT])"`
+			} `graphql:"issue(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]any{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"number": githubv4.Int(issueNumber), //nolint:gosec // Issue numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+
+	closers := make(map[string]issueCloser)
+	for _, node := range query.Repository.Issue.TimelineItems.Nodes {
+		pr := node.ClosedEvent.Closer.PullRequest
+		if pr.Number == 0 {
+			continue
+		}
+		closers[node.ClosedEvent.CreatedAt.UTC().Format("2006-01-02T15:04:05Z")] = issueCloser{
+			PullRequest: MinimalIssueTimelineEventSource{
+				Repository:    pr.Repository.NameWithOwner,
+				Number:        pr.Number,
+				Title:         sanitize.Sanitize(pr.Title),
+				State:         strings.ToLower(pr.State),
+				IsPullRequest: true,
+				Merged:        pr.Merged,
+				URL:           pr.URL,
+			},
+			Author: pr.Author.Login,
+		}
+	}
+	return closers, nil
+}
+
+// isSafeLogin reports whether content by login may be shown in lockdown mode. Content whose
+// author is unknown is never safe.
+func isSafeLogin(ctx context.Context, cache *lockdown.RepoAccessCache, login, owner, repo string) (bool, error) {
+	if login == "" {
+		return false, nil
+	}
+	return cache.IsSafeContent(ctx, login, owner, repo)
+}
+
 // ListIssueTypes creates a tool to list defined issue types for an organization. This can be used to understand supported issue type values for creating or updating issues.
 func ListIssueTypes(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
@@ -1022,6 +1274,18 @@ Options are:
 						Type:        "number",
 						Description: "Issue number that this issue is a duplicate of. Only used when state_reason is 'duplicate'.",
 					},
+					"template": {
+						Type:        "string",
+						Description: "For 'create', the name or filename of an issue template or issue form to create the issue from. Use list_issue_templates to get the templates of the repository. The title is prefixed with the title of the template, and the labels, assignees and type of the template are applied. Markdown templates use 'body' when provided and the template body otherwise.",
+					},
+					"check_similar": {
+						Type:        "boolean",
+						Description: "For 'create', search for very similar open issues first and return them instead of creating the issue if any exist",
+					},
+					"template_fields": {
+						Type:        "object",
+						Description: "For 'create' with an issue form, the values of the form fields keyed by field id, or by label for fields without an id. Use a string for input and textarea fields, and a string or a list of option labels for dropdown and checkboxes fields. Values are validated against the form and rendered into the issue body like the web form does; do not provide 'body'.",
+					},
 				},
 				Required: []string{"method", "owner", "repo"},
 			},
@@ -1096,6 +1360,22 @@ Options are:
 				return utils.NewToolResultError("duplicate_of can only be used when state_reason is 'duplicate'"), nil, nil
 			}
 
+			templateName, err := OptionalParam[string](args, "template")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			templateFields, err := OptionalParam[map[string]any](args, "template_fields")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(templateFields) > 0 && templateName == "" {
+				return utils.NewToolResultError("template_fields can only be used with template"), nil, nil
+			}
+			checkSimilar, err := OptionalParam[bool](args, "check_similar")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := deps.GetClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
@@ -1108,6 +1388,20 @@ Options are:
 
 			switch method {
 			case "create":
+				if checkSimilar {
+					result, err := checkSimilarOpenIssues(ctx, client, owner, repo, title, body, labels)
+					if err != nil || result != nil {
+						return result, nil, err
+					}
+				}
+				if templateName != "" {
+					rawClient, err := deps.GetRawClient(ctx)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to get GitHub raw content client", err), nil, nil
+					}
+					result, err := createIssueFromTemplate(ctx, client, rawClient, owner, repo, templateName, templateFields, title, body, assignees, labels, milestoneNum, issueType)
+					return result, nil, err
+				}
 				result, err := CreateIssue(ctx, client, owner, repo, title, body, assignees, labels, milestoneNum, issueType)
 				return result, nil, err
 			case "update":
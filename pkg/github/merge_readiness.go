@@ -0,0 +1,558 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// mergeReadinessQuery fetches everything about a pull request, its base branch protection and
+// the status checks of its head commit that decides whether it can be merged.
+type mergeReadinessQuery struct {
+	Repository struct {
+		MergeCommitAllowed githubv4.Boolean
+		SquashMergeAllowed githubv4.Boolean
+		RebaseMergeAllowed githubv4.Boolean
+		PullRequest        struct {
+			State               githubv4.String
+			IsDraft             githubv4.Boolean
+			Mergeable           githubv4.String
+			MergeStateStatus    githubv4.String
+			ReviewDecision      *githubv4.String
+			IsInMergeQueue      githubv4.Boolean
+			IsMergeQueueEnabled githubv4.Boolean
+			MergeQueueEntry     *struct {
+				Position githubv4.Int
+				State    githubv4.String
+			}
+			BaseRefName githubv4.String
+			HeadRefOid  githubv4.String
+			BaseRef     *struct {
+				BranchProtectionRule *struct {
+					RequiredApprovingReviewCount   githubv4.Int
+					RequiresApprovingReviews       githubv4.Boolean
+					RequiresCodeOwnerReviews       githubv4.Boolean
+					RequiresStatusChecks           githubv4.Boolean
+					RequiresStrictStatusChecks     githubv4.Boolean
+					RequiredStatusCheckContexts    []githubv4.String
+					RequiresConversationResolution githubv4.Boolean
+				}
+			}
+			LatestOpinionatedReviews struct {
+				Nodes []mergeReadinessReviewNode
+			} `graphql:"latestOpinionatedReviews(first: 100, writersOnly: true)"`
+			ReviewRequests struct {
+				Nodes []mergeReadinessReviewRequestNode
+			} `graphql:"reviewRequests(first: 100)"`
+			ReviewThreads mergeReadinessThreads `graphql:"reviewThreads(first: 100)"`
+			Commits       struct {
+				Nodes []mergeReadinessCommitNode
+			} `graphql:"commits(last: 1)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// mergeReadinessThreads is one page of the review threads of a pull request.
+type mergeReadinessThreads struct {
+	Nodes []struct {
+		IsResolved githubv4.Boolean
+	}
+	PageInfo struct {
+		HasNextPage githubv4.Boolean
+		EndCursor   githubv4.String
+	}
+}
+
+// mergeReadinessThreadsQuery fetches the review threads of a pull request after the first
+// page returned by mergeReadinessQuery.
+type mergeReadinessThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads mergeReadinessThreads `graphql:"reviewThreads(first: 100, after: $after)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+type mergeReadinessReviewNode struct {
+	State  githubv4.String
+	Author struct {
+		Login githubv4.String
+	}
+}
+
+type mergeReadinessReviewRequestNode struct {
+	AsCodeOwner       githubv4.Boolean
+	RequestedReviewer struct {
+		User struct {
+			Login githubv4.String
+		} `graphql:"... on User"`
+		Team struct {
+			CombinedSlug githubv4.String
+		} `graphql:"... on Team"`
+	}
+}
+
+type mergeReadinessCommitNode struct {
+	Commit struct {
+		StatusCheckRollup *struct {
+			State    githubv4.String
+			Contexts struct {
+				Nodes []statusCheckContextNode
+			} `graphql:"contexts(first: 100)"`
+		}
+	}
+}
+
+// statusCheckContextNode is either a check run (Checks API) or a commit status context.
+type statusCheckContextNode struct {
+	Typename githubv4.String `graphql:"__typename"`
+	CheckRun struct {
+		Name       githubv4.String
+		Status     githubv4.String
+		Conclusion *githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $prNum)"`
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context    githubv4.String
+		State      githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $prNum)"`
+	} `graphql:"... on StatusContext"`
+}
+
+// Outcomes of a status check as reported by get_merge_readiness.
+const (
+	checkOutcomePassing = "passing"
+	checkOutcomePending = "pending"
+	checkOutcomeFailing = "failing"
+)
+
+// outcome returns the name and outcome of a check run or status context.
+func (n statusCheckContextNode) outcome() (string, string) {
+	if n.Typename == "CheckRun" {
+		name := string(n.CheckRun.Name)
+		if n.CheckRun.Status != "COMPLETED" || n.CheckRun.Conclusion == nil {
+			return name, checkOutcomePending
+		}
+		switch *n.CheckRun.Conclusion {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+			return name, checkOutcomePassing
+		default:
+			return name, checkOutcomeFailing
+		}
+	}
+
+	name := string(n.StatusContext.Context)
+	switch n.StatusContext.State {
+	case "SUCCESS":
+		return name, checkOutcomePassing
+	case "PENDING", "EXPECTED":
+		return name, checkOutcomePending
+	default:
+		return name, checkOutcomeFailing
+	}
+}
+
+func (n statusCheckContextNode) isRequired() bool {
+	if n.Typename == "CheckRun" {
+		return bool(n.CheckRun.IsRequired)
+	}
+	return bool(n.StatusContext.IsRequired)
+}
+
+// MergeReadiness is the output type of the get_merge_readiness method.
+type MergeReadiness struct {
+	Ready               bool                        `json:"ready"`
+	BlockedBecause      []string                    `json:"blocked_because"`
+	State               string                      `json:"state"`
+	IsDraft             bool                        `json:"is_draft"`
+	Mergeable           string                      `json:"mergeable"`
+	MergeStateStatus    string                      `json:"merge_state_status"`
+	BaseRef             string                      `json:"base_ref"`
+	HeadSHA             string                      `json:"head_sha"`
+	BehindBase          bool                        `json:"behind_base"`
+	StatusChecks        MergeReadinessStatusChecks  `json:"status_checks"`
+	Reviews             MergeReadinessReviews       `json:"reviews"`
+	Conversations       MergeReadinessConversations `json:"conversations"`
+	MergeQueue          MergeReadinessMergeQueue    `json:"merge_queue"`
+	AllowedMergeMethods []string                    `json:"allowed_merge_methods"`
+}
+
+// MergeReadinessStatusChecks summarizes the status checks of the head commit of a pull request.
+type MergeReadinessStatusChecks struct {
+	State              string   `json:"state,omitempty"`
+	Required           []string `json:"required"`
+	Missing            []string `json:"missing"`
+	Failing            []string `json:"failing"`
+	Pending            []string `json:"pending"`
+	UpToDateRequired   bool     `json:"up_to_date_required"`
+	FailingNotRequired []string `json:"failing_not_required,omitempty"`
+}
+
+// MergeReadinessReviews summarizes the review requirements of a pull request.
+type MergeReadinessReviews struct {
+	ReviewDecision          string   `json:"review_decision,omitempty"`
+	RequiredApprovals       int      `json:"required_approvals"`
+	Approvals               int      `json:"approvals"`
+	ApprovedBy              []string `json:"approved_by"`
+	ChangesRequestedBy      []string `json:"changes_requested_by"`
+	CodeOwnerReviewRequired bool     `json:"code_owner_review_required"`
+	PendingCodeOwners       []string `json:"pending_code_owners"`
+}
+
+// MergeReadinessConversations summarizes the review threads of a pull request.
+type MergeReadinessConversations struct {
+	ResolutionRequired bool `json:"resolution_required"`
+	Unresolved         int  `json:"unresolved"`
+}
+
+// MergeReadinessMergeQueue describes whether the pull request has to be merged through a merge queue.
+type MergeReadinessMergeQueue struct {
+	Required bool   `json:"required"`
+	InQueue  bool   `json:"in_queue"`
+	Position int    `json:"position,omitempty"`
+	State    string `json:"state,omitempty"`
+}
+
+// mergeRequirements are the merge requirements of the base branch, combining its classic
+// branch protection rule and the rulesets that apply to it.
+type mergeRequirements struct {
+	statusChecks            []string
+	strictStatusChecks      bool
+	requiredApprovals       int
+	codeOwnerReview         bool
+	conversationResolution  bool
+	mergeQueue              bool
+	rulesetAllowedMethods   []string
+	restrictsAllowedMethods bool
+}
+
+func (r *mergeRequirements) addStatusCheck(name string) {
+	if !slices.Contains(r.statusChecks, name) {
+		r.statusChecks = append(r.statusChecks, name)
+	}
+}
+
+// addRules merges the rules that rulesets enforce on the base branch into the requirements.
+func (r *mergeRequirements) addRules(rules *github.BranchRules) {
+	if rules == nil {
+		return
+	}
+	for _, rule := range rules.RequiredStatusChecks {
+		for _, check := range rule.Parameters.RequiredStatusChecks {
+			r.addStatusCheck(check.Context)
+		}
+		r.strictStatusChecks = r.strictStatusChecks || rule.Parameters.StrictRequiredStatusChecksPolicy
+	}
+	for _, rule := range rules.PullRequest {
+		r.requiredApprovals = max(r.requiredApprovals, rule.Parameters.RequiredApprovingReviewCount)
+		r.codeOwnerReview = r.codeOwnerReview || rule.Parameters.RequireCodeOwnerReview
+		r.conversationResolution = r.conversationResolution || rule.Parameters.RequiredReviewThreadResolution
+		if len(rule.Parameters.AllowedMergeMethods) == 0 {
+			continue
+		}
+		// Every ruleset has to allow a merge method for it to be usable.
+		methods := make([]string, 0, len(rule.Parameters.AllowedMergeMethods))
+		for _, method := range rule.Parameters.AllowedMergeMethods {
+			if !r.restrictsAllowedMethods || slices.Contains(r.rulesetAllowedMethods, string(method)) {
+				methods = append(methods, string(method))
+			}
+		}
+		r.rulesetAllowedMethods = methods
+		r.restrictsAllowedMethods = true
+	}
+	r.mergeQueue = r.mergeQueue || len(rules.MergeQueue) > 0
+}
+
+// buildMergeReadiness assembles the merge readiness report of a pull request from the GraphQL
+// query result and the rulesets that apply to its base branch.
+func buildMergeReadiness(query *mergeReadinessQuery, rules *github.BranchRules) MergeReadiness {
+	repository := query.Repository
+	pr := repository.PullRequest
+
+	var reqs mergeRequirements
+	if pr.BaseRef != nil && pr.BaseRef.BranchProtectionRule != nil {
+		rule := pr.BaseRef.BranchProtectionRule
+		if rule.RequiresStatusChecks {
+			for _, check := range rule.RequiredStatusCheckContexts {
+				reqs.addStatusCheck(string(check))
+			}
+			reqs.strictStatusChecks = bool(rule.RequiresStrictStatusChecks)
+		}
+		if rule.RequiresApprovingReviews {
+			reqs.requiredApprovals = int(rule.RequiredApprovingReviewCount)
+		}
+		reqs.codeOwnerReview = bool(rule.RequiresCodeOwnerReviews)
+		reqs.conversationResolution = bool(rule.RequiresConversationResolution)
+	}
+	reqs.addRules(rules)
+	reqs.mergeQueue = reqs.mergeQueue || bool(pr.IsMergeQueueEnabled)
+
+	result := MergeReadiness{
+		BlockedBecause:   []string{},
+		State:            string(pr.State),
+		IsDraft:          bool(pr.IsDraft),
+		Mergeable:        string(pr.Mergeable),
+		MergeStateStatus: string(pr.MergeStateStatus),
+		BaseRef:          string(pr.BaseRefName),
+		HeadSHA:          string(pr.HeadRefOid),
+		BehindBase:       pr.MergeStateStatus == "BEHIND",
+		MergeQueue: MergeReadinessMergeQueue{
+			Required: reqs.mergeQueue,
+			InQueue:  bool(pr.IsInMergeQueue),
+		},
+	}
+	if entry := pr.MergeQueueEntry; entry != nil {
+		result.MergeQueue.Position = int(entry.Position)
+		result.MergeQueue.State = string(entry.State)
+	}
+	blocked := func(format string, args ...any) {
+		result.BlockedBecause = append(result.BlockedBecause, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case pr.State == "MERGED":
+		blocked("pull request is already merged")
+	case pr.State == "CLOSED":
+		blocked("pull request is closed")
+	case bool(pr.IsDraft):
+		blocked("pull request is a draft")
+	}
+	switch pr.Mergeable {
+	case "CONFLICTING":
+		blocked("head branch has merge conflicts with %s", pr.BaseRefName)
+	case "UNKNOWN":
+		blocked("mergeability is still being computed by GitHub, check again shortly")
+	}
+	if result.BehindBase {
+		blocked("head branch is behind %s and the branch must be up to date before merging", pr.BaseRefName)
+	}
+
+	result.StatusChecks = buildStatusChecks(pr.Commits.Nodes, &reqs)
+	checks := result.StatusChecks
+	if len(checks.Missing) > 0 {
+		blocked("required status checks have not run: %s", strings.Join(checks.Missing, ", "))
+	}
+	if len(checks.Failing) > 0 {
+		blocked("required status checks are failing: %s", strings.Join(checks.Failing, ", "))
+	}
+	if len(checks.Pending) > 0 {
+		blocked("required status checks are still running: %s", strings.Join(checks.Pending, ", "))
+	}
+
+	result.Reviews = buildReviews(&reqs, pr.ReviewDecision, pr.LatestOpinionatedReviews.Nodes, pr.ReviewRequests.Nodes)
+	reviews := result.Reviews
+	if len(reviews.ChangesRequestedBy) > 0 {
+		blocked("changes requested by %s", strings.Join(reviews.ChangesRequestedBy, ", "))
+	}
+	if reviews.Approvals < reviews.RequiredApprovals {
+		blocked("%d approving review(s) required, %d given", reviews.RequiredApprovals, reviews.Approvals)
+	}
+	if len(reviews.PendingCodeOwners) > 0 {
+		blocked("code owner review required from %s", strings.Join(reviews.PendingCodeOwners, ", "))
+	} else if reviews.CodeOwnerReviewRequired && reviews.ReviewDecision == "REVIEW_REQUIRED" && reviews.Approvals >= reviews.RequiredApprovals {
+		blocked("code owner review required")
+	}
+
+	unresolved := 0
+	for _, thread := range pr.ReviewThreads.Nodes {
+		if !thread.IsResolved {
+			unresolved++
+		}
+	}
+	result.Conversations = MergeReadinessConversations{
+		ResolutionRequired: reqs.conversationResolution,
+		Unresolved:         unresolved,
+	}
+	if reqs.conversationResolution && unresolved > 0 {
+		blocked("%d unresolved review conversation(s) must be resolved", unresolved)
+	}
+
+	result.AllowedMergeMethods = allowedMergeMethods(repository.MergeCommitAllowed, repository.SquashMergeAllowed, repository.RebaseMergeAllowed, &reqs)
+	if len(result.AllowedMergeMethods) == 0 {
+		blocked("no merge method is allowed by both the repository settings and the branch rulesets")
+	}
+
+	// GitHub can block a merge for reasons not covered above, such as required deployments
+	// or signed commits.
+	if pr.MergeStateStatus == "BLOCKED" && len(result.BlockedBecause) == 0 {
+		blocked("merging is blocked by branch protection or rulesets, check get_branch_rules for the rules of %s", pr.BaseRefName)
+	}
+
+	result.Ready = len(result.BlockedBecause) == 0
+	return result
+}
+
+// buildStatusChecks classifies the required status checks of the head commit as missing,
+// failing or pending. Checks are required if the base branch requires them by name or GitHub
+// reports them as required.
+func buildStatusChecks(commits []mergeReadinessCommitNode, reqs *mergeRequirements) MergeReadinessStatusChecks {
+	result := MergeReadinessStatusChecks{
+		Missing:          []string{},
+		Failing:          []string{},
+		Pending:          []string{},
+		UpToDateRequired: reqs.strictStatusChecks,
+	}
+
+	outcomes := map[string]string{}
+	var contexts []statusCheckContextNode
+	if len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+		result.State = string(commits[0].Commit.StatusCheckRollup.State)
+		contexts = commits[0].Commit.StatusCheckRollup.Contexts.Nodes
+	}
+	for _, node := range contexts {
+		name, outcome := node.outcome()
+		if node.isRequired() {
+			reqs.addStatusCheck(name)
+		}
+		// A check that ran several times counts as failing if any run failed.
+		if outcomes[name] != checkOutcomeFailing && outcomes[name] != checkOutcomePending {
+			outcomes[name] = outcome
+		} else if outcome == checkOutcomeFailing {
+			outcomes[name] = outcome
+		}
+	}
+
+	result.Required = slices.Clone(reqs.statusChecks)
+	sort.Strings(result.Required)
+	if result.Required == nil {
+		result.Required = []string{}
+	}
+	for _, name := range result.Required {
+		switch outcome, ok := outcomes[name]; {
+		case !ok:
+			result.Missing = append(result.Missing, name)
+		case outcome == checkOutcomeFailing:
+			result.Failing = append(result.Failing, name)
+		case outcome == checkOutcomePending:
+			result.Pending = append(result.Pending, name)
+		}
+	}
+	for name, outcome := range outcomes {
+		if outcome == checkOutcomeFailing && !slices.Contains(result.Required, name) {
+			result.FailingNotRequired = append(result.FailingNotRequired, name)
+		}
+	}
+	sort.Strings(result.FailingNotRequired)
+	return result
+}
+
+// buildReviews summarizes the latest reviews and the outstanding code owner review requests.
+func buildReviews(reqs *mergeRequirements, decision *githubv4.String, latestReviews []mergeReadinessReviewNode, requests []mergeReadinessReviewRequestNode) MergeReadinessReviews {
+	result := MergeReadinessReviews{
+		RequiredApprovals:       reqs.requiredApprovals,
+		ApprovedBy:              []string{},
+		ChangesRequestedBy:      []string{},
+		CodeOwnerReviewRequired: reqs.codeOwnerReview,
+		PendingCodeOwners:       []string{},
+	}
+	if decision != nil {
+		result.ReviewDecision = string(*decision)
+	}
+	for _, review := range latestReviews {
+		switch review.State {
+		case "APPROVED":
+			result.ApprovedBy = append(result.ApprovedBy, string(review.Author.Login))
+		case "CHANGES_REQUESTED":
+			result.ChangesRequestedBy = append(result.ChangesRequestedBy, string(review.Author.Login))
+		}
+	}
+	result.Approvals = len(result.ApprovedBy)
+
+	if reqs.codeOwnerReview {
+		for _, request := range requests {
+			if !request.AsCodeOwner {
+				continue
+			}
+			if login := request.RequestedReviewer.User.Login; login != "" {
+				result.PendingCodeOwners = append(result.PendingCodeOwners, string(login))
+			} else if slug := request.RequestedReviewer.Team.CombinedSlug; slug != "" {
+				result.PendingCodeOwners = append(result.PendingCodeOwners, string(slug))
+			}
+		}
+	}
+	return result
+}
+
+// allowedMergeMethods lists the merge methods enabled in the repository settings that are
+// not excluded by a ruleset.
+func allowedMergeMethods(mergeCommit, squash, rebase githubv4.Boolean, reqs *mergeRequirements) []string {
+	methods := []string{}
+	for _, method := range []struct {
+		name    string
+		enabled githubv4.Boolean
+	}{
+		{"merge", mergeCommit},
+		{"squash", squash},
+		{"rebase", rebase},
+	} {
+		if !method.enabled {
+			continue
+		}
+		if reqs.restrictsAllowedMethods && !slices.Contains(reqs.rulesetAllowedMethods, method.name) {
+			continue
+		}
+		methods = append(methods, method.name)
+	}
+	return methods
+}
+
+// GetPullRequestMergeReadiness reports whether a pull request can be merged and, if not, why.
+func GetPullRequestMergeReadiness(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(pullNumber)), //nolint:gosec // pullNumber is controlled by user input validation
+	}
+	var query mergeReadinessQuery
+	if err := gqlClient.Query(ctx, &query, vars); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get pull request merge readiness",
+			err,
+		), nil
+	}
+
+	// Every review thread has to be loaded, or unresolved conversations past the first page
+	// would be missed.
+	threads := &query.Repository.PullRequest.ReviewThreads
+	for pageInfo := threads.PageInfo; pageInfo.HasNextPage; {
+		vars["after"] = pageInfo.EndCursor
+		var page mergeReadinessThreadsQuery
+		if err := gqlClient.Query(ctx, &page, vars); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				"failed to get pull request review threads",
+				err,
+			), nil
+		}
+		threads.Nodes = append(threads.Nodes, page.Repository.PullRequest.ReviewThreads.Nodes...)
+		pageInfo = page.Repository.PullRequest.ReviewThreads.PageInfo
+	}
+
+	// go-github does not escape the branch name, so we build the request ourselves.
+	u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(string(query.Repository.PullRequest.BaseRefName)))
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var rules *github.BranchRules
+	resp, err := client.Do(ctx, req, &rules)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get rules for base branch",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return utils.NewToolResultJSON(buildMergeReadiness(&query, rules))
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergeRequirementsAddRules(t *testing.T) {
+	reqs := mergeRequirements{
+		statusChecks:      []string{"build"},
+		requiredApprovals: 1,
+	}
+	reqs.addRules(&github.BranchRules{
+		RequiredStatusChecks: []*github.RequiredStatusChecksBranchRule{
+			{Parameters: github.RequiredStatusChecksRuleParameters{
+				RequiredStatusChecks: []*github.RuleStatusCheck{{Context: "build"}, {Context: "test"}},
+			}},
+		},
+		PullRequest: []*github.PullRequestBranchRule{
+			{Parameters: github.PullRequestRuleParameters{
+				RequiredApprovingReviewCount: 2,
+				RequireCodeOwnerReview:       true,
+				AllowedMergeMethods:          []github.PullRequestMergeMethod{github.PullRequestMergeMethodSquash, github.PullRequestMergeMethodRebase},
+			}},
+			{Parameters: github.PullRequestRuleParameters{
+				RequiredApprovingReviewCount:   1,
+				RequiredReviewThreadResolution: true,
+				AllowedMergeMethods:            []github.PullRequestMergeMethod{github.PullRequestMergeMethodMerge, github.PullRequestMergeMethodSquash},
+			}},
+		},
+		MergeQueue: []*github.MergeQueueBranchRule{{}},
+	})
+
+	assert.Equal(t, []string{"build", "test"}, reqs.statusChecks)
+	assert.Equal(t, 2, reqs.requiredApprovals)
+	assert.True(t, reqs.codeOwnerReview)
+	assert.True(t, reqs.conversationResolution)
+	assert.True(t, reqs.mergeQueue)
+	assert.Equal(t, []string{"squash"}, reqs.rulesetAllowedMethods)
+	assert.Equal(t, []string{"squash"}, allowedMergeMethods(true, true, true, &reqs))
+	assert.Empty(t, allowedMergeMethods(true, false, true, &reqs))
+
+	var unrestricted mergeRequirements
+	unrestricted.addRules(nil)
+	assert.Equal(t, []string{"merge", "rebase"}, allowedMergeMethods(true, false, true, &unrestricted))
+}
+
+func Test_StatusCheckContextOutcome(t *testing.T) {
+	conclusion := func(s string) *githubv4.String {
+		v := githubv4.String(s)
+		return &v
+	}
+	checkRun := func(status string, c *githubv4.String) statusCheckContextNode {
+		var node statusCheckContextNode
+		node.Typename = "CheckRun"
+		node.CheckRun.Name = "build"
+		node.CheckRun.Status = githubv4.String(status)
+		node.CheckRun.Conclusion = c
+		return node
+	}
+	statusContext := func(state string) statusCheckContextNode {
+		var node statusCheckContextNode
+		node.Typename = "StatusContext"
+		node.StatusContext.Context = "ci/lint"
+		node.StatusContext.State = githubv4.String(state)
+		return node
+	}
+
+	tests := []struct {
+		name            string
+		node            statusCheckContextNode
+		expectedName    string
+		expectedOutcome string
+	}{
+		{name: "successful check run", node: checkRun("COMPLETED", conclusion("SUCCESS")), expectedName: "build", expectedOutcome: checkOutcomePassing},
+		{name: "skipped check run", node: checkRun("COMPLETED", conclusion("SKIPPED")), expectedName: "build", expectedOutcome: checkOutcomePassing},
+		{name: "timed out check run", node: checkRun("COMPLETED", conclusion("TIMED_OUT")), expectedName: "build", expectedOutcome: checkOutcomeFailing},
+		{name: "queued check run", node: checkRun("QUEUED", nil), expectedName: "build", expectedOutcome: checkOutcomePending},
+		{name: "successful status", node: statusContext("SUCCESS"), expectedName: "ci/lint", expectedOutcome: checkOutcomePassing},
+		{name: "expected status", node: statusContext("EXPECTED"), expectedName: "ci/lint", expectedOutcome: checkOutcomePending},
+		{name: "errored status", node: statusContext("ERROR"), expectedName: "ci/lint", expectedOutcome: checkOutcomeFailing},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			name, outcome := tc.node.outcome()
+			assert.Equal(t, tc.expectedName, name)
+			assert.Equal(t, tc.expectedOutcome, outcome)
+		})
+	}
+}
@@ -0,0 +1,495 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxMilestoneProgressPages limits progress reports to the first 1000 open issues and pull
+// requests of a milestone.
+const maxMilestoneProgressPages = 10
+
+// Group names used in milestone progress reports for items without an assignee or label.
+const (
+	milestoneProgressUnassigned = "(unassigned)"
+	milestoneProgressUnlabeled  = "(no label)"
+)
+
+// MilestoneProgressItem is an open issue or pull request of a milestone.
+type MilestoneProgressItem struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	IsPullRequest bool   `json:"is_pull_request"`
+	URL           string `json:"url"`
+}
+
+// MilestoneProgressGroup is the open issues and pull requests of a milestone that share an
+// assignee or a label.
+type MilestoneProgressGroup struct {
+	Name         string                  `json:"name"`
+	Issues       int                     `json:"issues"`
+	PullRequests int                     `json:"pull_requests"`
+	Items        []MilestoneProgressItem `json:"items"`
+}
+
+// MilestoneProgress is the progress report of a milestone. Items with several assignees or
+// labels are counted in each of their groups.
+type MilestoneProgress struct {
+	Milestone        MinimalMilestone         `json:"milestone"`
+	PercentComplete  float64                  `json:"percent_complete"`
+	Overdue          bool                     `json:"overdue"`
+	DaysRemaining    *int                     `json:"days_remaining,omitempty"`
+	OpenIssues       int                      `json:"open_issues"`
+	OpenPullRequests int                      `json:"open_pull_requests"`
+	ByAssignee       []MilestoneProgressGroup `json:"by_assignee"`
+	ByLabel          []MilestoneProgressGroup `json:"by_label"`
+	Truncated        bool                     `json:"truncated,omitempty"`
+}
+
+// MilestoneRead creates a tool to list milestones, get a milestone and report its progress.
+func MilestoneRead(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMilestones,
+		mcp.Tool{
+			Name:        "milestone_read",
+			Description: t("TOOL_MILESTONE_READ_DESCRIPTION", "List the milestones of a repository with their open and closed issue counts and due dates, get a single milestone, or get a progress report of a milestone."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MILESTONE_READ_USER_TITLE", "Read milestones"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The read operation to perform:
+- 'list': list the milestones of the repository.
+- 'get': get the milestone 'milestone_number'.
+- 'get_progress': get the completion of the milestone 'milestone_number' with its open issues and pull requests grouped by assignee and by label.`,
+						Enum: []any{"list", "get", "get_progress"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"milestone_number": {
+						Type:        "number",
+						Description: "The number of the milestone. Required for 'get' and 'get_progress'.",
+					},
+					"state": {
+						Type:        "string",
+						Description: "For 'list', filter milestones by state. Defaults to open.",
+						Enum:        []any{"open", "closed", "all"},
+					},
+					"sort": {
+						Type:        "string",
+						Description: "For 'list', what to sort milestones by. Defaults to due_on.",
+						Enum:        []any{"due_on", "completeness"},
+					},
+					"direction": {
+						Type:        "string",
+						Description: "For 'list', the sort direction. Defaults to asc.",
+						Enum:        []any{"asc", "desc"},
+					},
+				},
+				Required: []string{"method", "owner", "repo"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			milestoneNumber, err := OptionalIntParam(args, "milestone_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := OptionalParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sortBy, err := OptionalParam[string](args, "sort")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			direction, err := OptionalParam[string](args, "direction")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "list":
+				opts := &github.MilestoneListOptions{
+					State:     state,
+					Sort:      sortBy,
+					Direction: direction,
+					ListOptions: github.ListOptions{
+						Page:    pagination.Page,
+						PerPage: pagination.PerPage,
+					},
+				}
+				milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list milestones", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				minimalMilestones := make([]MinimalMilestone, 0, len(milestones))
+				for _, milestone := range milestones {
+					minimalMilestones = append(minimalMilestones, convertToMinimalMilestone(milestone))
+				}
+
+				result, err := utils.NewToolResultJSON(minimalMilestones)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "get":
+				if milestoneNumber == 0 {
+					return utils.NewToolResultError("milestone_number is required for get"), nil, nil
+				}
+				milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, milestoneNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get milestone", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalMilestone(milestone))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "get_progress":
+				if milestoneNumber == 0 {
+					return utils.NewToolResultError("milestone_number is required for get_progress"), nil, nil
+				}
+				result, err := GetMilestoneProgress(ctx, client, owner, repo, milestoneNumber)
+				return result, nil, err
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: list, get, get_progress", method)), nil, nil
+			}
+		},
+	)
+}
+
+// MilestoneWrite creates a tool to create, update, close and delete milestones.
+func MilestoneWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMilestones,
+		mcp.Tool{
+			Name:        "milestone_write",
+			Description: t("TOOL_MILESTONE_WRITE_DESCRIPTION", "Create, update, close or delete a milestone of a repository. Updates only change the fields that are provided."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_MILESTONE_WRITE_USER_TITLE", "Manage milestones"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'create': create a milestone with 'title'.
+- 'update': change the title, description, due date or state of the milestone 'milestone_number'.
+- 'close': close the milestone 'milestone_number'.
+- 'delete': delete the milestone 'milestone_number'. Its issues and pull requests are kept but no longer belong to a milestone.`,
+						Enum: []any{"create", "update", "close", "delete"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"milestone_number": {
+						Type:        "number",
+						Description: "The number of the milestone. Required for 'update', 'close' and 'delete'.",
+					},
+					"title": {
+						Type:        "string",
+						Description: "The title of the milestone. Required for 'create'.",
+					},
+					"description": {
+						Type:        "string",
+						Description: "The description of the milestone",
+					},
+					"due_on": {
+						Type:        "string",
+						Description: "The due date of the milestone (ISO 8601 timestamp or YYYY-MM-DD)",
+					},
+					"state": {
+						Type:        "string",
+						Description: "The state of the milestone. Use 'open' with 'update' to reopen a closed milestone.",
+						Enum:        []any{"open", "closed"},
+					},
+				},
+				Required: []string{"method", "owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			milestoneNumber, err := OptionalIntParam(args, "milestone_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			milestone, err := milestoneFromArgs(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "create":
+				if milestone.GetTitle() == "" {
+					return utils.NewToolResultError("title is required for create"), nil, nil
+				}
+				created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create milestone", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalMilestone(created))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "update", "close":
+				if milestoneNumber == 0 {
+					return utils.NewToolResultError(fmt.Sprintf("milestone_number is required for %s", method)), nil, nil
+				}
+				if method == "close" {
+					milestone.State = github.Ptr("closed")
+				}
+				if *milestone == (github.Milestone{}) {
+					return utils.NewToolResultError("at least one of title, description, due_on or state is required for update"), nil, nil
+				}
+				updated, resp, err := client.Issues.EditMilestone(ctx, owner, repo, milestoneNumber, milestone)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to %s milestone", method), resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalMilestone(updated))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "delete":
+				if milestoneNumber == 0 {
+					return utils.NewToolResultError("milestone_number is required for delete"), nil, nil
+				}
+				resp, err := client.Issues.DeleteMilestone(ctx, owner, repo, milestoneNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete milestone", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("Milestone %d deleted from %s/%s", milestoneNumber, owner, repo)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: create, update, close, delete", method)), nil, nil
+			}
+		},
+	)
+}
+
+// milestoneFromArgs builds a milestone request with the fields provided in args.
+func milestoneFromArgs(args map[string]any) (*github.Milestone, error) {
+	milestone := &github.Milestone{}
+	title, err := OptionalParam[string](args, "title")
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		milestone.Title = github.Ptr(title)
+	}
+	description, ok, err := OptionalParamOK[string](args, "description")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		milestone.Description = github.Ptr(description)
+	}
+	dueOn, err := OptionalParam[string](args, "due_on")
+	if err != nil {
+		return nil, err
+	}
+	if dueOn != "" {
+		due, err := parseISOTimestamp(dueOn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_on: %w", err)
+		}
+		milestone.DueOn = &github.Timestamp{Time: due}
+	}
+	state, err := OptionalParam[string](args, "state")
+	if err != nil {
+		return nil, err
+	}
+	if state != "" {
+		milestone.State = github.Ptr(state)
+	}
+	return milestone, nil
+}
+
+// GetMilestoneProgress reports the completion of a milestone and groups its open issues and pull
+// requests by assignee and by label.
+func GetMilestoneProgress(ctx context.Context, client *github.Client, owner, repo string, milestoneNumber int) (*mcp.CallToolResult, error) {
+	milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, milestoneNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get milestone", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	var issues []*github.Issue
+	truncated := false
+	opts := &github.IssueListByRepoOptions{
+		Milestone:   strconv.Itoa(milestoneNumber),
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for page := 0; ; page++ {
+		if page == maxMilestoneProgressPages {
+			truncated = true
+			break
+		}
+		pageIssues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list milestone issues", resp, err), nil
+		}
+		_ = resp.Body.Close()
+		issues = append(issues, pageIssues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	progress := buildMilestoneProgress(milestone, issues, time.Now())
+	progress.Truncated = truncated
+	return utils.NewToolResultJSON(progress)
+}
+
+// buildMilestoneProgress computes the progress report of a milestone from its open issues and
+// pull requests as of now.
+func buildMilestoneProgress(milestone *github.Milestone, issues []*github.Issue, now time.Time) MilestoneProgress {
+	progress := MilestoneProgress{
+		Milestone: convertToMinimalMilestone(milestone),
+	}
+	if total := milestone.GetOpenIssues() + milestone.GetClosedIssues(); total > 0 {
+		// Round to one decimal place.
+		progress.PercentComplete = float64(milestone.GetClosedIssues()*1000/total) / 10
+	}
+	if milestone.DueOn != nil && milestone.GetState() == "open" {
+		days := int(milestone.DueOn.Sub(now).Hours() / 24)
+		progress.DaysRemaining = &days
+		progress.Overdue = milestone.DueOn.Before(now)
+	}
+
+	byAssignee := map[string]*MilestoneProgressGroup{}
+	byLabel := map[string]*MilestoneProgressGroup{}
+	addToGroup := func(groups map[string]*MilestoneProgressGroup, name string, item MilestoneProgressItem) {
+		group, ok := groups[name]
+		if !ok {
+			group = &MilestoneProgressGroup{Name: name, Items: []MilestoneProgressItem{}}
+			groups[name] = group
+		}
+		if item.IsPullRequest {
+			group.PullRequests++
+		} else {
+			group.Issues++
+		}
+		group.Items = append(group.Items, item)
+	}
+
+	for _, issue := range issues {
+		item := MilestoneProgressItem{
+			Number:        issue.GetNumber(),
+			Title:         sanitize.Sanitize(issue.GetTitle()),
+			IsPullRequest: issue.IsPullRequest(),
+			URL:           issue.GetHTMLURL(),
+		}
+		if item.IsPullRequest {
+			progress.OpenPullRequests++
+		} else {
+			progress.OpenIssues++
+		}
+
+		if len(issue.Assignees) == 0 {
+			addToGroup(byAssignee, milestoneProgressUnassigned, item)
+		}
+		for _, assignee := range issue.Assignees {
+			addToGroup(byAssignee, assignee.GetLogin(), item)
+		}
+		if len(issue.Labels) == 0 {
+			addToGroup(byLabel, milestoneProgressUnlabeled, item)
+		}
+		for _, label := range issue.Labels {
+			addToGroup(byLabel, label.GetName(), item)
+		}
+	}
+
+	progress.ByAssignee = sortMilestoneProgressGroups(byAssignee)
+	progress.ByLabel = sortMilestoneProgressGroups(byLabel)
+	return progress
+}
+
+// sortMilestoneProgressGroups returns the groups with the most open items first.
+func sortMilestoneProgressGroups(groups map[string]*MilestoneProgressGroup) []MilestoneProgressGroup {
+	sorted := make([]MilestoneProgressGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, *group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i].Items) != len(sorted[j].Items) {
+			return len(sorted[i].Items) > len(sorted[j].Items)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
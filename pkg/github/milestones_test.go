@@ -0,0 +1,439 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MilestoneRead(t *testing.T) {
+	// Verify tool definition once
+	serverTool := MilestoneRead(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "milestone_read", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "milestone_number")
+	assert.Contains(t, schema.Properties, "page")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo"})
+
+	dueOn := &github.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mockMilestone := &github.Milestone{
+		Number:       github.Ptr(3),
+		Title:        github.Ptr("v1.0"),
+		Description:  github.Ptr("First release"),
+		State:        github.Ptr("open"),
+		OpenIssues:   github.Ptr(2),
+		ClosedIssues: github.Ptr(6),
+		DueOn:        dueOn,
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/milestone/3"),
+	}
+	mockIssues := []*github.Issue{
+		{
+			Number:    github.Ptr(10),
+			Title:     github.Ptr("Crash on startup"),
+			HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/10"),
+			Assignees: []*github.User{{Login: github.Ptr("octocat")}},
+			Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		},
+		{
+			Number:           github.Ptr(11),
+			Title:            github.Ptr("Fix crash on startup"),
+			HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/11"),
+			Assignees:        []*github.User{{Login: github.Ptr("octocat")}},
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/11")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		verify         func(t *testing.T, text string)
+	}{
+		{
+			name: "lists milestones",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposMilestonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "all",
+						"sort":      "completeness",
+						"direction": "desc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Milestone{mockMilestone}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":    "list",
+				"owner":     "owner",
+				"repo":      "repo",
+				"state":     "all",
+				"sort":      "completeness",
+				"direction": "desc",
+			},
+			verify: func(t *testing.T, text string) {
+				var milestones []MinimalMilestone
+				require.NoError(t, json.Unmarshal([]byte(text), &milestones))
+				require.Len(t, milestones, 1)
+				assert.Equal(t, 3, milestones[0].Number)
+				assert.Equal(t, 2, milestones[0].OpenIssues)
+				assert.Equal(t, 6, milestones[0].ClosedIssues)
+				assert.Equal(t, "2024-06-01T00:00:00Z", milestones[0].DueOn)
+			},
+		},
+		{
+			name: "gets a milestone",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposMilestonesByOwnerByRepoByMilestoneNumber, mockMilestone),
+			),
+			requestArgs: map[string]any{
+				"method":           "get",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			},
+			verify: func(t *testing.T, text string) {
+				var milestone MinimalMilestone
+				require.NoError(t, json.Unmarshal([]byte(text), &milestone))
+				assert.Equal(t, "v1.0", milestone.Title)
+				assert.Equal(t, "First release", milestone.Description)
+			},
+		},
+		{
+			name: "reports milestone progress",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposMilestonesByOwnerByRepoByMilestoneNumber, mockMilestone),
+				WithRequestMatchHandler(
+					GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"milestone": "3",
+						"state":     "open",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIssues),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "get_progress",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			},
+			verify: func(t *testing.T, text string) {
+				var progress MilestoneProgress
+				require.NoError(t, json.Unmarshal([]byte(text), &progress))
+				assert.Equal(t, 3, progress.Milestone.Number)
+				assert.InDelta(t, 75.0, progress.PercentComplete, 0.01)
+				assert.Equal(t, 1, progress.OpenIssues)
+				assert.Equal(t, 1, progress.OpenPullRequests)
+				require.Len(t, progress.ByAssignee, 1)
+				assert.Equal(t, "octocat", progress.ByAssignee[0].Name)
+				assert.Equal(t, 1, progress.ByAssignee[0].Issues)
+				assert.Equal(t, 1, progress.ByAssignee[0].PullRequests)
+				assert.False(t, progress.Truncated)
+			},
+		},
+		{
+			name:         "get requires milestone_number",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "get",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "milestone_number is required for get",
+		},
+		{
+			name: "milestone not found",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "get_progress",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			tc.verify(t, textContent.Text)
+		})
+	}
+}
+
+func Test_MilestoneWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := MilestoneWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "milestone_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, schema.Properties, "due_on")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo"})
+
+	mockMilestone := &github.Milestone{
+		Number: github.Ptr(4),
+		Title:  github.Ptr("v2.0"),
+		State:  github.Ptr("open"),
+	}
+	mockClosedMilestone := &github.Milestone{
+		Number: github.Ptr(4),
+		Title:  github.Ptr("v2.0"),
+		State:  github.Ptr("closed"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedState   string
+		expectedMessage string
+	}{
+		{
+			name: "creates a milestone",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":       "v2.0",
+						"description": "Second release",
+						"due_on":      "2024-09-30T00:00:00Z",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockMilestone),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":      "create",
+				"owner":       "owner",
+				"repo":        "repo",
+				"title":       "v2.0",
+				"description": "Second release",
+				"due_on":      "2024-09-30",
+			},
+			expectedState: "open",
+		},
+		{
+			name: "updates a milestone",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectRequestBody(t, map[string]any{
+						"description": "",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockMilestone),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "update",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(4),
+				"description":      "",
+			},
+			expectedState: "open",
+		},
+		{
+			name: "closes a milestone",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectRequestBody(t, map[string]any{
+						"state": "closed",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockClosedMilestone),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "close",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(4),
+			},
+			expectedState: "closed",
+		},
+		{
+			name: "deletes a milestone",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "delete",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(4),
+			},
+			expectedMessage: "Milestone 4 deleted from owner/repo",
+		},
+		{
+			name:         "create requires title",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "title is required for create",
+		},
+		{
+			name:         "update requires a change",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":           "update",
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(4),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of title, description, due_on or state is required for update",
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "v2.0",
+				"due_on": "next friday",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid due_on",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, textContent.Text)
+				return
+			}
+			var milestone MinimalMilestone
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &milestone))
+			assert.Equal(t, 4, milestone.Number)
+			assert.Equal(t, tc.expectedState, milestone.State)
+		})
+	}
+}
+
+func Test_buildMilestoneProgress(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	milestone := &github.Milestone{
+		Number:       github.Ptr(1),
+		Title:        github.Ptr("v1.0"),
+		State:        github.Ptr("open"),
+		OpenIssues:   github.Ptr(3),
+		ClosedIssues: github.Ptr(0),
+		DueOn:        &github.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	issues := []*github.Issue{
+		{
+			Number:    github.Ptr(1),
+			Assignees: []*github.User{{Login: github.Ptr("alice")}, {Login: github.Ptr("bob")}},
+			Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		},
+		{
+			Number:    github.Ptr(2),
+			Assignees: []*github.User{{Login: github.Ptr("bob")}},
+			Labels:    []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("ui")}},
+		},
+		{
+			Number: github.Ptr(3),
+		},
+	}
+
+	progress := buildMilestoneProgress(milestone, issues, now)
+
+	assert.Equal(t, 0.0, progress.PercentComplete)
+	assert.True(t, progress.Overdue)
+	require.NotNil(t, progress.DaysRemaining)
+	assert.Equal(t, -9, *progress.DaysRemaining)
+	assert.Equal(t, 3, progress.OpenIssues)
+
+	assignees := make([]string, len(progress.ByAssignee))
+	for i, group := range progress.ByAssignee {
+		assignees[i] = group.Name
+	}
+	assert.Equal(t, []string{"bob", "(unassigned)", "alice"}, assignees)
+
+	labels := make([]string, len(progress.ByLabel))
+	for i, group := range progress.ByLabel {
+		labels[i] = group.Name
+	}
+	assert.Equal(t, []string{"bug", "(no label)", "ui"}, labels)
+	assert.Equal(t, 2, progress.ByLabel[0].Issues)
+}
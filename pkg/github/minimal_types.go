@@ -1,6 +1,9 @@
 package github
 
 import (
+	"encoding/json"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/google/go-github/v79/github"
 )
 
@@ -108,6 +111,16 @@ type MinimalBranch struct {
 	Protected bool   `json:"protected"`
 }
 
+// MinimalBranchRule is the output type for a rule that applies to a branch.
+// Parameters are kept as raw JSON because their shape depends on the rule type.
+type MinimalBranchRule struct {
+	Type              string          `json:"type"`
+	RulesetSourceType string          `json:"ruleset_source_type,omitempty"`
+	RulesetSource     string          `json:"ruleset_source,omitempty"`
+	RulesetID         int64           `json:"ruleset_id,omitempty"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+}
+
 // MinimalResponse represents a minimal response for all CRUD operations.
 // Success is implicit in the HTTP response status, and all other information
 // can be derived from the URL or fetched separately if needed.
@@ -259,3 +272,401 @@ func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 		Protected: branch.GetProtected(),
 	}
 }
+
+// MinimalRepositorySettings is the trimmed output type for repository administration results.
+type MinimalRepositorySettings struct {
+	FullName            string   `json:"full_name"`
+	HTMLURL             string   `json:"html_url"`
+	Description         string   `json:"description,omitempty"`
+	Homepage            string   `json:"homepage,omitempty"`
+	Topics              []string `json:"topics,omitempty"`
+	DefaultBranch       string   `json:"default_branch,omitempty"`
+	Visibility          string   `json:"visibility,omitempty"`
+	Archived            bool     `json:"archived"`
+	IsTemplate          bool     `json:"is_template"`
+	HasIssues           bool     `json:"has_issues"`
+	HasWiki             bool     `json:"has_wiki"`
+	HasProjects         bool     `json:"has_projects"`
+	HasDiscussions      bool     `json:"has_discussions"`
+	AllowMergeCommit    bool     `json:"allow_merge_commit"`
+	AllowSquashMerge    bool     `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool     `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool     `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool     `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"`
+}
+
+// convertToMinimalRepositorySettings converts a GitHub API Repository to MinimalRepositorySettings
+func convertToMinimalRepositorySettings(repo *github.Repository) MinimalRepositorySettings {
+	return MinimalRepositorySettings{
+		FullName:            repo.GetFullName(),
+		HTMLURL:             repo.GetHTMLURL(),
+		Description:         repo.GetDescription(),
+		Homepage:            repo.GetHomepage(),
+		Topics:              repo.Topics,
+		DefaultBranch:       repo.GetDefaultBranch(),
+		Visibility:          repo.GetVisibility(),
+		Archived:            repo.GetArchived(),
+		IsTemplate:          repo.GetIsTemplate(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+		HasProjects:         repo.GetHasProjects(),
+		HasDiscussions:      repo.GetHasDiscussions(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:      repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:   repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+	}
+}
+
+// MinimalCollaborator is the trimmed output type for repository collaborators.
+type MinimalCollaborator struct {
+	Login       string          `json:"login"`
+	ID          int64           `json:"id,omitempty"`
+	ProfileURL  string          `json:"profile_url,omitempty"`
+	RoleName    string          `json:"role_name,omitempty"`
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// MinimalRepositoryInvitation is the trimmed output type for repository invitations.
+type MinimalRepositoryInvitation struct {
+	ID          int64  `json:"id"`
+	Repository  string `json:"repository,omitempty"`
+	Invitee     string `json:"invitee,omitempty"`
+	Inviter     string `json:"inviter,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+}
+
+// MinimalRepositoryTeam is the trimmed output type for teams with access to a repository.
+type MinimalRepositoryTeam struct {
+	ID         int64  `json:"id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	Permission string `json:"permission,omitempty"`
+	HTMLURL    string `json:"html_url,omitempty"`
+}
+
+func convertToMinimalCollaborator(user *github.User) MinimalCollaborator {
+	return MinimalCollaborator{
+		Login:       user.GetLogin(),
+		ID:          user.GetID(),
+		ProfileURL:  user.GetHTMLURL(),
+		RoleName:    user.GetRoleName(),
+		Permissions: user.Permissions,
+	}
+}
+
+func convertToMinimalRepositoryInvitation(invitation *github.RepositoryInvitation) MinimalRepositoryInvitation {
+	minimalInvitation := MinimalRepositoryInvitation{
+		ID:          invitation.GetID(),
+		Repository:  invitation.GetRepo().GetFullName(),
+		Invitee:     invitation.GetInvitee().GetLogin(),
+		Inviter:     invitation.GetInviter().GetLogin(),
+		Permissions: invitation.GetPermissions(),
+		HTMLURL:     invitation.GetHTMLURL(),
+	}
+	if invitation.CreatedAt != nil {
+		minimalInvitation.CreatedAt = invitation.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalInvitation
+}
+
+func convertToMinimalRepositoryTeam(team *github.Team) MinimalRepositoryTeam {
+	return MinimalRepositoryTeam{
+		ID:         team.GetID(),
+		Slug:       team.GetSlug(),
+		Name:       team.GetName(),
+		Permission: team.GetPermission(),
+		HTMLURL:    team.GetHTMLURL(),
+	}
+}
+
+// MinimalTeam is the trimmed output type for organization teams.
+type MinimalTeam struct {
+	ID                  int64  `json:"id"`
+	Slug                string `json:"slug"`
+	Name                string `json:"name"`
+	Description         string `json:"description,omitempty"`
+	Privacy             string `json:"privacy,omitempty"`
+	NotificationSetting string `json:"notification_setting,omitempty"`
+	Parent              string `json:"parent,omitempty"`
+	HTMLURL             string `json:"html_url,omitempty"`
+}
+
+func convertToMinimalTeam(team *github.Team) MinimalTeam {
+	return MinimalTeam{
+		ID:                  team.GetID(),
+		Slug:                team.GetSlug(),
+		Name:                team.GetName(),
+		Description:         team.GetDescription(),
+		Privacy:             team.GetPrivacy(),
+		NotificationSetting: team.GetNotificationSetting(),
+		Parent:              team.GetParent().GetSlug(),
+		HTMLURL:             team.GetHTMLURL(),
+	}
+}
+
+// MinimalWebhook is the trimmed output type for repository and organization webhooks.
+// The webhook secret is never included; HasSecret reports whether one is configured.
+type MinimalWebhook struct {
+	ID           int64          `json:"id"`
+	Name         string         `json:"name,omitempty"`
+	Active       bool           `json:"active"`
+	Events       []string       `json:"events,omitempty"`
+	URL          string         `json:"url,omitempty"`
+	ContentType  string         `json:"content_type,omitempty"`
+	InsecureSSL  string         `json:"insecure_ssl,omitempty"`
+	HasSecret    bool           `json:"has_secret"`
+	CreatedAt    string         `json:"created_at,omitempty"`
+	UpdatedAt    string         `json:"updated_at,omitempty"`
+	LastResponse map[string]any `json:"last_response,omitempty"`
+}
+
+// MinimalHookDelivery is the trimmed output type for webhook deliveries.
+type MinimalHookDelivery struct {
+	ID          int64   `json:"id"`
+	GUID        string  `json:"guid,omitempty"`
+	DeliveredAt string  `json:"delivered_at,omitempty"`
+	Redelivery  bool    `json:"redelivery"`
+	Duration    float64 `json:"duration,omitempty"`
+	Status      string  `json:"status,omitempty"`
+	StatusCode  int     `json:"status_code"`
+	Event       string  `json:"event,omitempty"`
+	Action      string  `json:"action,omitempty"`
+}
+
+func convertToMinimalWebhook(hook *github.Hook) MinimalWebhook {
+	minimalHook := MinimalWebhook{
+		ID:           hook.GetID(),
+		Name:         hook.GetName(),
+		Active:       hook.GetActive(),
+		Events:       hook.Events,
+		LastResponse: hook.LastResponse,
+	}
+	if config := hook.GetConfig(); config != nil {
+		minimalHook.URL = config.GetURL()
+		minimalHook.ContentType = config.GetContentType()
+		minimalHook.InsecureSSL = config.GetInsecureSSL()
+		minimalHook.HasSecret = config.GetSecret() != ""
+	}
+	if hook.CreatedAt != nil {
+		minimalHook.CreatedAt = hook.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if hook.UpdatedAt != nil {
+		minimalHook.UpdatedAt = hook.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalHook
+}
+
+func convertToMinimalHookDelivery(delivery *github.HookDelivery) MinimalHookDelivery {
+	minimalDelivery := MinimalHookDelivery{
+		ID:         delivery.GetID(),
+		GUID:       delivery.GetGUID(),
+		Redelivery: delivery.GetRedelivery(),
+		Status:     delivery.GetStatus(),
+		StatusCode: delivery.GetStatusCode(),
+		Event:      delivery.GetEvent(),
+		Action:     delivery.GetAction(),
+	}
+	if delivery.Duration != nil {
+		minimalDelivery.Duration = *delivery.Duration
+	}
+	if delivery.DeliveredAt != nil {
+		minimalDelivery.DeliveredAt = delivery.DeliveredAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalDelivery
+}
+
+// MinimalIssueTimelineEvent is the normalized output type for an event on an issue timeline.
+// Only the fields relevant to the event type are set.
+type MinimalIssueTimelineEvent struct {
+	ID        int64  `json:"id,omitempty"`
+	Event     string `json:"event"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// Body is the body of a comment or review. BodyHidden is set when it was removed by lockdown mode.
+	Body       string `json:"body,omitempty"`
+	BodyHidden bool   `json:"body_hidden,omitempty"`
+
+	Label     string                           `json:"label,omitempty"`
+	Assignee  string                           `json:"assignee,omitempty"`
+	Milestone string                           `json:"milestone,omitempty"`
+	Reviewer  string                           `json:"requested_reviewer,omitempty"`
+	Rename    *MinimalIssueTimelineRename      `json:"rename,omitempty"`
+	Source    *MinimalIssueTimelineEventSource `json:"source,omitempty"`
+	ClosedBy  *MinimalIssueTimelineEventSource `json:"closed_by,omitempty"`
+	CommitID  string                           `json:"commit_id,omitempty"`
+	State     string                           `json:"state,omitempty"`
+}
+
+// MinimalIssueTimelineRename is the title change of a renamed event. Hidden is set when the
+// titles were removed by lockdown mode.
+type MinimalIssueTimelineRename struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// MinimalIssueTimelineEventSource is the issue or pull request that cross-referenced an issue, or
+// the pull request that closed it. TitleHidden is set when the title was removed by lockdown mode.
+type MinimalIssueTimelineEventSource struct {
+	Repository    string `json:"repository,omitempty"`
+	Number        int    `json:"number"`
+	Title         string `json:"title,omitempty"`
+	TitleHidden   bool   `json:"title_hidden,omitempty"`
+	State         string `json:"state,omitempty"`
+	IsPullRequest bool   `json:"is_pull_request"`
+	Merged        bool   `json:"merged,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+func convertToMinimalIssueTimelineEvent(event *github.Timeline) MinimalIssueTimelineEvent {
+	minimalEvent := MinimalIssueTimelineEvent{
+		ID:       event.GetID(),
+		Event:    event.GetEvent(),
+		Actor:    event.GetActor().GetLogin(),
+		CommitID: event.GetCommitID(),
+	}
+	if minimalEvent.Actor == "" {
+		minimalEvent.Actor = event.GetUser().GetLogin()
+	}
+	if event.CreatedAt != nil {
+		minimalEvent.CreatedAt = event.CreatedAt.Format("2006-01-02T15:04:05Z")
+	} else if event.SubmittedAt != nil {
+		minimalEvent.CreatedAt = event.SubmittedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if event.Body != nil {
+		minimalEvent.Body = sanitize.Sanitize(event.GetBody())
+	}
+	if event.Label != nil {
+		minimalEvent.Label = event.Label.GetName()
+	}
+	if event.Assignee != nil {
+		minimalEvent.Assignee = event.Assignee.GetLogin()
+	}
+	if event.Milestone != nil {
+		minimalEvent.Milestone = event.Milestone.GetTitle()
+	}
+	if event.Reviewer != nil {
+		minimalEvent.Reviewer = event.Reviewer.GetLogin()
+	} else if event.RequestedTeam != nil {
+		minimalEvent.Reviewer = event.RequestedTeam.GetSlug()
+	}
+	if event.Rename != nil {
+		minimalEvent.Rename = &MinimalIssueTimelineRename{
+			From: sanitize.Sanitize(event.Rename.GetFrom()),
+			To:   sanitize.Sanitize(event.Rename.GetTo()),
+		}
+	}
+	if issue := event.GetSource().GetIssue(); issue != nil {
+		minimalEvent.Source = &MinimalIssueTimelineEventSource{
+			Repository:    issue.GetRepository().GetFullName(),
+			Number:        issue.GetNumber(),
+			Title:         sanitize.Sanitize(issue.GetTitle()),
+			State:         issue.GetState(),
+			IsPullRequest: issue.IsPullRequest(),
+			Merged:        issue.GetPullRequestLinks().GetMergedAt() != (github.Timestamp{}),
+			URL:           issue.GetHTMLURL(),
+		}
+	}
+	if event.GetEvent() == "committed" {
+		// Commits have no actor; their author is only known when GitHub matched it to a user.
+		if minimalEvent.Actor == "" {
+			minimalEvent.Actor = event.GetAuthor().GetLogin()
+		}
+		minimalEvent.CommitID = event.GetSHA()
+		minimalEvent.Body = sanitize.Sanitize(event.GetMessage())
+	}
+	minimalEvent.State = event.GetState()
+	return minimalEvent
+}
+
+// MinimalMilestone is the trimmed output type for milestones.
+type MinimalMilestone struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	State        string `json:"state"`
+	OpenIssues   int    `json:"open_issues"`
+	ClosedIssues int    `json:"closed_issues"`
+	DueOn        string `json:"due_on,omitempty"`
+	ClosedAt     string `json:"closed_at,omitempty"`
+	HTMLURL      string `json:"html_url,omitempty"`
+}
+
+func convertToMinimalMilestone(milestone *github.Milestone) MinimalMilestone {
+	minimalMilestone := MinimalMilestone{
+		Number:       milestone.GetNumber(),
+		Title:        milestone.GetTitle(),
+		Description:  milestone.GetDescription(),
+		State:        milestone.GetState(),
+		OpenIssues:   milestone.GetOpenIssues(),
+		ClosedIssues: milestone.GetClosedIssues(),
+		HTMLURL:      milestone.GetHTMLURL(),
+	}
+	if milestone.DueOn != nil {
+		minimalMilestone.DueOn = milestone.DueOn.Format("2006-01-02T15:04:05Z")
+	}
+	if milestone.ClosedAt != nil {
+		minimalMilestone.ClosedAt = milestone.ClosedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalMilestone
+}
+
+// MinimalComment is the trimmed output type for issue and pull request review comments.
+type MinimalComment struct {
+	ID        int64        `json:"id"`
+	NodeID    string       `json:"node_id"`
+	Body      string       `json:"body"`
+	User      *MinimalUser `json:"user,omitempty"`
+	HTMLURL   string       `json:"html_url"`
+	UpdatedAt string       `json:"updated_at,omitempty"`
+}
+
+func convertIssueCommentToMinimalComment(comment *github.IssueComment) MinimalComment {
+	minimalComment := MinimalComment{
+		ID:      comment.GetID(),
+		NodeID:  comment.GetNodeID(),
+		Body:    comment.GetBody(),
+		User:    convertToMinimalUser(comment.GetUser()),
+		HTMLURL: comment.GetHTMLURL(),
+	}
+	if comment.UpdatedAt != nil {
+		minimalComment.UpdatedAt = comment.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalComment
+}
+
+func convertPullRequestCommentToMinimalComment(comment *github.PullRequestComment) MinimalComment {
+	minimalComment := MinimalComment{
+		ID:      comment.GetID(),
+		NodeID:  comment.GetNodeID(),
+		Body:    comment.GetBody(),
+		User:    convertToMinimalUser(comment.GetUser()),
+		HTMLURL: comment.GetHTMLURL(),
+	}
+	if comment.UpdatedAt != nil {
+		minimalComment.UpdatedAt = comment.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalComment
+}
+
+// MinimalReaction is the trimmed output type for reactions.
+type MinimalReaction struct {
+	ID      int64        `json:"id"`
+	Content string       `json:"content"`
+	User    *MinimalUser `json:"user,omitempty"`
+}
+
+func convertToMinimalReaction(reaction *github.Reaction) MinimalReaction {
+	return MinimalReaction{
+		ID:      reaction.GetID(),
+		Content: reaction.GetContent(),
+		User:    convertToMinimalUser(reaction.GetUser()),
+	}
+}
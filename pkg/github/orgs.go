@@ -0,0 +1,819 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// OrgMember is an organization member with their role.
+type OrgMember struct {
+	Login string `json:"login"`
+	Name  string `json:"name,omitempty"`
+	Role  string `json:"role"`
+}
+
+// orgMembersQuery lists organization members together with their role.
+type orgMembersQuery struct {
+	Organization struct {
+		MembersWithRole struct {
+			Edges []struct {
+				Role githubv4.String
+				Node struct {
+					Login githubv4.String
+					Name  githubv4.String
+				}
+			}
+			PageInfo struct {
+				HasNextPage     githubv4.Boolean
+				HasPreviousPage githubv4.Boolean
+				StartCursor     githubv4.String
+				EndCursor       githubv4.String
+			}
+			TotalCount int
+		} `graphql:"membersWithRole(first: $first, after: $after)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// ListOrgMembers creates a tool to list the members of an organization with their role.
+func ListOrgMembers(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "list_org_members",
+			Description: t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of an organization with their role (ADMIN or MEMBER)."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithCursorPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+
+			vars := map[string]any{
+				"org":   githubv4.String(org),
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+
+			var q orgMembersQuery
+			if err := gqlClient.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list organization members", err), nil, nil
+			}
+
+			members := make([]OrgMember, 0, len(q.Organization.MembersWithRole.Edges))
+			for _, edge := range q.Organization.MembersWithRole.Edges {
+				members = append(members, OrgMember{
+					Login: string(edge.Node.Login),
+					Name:  string(edge.Node.Name),
+					Role:  string(edge.Role),
+				})
+			}
+
+			response := map[string]any{
+				"members": members,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Organization.MembersWithRole.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Organization.MembersWithRole.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Organization.MembersWithRole.PageInfo.StartCursor),
+					"endCursor":       string(q.Organization.MembersWithRole.PageInfo.EndCursor),
+				},
+				"totalCount": q.Organization.MembersWithRole.TotalCount,
+			}
+
+			result, err := utils.NewToolResultJSON(response)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// ListOrgOutsideCollaborators creates a tool to list the outside collaborators of an organization.
+func ListOrgOutsideCollaborators(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "list_org_outside_collaborators",
+			Description: t("TOOL_LIST_ORG_OUTSIDE_COLLABORATORS_DESCRIPTION", "List users who are collaborators on at least one repository of an organization but are not members of it."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ORG_OUTSIDE_COLLABORATORS_USER_TITLE", "List organization outside collaborators"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"filter": {
+						Type:        "string",
+						Description: "Filter outside collaborators: '2fa_disabled' for those without two-factor authentication, 'all' for everyone",
+						Enum:        []any{"2fa_disabled", "all"},
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			filter, err := OptionalParam[string](args, "filter")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			users, resp, err := client.Organizations.ListOutsideCollaborators(ctx, org, &github.ListOutsideCollaboratorsOptions{
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list outside collaborators", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalUsers := make([]MinimalUser, 0, len(users))
+			for _, user := range users {
+				minimalUsers = append(minimalUsers, *convertToMinimalUser(user))
+			}
+
+			result, err := utils.NewToolResultJSON(minimalUsers)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// OrgMembershipWrite creates a tool to invite, update and remove organization members.
+func OrgMembershipWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "org_membership_write",
+			Description: t("TOOL_ORG_MEMBERSHIP_WRITE_DESCRIPTION", "Invite a user to an organization, change the role of a member, or remove a member (or cancel their pending invitation). Requires organization owner permissions."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_ORG_MEMBERSHIP_WRITE_USER_TITLE", "Manage organization membership"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'invite': invite 'username' or 'email' to the organization.
+- 'set_role': change the role of an existing member of the organization.
+- 'remove': remove 'username' from the organization, or cancel their pending invitation.`,
+						Enum: []any{"invite", "set_role", "remove"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"username": {
+						Type:        "string",
+						Description: "GitHub username. Required for 'set_role' and 'remove'; for 'invite', provide either 'username' or 'email'.",
+					},
+					"email": {
+						Type:        "string",
+						Description: "Email address to invite. Only used for 'invite'.",
+					},
+					"role": {
+						Type:        "string",
+						Description: "Role for the member. For 'invite': direct_member (default), admin or billing_manager. For 'set_role': member or admin.",
+						Enum:        []any{"direct_member", "member", "admin", "billing_manager"},
+					},
+				},
+				Required: []string{"method", "org"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			username, err := OptionalParam[string](args, "username")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			email, err := OptionalParam[string](args, "email")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			role, err := OptionalParam[string](args, "role")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "invite":
+				if (username == "") == (email == "") {
+					return utils.NewToolResultError("exactly one of username or email is required for invite"), nil, nil
+				}
+				opts := &github.CreateOrgInvitationOptions{}
+				if role != "" {
+					opts.Role = github.Ptr(role)
+				}
+				if email != "" {
+					opts.Email = github.Ptr(email)
+				} else {
+					user, resp, err := client.Users.Get(ctx, username)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get user", resp, err), nil, nil
+					}
+					_ = resp.Body.Close()
+					opts.InviteeID = user.ID
+				}
+
+				invitation, resp, err := client.Organizations.CreateOrgInvitation(ctx, org, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to invite member", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(map[string]any{
+					"id":         invitation.GetID(),
+					"login":      invitation.GetLogin(),
+					"email":      invitation.GetEmail(),
+					"role":       invitation.GetRole(),
+					"created_at": invitation.GetCreatedAt(),
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+
+			case "set_role":
+				if username == "" {
+					return utils.NewToolResultError("missing required parameter: username"), nil, nil
+				}
+				if role != "member" && role != "admin" {
+					return utils.NewToolResultError("role must be 'member' or 'admin' for set_role"), nil, nil
+				}
+				membership, resp, err := client.Organizations.EditOrgMembership(ctx, username, org, &github.Membership{Role: github.Ptr(role)})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update membership", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("%s is now %s of %s (state: %s)", username, membership.GetRole(), org, membership.GetState())), nil, nil
+
+			case "remove":
+				if username == "" {
+					return utils.NewToolResultError("missing required parameter: username"), nil, nil
+				}
+				resp, err := client.Organizations.RemoveOrgMembership(ctx, username, org)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove member", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+					}
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to remove member", resp, body), nil, nil
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("%s removed from %s", username, org)), nil, nil
+
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: invite, set_role, remove", method)), nil, nil
+			}
+		},
+	)
+}
+
+// ListOrgTeams creates a tool to list the teams of an organization, or the child teams of a team.
+func ListOrgTeams(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "list_org_teams",
+			Description: t("TOOL_LIST_ORG_TEAMS_DESCRIPTION", "List the teams of an organization. When 'parent_team_slug' is provided, lists the child teams nested under that team."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ORG_TEAMS_USER_TITLE", "List organization teams"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"parent_team_slug": {
+						Type:        "string",
+						Description: "Only list the child teams of this team",
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parentSlug, err := OptionalParam[string](args, "parent_team_slug")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			listOptions := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var (
+				teams []*github.Team
+				resp  *github.Response
+			)
+			if parentSlug != "" {
+				teams, resp, err = client.Teams.ListChildTeamsByParentSlug(ctx, org, parentSlug, listOptions)
+			} else {
+				teams, resp, err = client.Teams.ListTeams(ctx, org, listOptions)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list teams", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalTeams := make([]MinimalTeam, 0, len(teams))
+			for _, team := range teams {
+				minimalTeams = append(minimalTeams, convertToMinimalTeam(team))
+			}
+
+			result, err := utils.NewToolResultJSON(minimalTeams)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// TeamWrite creates a tool to create, update and delete organization teams.
+func TeamWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "team_write",
+			Description: t("TOOL_TEAM_WRITE_DESCRIPTION", "Create, update or delete a team in an organization. Teams can be nested by setting a parent team."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_TEAM_WRITE_USER_TITLE", "Manage organization teams"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'create': create a team named 'name'.
+- 'update': update the team 'team_slug'. Only provided fields are changed.
+- 'delete': delete the team 'team_slug' and all of its child teams.`,
+						Enum: []any{"create", "update", "delete"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"team_slug": {
+						Type:        "string",
+						Description: "Team slug. Required for 'update' and 'delete'.",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Team name. Required for 'create'.",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Team description",
+					},
+					"privacy": {
+						Type:        "string",
+						Description: "Team visibility: 'secret' (only visible to owners and members) or 'closed' (visible to all organization members). Nested teams must be 'closed'.",
+						Enum:        []any{"secret", "closed"},
+					},
+					"notification_setting": {
+						Type:        "string",
+						Description: "Whether team members are notified when the team is mentioned",
+						Enum:        []any{"notifications_enabled", "notifications_disabled"},
+					},
+					"parent_team_slug": {
+						Type:        "string",
+						Description: "Slug of the team to nest this team under",
+					},
+					"remove_parent": {
+						Type:        "boolean",
+						Description: "For 'update', move the team back to the top level",
+					},
+					"maintainers": {
+						Type:        "array",
+						Description: "For 'create', usernames of the team maintainers",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"method", "org"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			teamSlug, err := OptionalParam[string](args, "team_slug")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			name, err := OptionalParam[string](args, "name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			description, hasDescription, err := OptionalParamOK[string](args, "description")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			privacy, err := OptionalParam[string](args, "privacy")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			notificationSetting, err := OptionalParam[string](args, "notification_setting")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parentSlug, err := OptionalParam[string](args, "parent_team_slug")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			removeParent, err := OptionalParam[bool](args, "remove_parent")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			maintainers, err := OptionalStringArrayParam(args, "maintainers")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			if method != "create" && teamSlug == "" {
+				return utils.NewToolResultError(fmt.Sprintf("team_slug is required for %s", method)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			if method == "delete" {
+				resp, err := client.Teams.DeleteTeamBySlug(ctx, org, teamSlug)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete team", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("team %s/%s deleted", org, teamSlug)), nil, nil
+			}
+			if method != "create" && method != "update" {
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: create, update, delete", method)), nil, nil
+			}
+
+			newTeam := github.NewTeam{Name: name}
+			if hasDescription {
+				newTeam.Description = github.Ptr(description)
+			}
+			if privacy != "" {
+				newTeam.Privacy = github.Ptr(privacy)
+			}
+			if notificationSetting != "" {
+				newTeam.NotificationSetting = github.Ptr(notificationSetting)
+			}
+			if parentSlug != "" {
+				parent, resp, err := client.Teams.GetTeamBySlug(ctx, org, parentSlug)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get parent team", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				newTeam.ParentTeamID = parent.ID
+			}
+
+			var (
+				team *github.Team
+				resp *github.Response
+			)
+			if method == "create" {
+				if name == "" {
+					return utils.NewToolResultError("name is required for create"), nil, nil
+				}
+				newTeam.Maintainers = maintainers
+				team, resp, err = client.Teams.CreateTeam(ctx, org, newTeam)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create team", resp, err), nil, nil
+				}
+			} else {
+				if newTeam.Name == "" {
+					// The team name is always sent on update, so keep the current one.
+					existing, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get team", resp, err), nil, nil
+					}
+					_ = resp.Body.Close()
+					newTeam.Name = existing.GetName()
+				}
+				team, resp, err = client.Teams.EditTeamBySlug(ctx, org, teamSlug, newTeam, removeParent)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update team", resp, err), nil, nil
+				}
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(convertToMinimalTeam(team))
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// TeamMembershipWrite creates a tool to add and remove team members and maintainers.
+func TeamMembershipWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "team_membership_write",
+			Description: t("TOOL_TEAM_MEMBERSHIP_WRITE_DESCRIPTION", "Add a user to a team as a member or maintainer (or change their team role), or remove a user from a team."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_TEAM_MEMBERSHIP_WRITE_USER_TITLE", "Manage team membership"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'add': add 'username' to the team with 'role', or change their role if they are already on the team.
+- 'remove': remove 'username' from the team.`,
+						Enum: []any{"add", "remove"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"team_slug": {
+						Type:        "string",
+						Description: "Team slug",
+					},
+					"username": {
+						Type:        "string",
+						Description: "GitHub username",
+					},
+					"role": {
+						Type:        "string",
+						Description: "Team role for 'add'. Defaults to member.",
+						Enum:        []any{"member", "maintainer"},
+					},
+				},
+				Required: []string{"method", "org", "team_slug", "username"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			teamSlug, err := RequiredParam[string](args, "team_slug")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			username, err := RequiredParam[string](args, "username")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			role, err := OptionalParam[string](args, "role")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "add":
+				if role == "" {
+					role = "member"
+				}
+				membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{Role: role})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add team member", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("%s is a %s of %s/%s (state: %s)", username, membership.GetRole(), org, teamSlug, membership.GetState())), nil, nil
+
+			case "remove":
+				resp, err := client.Teams.RemoveTeamMembershipBySlug(ctx, org, teamSlug, username)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove team member", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("%s removed from %s/%s", username, org, teamSlug)), nil, nil
+
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: add, remove", method)), nil, nil
+			}
+		},
+	)
+}
+
+// OrgCustomPropertiesRead creates a tool to read organization custom repository properties.
+func OrgCustomPropertiesRead(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "org_custom_properties_read",
+			Description: t("TOOL_ORG_CUSTOM_PROPERTIES_READ_DESCRIPTION", "Read the custom repository properties defined by an organization, or the values set on its repositories."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ORG_CUSTOM_PROPERTIES_READ_USER_TITLE", "Read organization custom properties"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The read operation to perform:
+- 'list_definitions': list the custom properties defined for the organization, with their type, allowed values and defaults. Pagination is not used.
+- 'list_values': list the custom property values set on the organization's repositories.`,
+						Enum: []any{"list_definitions", "list_values"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"repository_query": {
+						Type:        "string",
+						Description: "For 'list_values', a repository search query to limit the repositories returned, e.g. 'props.team:platform'",
+					},
+				},
+				Required: []string{"method", "org"},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repositoryQuery, err := OptionalParam[string](args, "repository_query")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var (
+				properties any
+				resp       *github.Response
+			)
+			switch method {
+			case "list_definitions":
+				properties, resp, err = client.Organizations.GetAllCustomProperties(ctx, org)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list custom properties", resp, err), nil, nil
+				}
+			case "list_values":
+				properties, resp, err = client.Organizations.ListCustomPropertyValues(ctx, org, &github.ListCustomPropertyValuesOptions{
+					RepositoryQuery: repositoryQuery,
+					ListOptions: github.ListOptions{
+						Page:    pagination.Page,
+						PerPage: pagination.PerPage,
+					},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list custom property values", resp, err), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: list_definitions, list_values", method)), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(properties)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
@@ -0,0 +1,766 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListOrgMembers(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "after")
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	vars := map[string]any{
+		"org":   githubv4.String("octo-org"),
+		"first": githubv4.Int(30),
+		"after": (*githubv4.String)(nil),
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"organization": map[string]any{
+			"membersWithRole": map[string]any{
+				"edges": []any{
+					map[string]any{"role": "ADMIN", "node": map[string]any{"login": "octocat", "name": "The Octocat"}},
+					map[string]any{"role": "MEMBER", "node": map[string]any{"login": "hubot", "name": nil}},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     true,
+					"hasPreviousPage": false,
+					"startCursor":     "start",
+					"endCursor":       "next",
+				},
+				"totalCount": 5,
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		gqlClient       *githubv4.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedMembers []OrgMember
+	}{
+		{
+			name:      "lists members with role",
+			gqlClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(orgMembersQuery{}, vars, response))),
+			expectedMembers: []OrgMember{
+				{Login: "octocat", Name: "The Octocat", Role: "ADMIN"},
+				{Login: "hubot", Role: "MEMBER"},
+			},
+		},
+		{
+			name: "query fails",
+			gqlClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(orgMembersQuery{}, vars,
+				githubv4mock.ErrorResponse("Could not resolve to an Organization with the login of 'octo-org'.")))),
+			expectError:    true,
+			expectedErrMsg: "failed to list organization members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				GQLClient: tc.gqlClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{"org": "octo-org"})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var got struct {
+				Members    []OrgMember `json:"members"`
+				TotalCount int         `json:"totalCount"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+			assert.Equal(t, tc.expectedMembers, got.Members)
+			assert.Equal(t, 5, got.TotalCount)
+			assert.True(t, got.PageInfo.HasNextPage)
+			assert.Equal(t, "next", got.PageInfo.EndCursor)
+		})
+	}
+}
+
+func Test_ListOrgOutsideCollaborators(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListOrgOutsideCollaborators(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_outside_collaborators", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	mockUsers := []*github.User{
+		{Login: github.Ptr("contractor"), ID: github.Ptr(int64(10)), HTMLURL: github.Ptr("https://github.com/contractor")},
+	}
+
+	client := github.NewClient(NewMockedHTTPClient(
+		WithRequestMatchHandler(
+			GetOrgsOutsideCollaboratorsByOrg,
+			expectQueryParams(t, map[string]string{
+				"filter":   "2fa_disabled",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, mockUsers),
+			),
+		),
+	))
+	deps := BaseDeps{
+		Client: client,
+	}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"org":    "octo-org",
+		"filter": "2fa_disabled",
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var users []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &users))
+	assert.Equal(t, []MinimalUser{{Login: "contractor", ID: 10, ProfileURL: "https://github.com/contractor"}}, users)
+}
+
+func Test_OrgMembershipWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := OrgMembershipWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "org_membership_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "org"})
+	assert.Equal(t, []string{"admin:org"}, serverTool.RequiredScopes)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedLogin  string
+	}{
+		{
+			name: "invite by username",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetUsersByUsername, &github.User{Login: github.Ptr("newhire"), ID: github.Ptr(int64(42))}),
+				WithRequestMatchHandler(
+					PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"invitee_id": float64(42),
+						"role":       "direct_member",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Invitation{
+							ID:    github.Ptr(int64(7)),
+							Login: github.Ptr("newhire"),
+							Role:  github.Ptr("direct_member"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":   "invite",
+				"org":      "octo-org",
+				"username": "newhire",
+				"role":     "direct_member",
+			},
+			expectedLogin: "newhire",
+		},
+		{
+			name: "invite by email",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"email": "newhire@example.com",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Invitation{
+							ID:    github.Ptr(int64(8)),
+							Email: github.Ptr("newhire@example.com"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method": "invite",
+				"org":    "octo-org",
+				"email":  "newhire@example.com",
+			},
+		},
+		{
+			name:         "invite requires exactly one invitee",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "invite",
+				"org":    "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of username or email is required for invite",
+		},
+		{
+			name: "set role",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutOrgsMembershipsByOrgByUsername,
+					expectRequestBody(t, map[string]any{
+						"role": "admin",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{Role: github.Ptr("admin"), State: github.Ptr("active")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":   "set_role",
+				"org":      "octo-org",
+				"username": "octocat",
+				"role":     "admin",
+			},
+			expectedText: "octocat is now admin of octo-org (state: active)",
+		},
+		{
+			name:         "set role rejects invitation roles",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":   "set_role",
+				"org":      "octo-org",
+				"username": "octocat",
+				"role":     "billing_manager",
+			},
+			expectError:    true,
+			expectedErrMsg: "role must be 'member' or 'admin' for set_role",
+		},
+		{
+			name: "remove member",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteOrgsMembershipsByOrgByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":   "remove",
+				"org":      "octo-org",
+				"username": "leaver",
+			},
+			expectedText: "leaver removed from octo-org",
+		},
+		{
+			name: "remove fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "You must be an admin"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":   "remove",
+				"org":      "octo-org",
+				"username": "leaver",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to remove member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var invitation map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &invitation))
+			assert.Equal(t, tc.expectedLogin, invitation["login"])
+		})
+	}
+}
+
+func Test_ListOrgTeams(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListOrgTeams(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_teams", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	mockTeams := []*github.Team{
+		{ID: github.Ptr(int64(1)), Slug: github.Ptr("platform"), Name: github.Ptr("Platform"), Privacy: github.Ptr("closed")},
+	}
+	mockChildTeams := []*github.Team{
+		{
+			ID:      github.Ptr(int64(2)),
+			Slug:    github.Ptr("platform-oncall"),
+			Name:    github.Ptr("Platform on-call"),
+			Privacy: github.Ptr("closed"),
+			Parent:  &github.Team{Slug: github.Ptr("platform")},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		mockedClient  *http.Client
+		requestArgs   map[string]any
+		expectedTeams []MinimalTeam
+	}{
+		{
+			name: "lists organization teams",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsTeamsByOrg, mockTeams),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectedTeams: []MinimalTeam{{ID: 1, Slug: "platform", Name: "Platform", Privacy: "closed"}},
+		},
+		{
+			name: "lists child teams",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsTeamsTeamsByOrgByTeamSlug, mockChildTeams),
+			),
+			requestArgs: map[string]any{
+				"org":              "octo-org",
+				"parent_team_slug": "platform",
+			},
+			expectedTeams: []MinimalTeam{{ID: 2, Slug: "platform-oncall", Name: "Platform on-call", Privacy: "closed", Parent: "platform"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var teams []MinimalTeam
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &teams))
+			assert.Equal(t, tc.expectedTeams, teams)
+		})
+	}
+}
+
+func Test_TeamWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := TeamWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "team_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, schema.Properties, "parent_team_slug")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "org"})
+
+	parentTeam := &github.Team{ID: github.Ptr(int64(1)), Slug: github.Ptr("platform"), Name: github.Ptr("Platform")}
+	childTeam := &github.Team{
+		ID:      github.Ptr(int64(2)),
+		Slug:    github.Ptr("platform-oncall"),
+		Name:    github.Ptr("Platform on-call"),
+		Privacy: github.Ptr("closed"),
+		Parent:  parentTeam,
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedTeam   MinimalTeam
+	}{
+		{
+			name: "create nested team",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsTeamsByOrgByTeamSlug, parentTeam),
+				WithRequestMatchHandler(
+					PostOrgsTeamsByOrg,
+					expectRequestBody(t, map[string]any{
+						"name":           "Platform on-call",
+						"privacy":        "closed",
+						"parent_team_id": float64(1),
+						"maintainers":    []any{"octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, childTeam),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "create",
+				"org":              "octo-org",
+				"name":             "Platform on-call",
+				"privacy":          "closed",
+				"parent_team_slug": "platform",
+				"maintainers":      []any{"octocat"},
+			},
+			expectedTeam: convertToMinimalTeam(childTeam),
+		},
+		{
+			name: "update keeps the existing name",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsTeamsByOrgByTeamSlug, childTeam),
+				WithRequestMatchHandler(
+					PatchOrgsTeamsByOrgByTeamSlug,
+					expectRequestBody(t, map[string]any{
+						"name":           "Platform on-call",
+						"description":    "Pager rotation",
+						"parent_team_id": nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, childTeam),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":        "update",
+				"org":           "octo-org",
+				"team_slug":     "platform-oncall",
+				"description":   "Pager rotation",
+				"remove_parent": true,
+			},
+			expectedTeam: convertToMinimalTeam(childTeam),
+		},
+		{
+			name: "delete team",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteOrgsTeamsByOrgByTeamSlug,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":    "delete",
+				"org":       "octo-org",
+				"team_slug": "platform-oncall",
+			},
+			expectedText: "team octo-org/platform-oncall deleted",
+		},
+		{
+			name:         "create requires name",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "create",
+				"org":    "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "name is required for create",
+		},
+		{
+			name:         "update requires team_slug",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "update",
+				"org":    "octo-org",
+				"name":   "Renamed",
+			},
+			expectError:    true,
+			expectedErrMsg: "team_slug is required for update",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var team MinimalTeam
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &team))
+			assert.Equal(t, tc.expectedTeam, team)
+		})
+	}
+}
+
+func Test_TeamMembershipWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := TeamMembershipWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "team_membership_write", tool.Name)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "add maintainer",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]any{
+						"role": "maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{Role: github.Ptr("maintainer"), State: github.Ptr("active")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":    "add",
+				"org":       "octo-org",
+				"team_slug": "platform",
+				"username":  "octocat",
+				"role":      "maintainer",
+			},
+			expectedText: "octocat is a maintainer of octo-org/platform (state: active)",
+		},
+		{
+			name: "remove member",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":    "remove",
+				"org":       "octo-org",
+				"team_slug": "platform",
+				"username":  "octocat",
+			},
+			expectedText: "octocat removed from octo-org/platform",
+		},
+		{
+			name: "add fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":    "add",
+				"org":       "octo-org",
+				"team_slug": "platform",
+				"username":  "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add team member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_OrgCustomPropertiesRead(t *testing.T) {
+	// Verify tool definition once
+	serverTool := OrgCustomPropertiesRead(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "org_custom_properties_read", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedJSON   string
+	}{
+		{
+			name: "list definitions",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsPropertiesSchemaByOrg, []*github.CustomProperty{
+					{
+						PropertyName:  github.Ptr("team"),
+						ValueType:     "single_select",
+						AllowedValues: []string{"platform", "web"},
+					},
+				}),
+			),
+			requestArgs: map[string]any{
+				"method": "list_definitions",
+				"org":    "octo-org",
+			},
+			expectedJSON: `[{"property_name":"team","value_type":"single_select","allowed_values":["platform","web"]}]`,
+		},
+		{
+			name: "list values",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetOrgsPropertiesValuesByOrg,
+					expectQueryParams(t, map[string]string{
+						"repository_query": "props.team:platform",
+						"page":             "1",
+						"per_page":         "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []map[string]any{
+							{
+								"repository_id":        1,
+								"repository_name":      "api",
+								"repository_full_name": "octo-org/api",
+								"properties":           []map[string]any{{"property_name": "team", "value": "platform"}},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":           "list_values",
+				"org":              "octo-org",
+				"repository_query": "props.team:platform",
+			},
+			expectedJSON: `[{"repository_id":1,"repository_name":"api","repository_full_name":"octo-org/api","properties":[{"property_name":"team","value":"platform"}]}]`,
+		},
+		{
+			name:         "unknown method",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "set_values",
+				"org":    "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown method: set_values",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.JSONEq(t, tc.expectedJSON, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -219,6 +219,39 @@ func OptionalStringArrayParam(args map[string]any, p string) ([]string, error) {
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns an empty slice
+// 2. If it is present, iterates the elements and checks each is a whole number
+func OptionalIntArrayParam(args map[string]any, p string) ([]int, error) {
+	// Check if the parameter is present in the request
+	if _, ok := args[p]; !ok {
+		return []int{}, nil
+	}
+
+	switch v := args[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []int:
+		return v, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok {
+				return []int{}, fmt.Errorf("parameter %s is not of type number, is %T", p, v)
+			}
+			if f != float64(int(f)) {
+				return []int{}, fmt.Errorf("parameter %s: element %d (%v) is not a whole number", p, i, f)
+			}
+			intSlice[i] = int(f)
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, args[p])
+	}
+}
+
 func convertStringSliceToBigIntSlice(s []string) ([]int64, error) {
 	int64Slice := make([]int64, len(s))
 	for i, str := range s {
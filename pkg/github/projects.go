@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"strings"
 
@@ -41,6 +42,18 @@ const (
 	projectsMethodAddProjectItem    = "add_project_item"
 	projectsMethodUpdateProjectItem = "update_project_item"
 	projectsMethodDeleteProjectItem = "delete_project_item"
+	projectsMethodCreateProject     = "create_project"
+	projectsMethodUpdateProject     = "update_project"
+	projectsMethodCreateField       = "create_field"
+	projectsMethodUpdateField       = "update_field"
+	projectsMethodCreateDraftIssue  = "create_draft_issue"
+	projectsMethodConvertDraftIssue = "convert_draft_issue"
+	projectsMethodArchiveItem       = "archive_item"
+	projectsMethodUnarchiveItem     = "unarchive_item"
+	projectsMethodLinkProject       = "link_project"
+	projectsMethodUnlinkProject     = "unlink_project"
+	projectsMethodSummarizeItems    = "summarize_project_items"
+	projectsMethodBulkUpdateItems   = "bulk_update_project_items"
 )
 
 func ListProjects(t translations.TranslationHelperFunc) inventory.ServerTool {
@@ -1025,6 +1038,7 @@ func ProjectsList(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Description: t("TOOL_PROJECTS_LIST_DESCRIPTION",
 				`Tools for listing GitHub Projects resources.
 Use this tool to list projects for a user or organization, or list project fields and items for a specific project.
+Use 'summarize_project_items' to count the items matching a project filter per value of one or more fields, like the columns of a board view.
 `),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_PROJECTS_LIST_USER_TITLE", "List GitHub Projects resources"),
@@ -1040,6 +1054,7 @@ Use this tool to list projects for a user or organization, or list project field
 							projectsMethodListProjects,
 							projectsMethodListProjectFields,
 							projectsMethodListProjectItems,
+							projectsMethodSummarizeItems,
 						},
 					},
 					"owner_type": {
@@ -1053,11 +1068,18 @@ Use this tool to list projects for a user or organization, or list project field
 					},
 					"project_number": {
 						Type:        "number",
-						Description: "The project's number. Required for 'list_project_fields' and 'list_project_items' methods.",
+						Description: "The project's number. Required for 'list_project_fields', 'list_project_items' and 'summarize_project_items' methods.",
 					},
 					"query": {
 						Type:        "string",
-						Description: `Filter/query string. For list_projects: filter by title text and state (e.g. "roadmap is:open"). For list_project_items: advanced filtering using GitHub's project filtering syntax.`,
+						Description: `Filter/query string. For list_projects: filter by title text and state (e.g. "roadmap is:open"). For list_project_items and summarize_project_items: advanced filtering using GitHub's project filtering syntax (e.g. 'status:"In Progress" assignee:@me iteration:@current'), evaluated by GitHub.`,
+					},
+					"group_by": {
+						Type:        "array",
+						Description: "Field IDs to count items by, e.g. the Status field for a board summary. Required for 'summarize_project_items' method.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
 					},
 					"fields": {
 						Type:        "array",
@@ -1133,6 +1155,18 @@ Use this tool to list projects for a user or organization, or list project field
 					}
 				}
 				return listProjectItems(ctx, client, args, owner, ownerType)
+			case projectsMethodSummarizeItems:
+				if ownerType == "" {
+					projectNumber, err := RequiredInt(args, "project_number")
+					if err != nil {
+						return utils.NewToolResultError(err.Error()), nil, nil
+					}
+					ownerType, err = detectOwnerType(ctx, client, owner, projectNumber)
+					if err != nil {
+						return utils.NewToolResultError(err.Error()), nil, nil
+					}
+				}
+				return summarizeProjectItems(ctx, client, args, owner, ownerType)
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -1264,73 +1298,99 @@ Use this tool to get details about individual projects, project fields, and proj
 
 // ProjectsWrite returns the tool and handler for modifying GitHub Projects resources.
 func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"method": {
+				Type: "string",
+				Description: `The method to execute.
+Options are:
+- add_project_item, update_project_item, delete_project_item - Add an issue or pull request to the project, set a field value on an item, or remove an item.
+- bulk_update_project_items - Apply 'updated_fields' to the items 'item_ids' or to every item matching the project filter 'query'. Use 'dry_run' first to preview which items a query selects.
+- create_project - Create a project owned by 'owner' with 'title', optionally linked to a repository or team. Does not use 'project_number'.
+- update_project - Change the title, short description, README or visibility of the project, or set 'closed' to close or reopen it.
+- create_field, update_field - Create a field, or rename a field and replace its single select options or iteration schedule.
+- create_draft_issue - Add a draft issue to the project.
+- convert_draft_issue - Convert the draft issue 'item_id' to an issue in 'target_owner'/'target_repo'.
+- archive_item, unarchive_item - Archive or restore the item 'item_id'.
+- link_project, unlink_project - Link the project to, or unlink it from, a repository ('target_owner' and 'target_repo') or a team ('team_slug').
+`,
+				Enum: []any{
+					projectsMethodAddProjectItem,
+					projectsMethodUpdateProjectItem,
+					projectsMethodBulkUpdateItems,
+					projectsMethodDeleteProjectItem,
+					projectsMethodCreateProject,
+					projectsMethodUpdateProject,
+					projectsMethodCreateField,
+					projectsMethodUpdateField,
+					projectsMethodCreateDraftIssue,
+					projectsMethodConvertDraftIssue,
+					projectsMethodArchiveItem,
+					projectsMethodUnarchiveItem,
+					projectsMethodLinkProject,
+					projectsMethodUnlinkProject,
+				},
+			},
+			"owner_type": {
+				Type:        "string",
+				Description: "Owner type (user or org). If not provided, will be automatically detected.",
+				Enum:        []any{"user", "org"},
+			},
+			"owner": {
+				Type:        "string",
+				Description: "The project owner (user or organization login). The name is not case sensitive.",
+			},
+			"project_number": {
+				Type:        "number",
+				Description: "The project's number. Required for every method except 'create_project'.",
+			},
+			"item_id": {
+				Type:        "number",
+				Description: "The project item ID. Required for 'update_project_item', 'delete_project_item', 'convert_draft_issue', 'archive_item' and 'unarchive_item' methods.",
+			},
+			"item_type": {
+				Type:        "string",
+				Description: "The item's type, either issue or pull_request. Required for 'add_project_item' method.",
+				Enum:        []any{"issue", "pull_request"},
+			},
+			"item_owner": {
+				Type:        "string",
+				Description: "The owner (user or organization) of the repository containing the issue or pull request. Required for 'add_project_item' method.",
+			},
+			"item_repo": {
+				Type:        "string",
+				Description: "The name of the repository containing the issue or pull request. Required for 'add_project_item' method.",
+			},
+			"issue_number": {
+				Type:        "number",
+				Description: "The issue number (use when item_type is 'issue' for 'add_project_item' method). Provide either issue_number or pull_request_number.",
+			},
+			"pull_request_number": {
+				Type:        "number",
+				Description: "The pull request number (use when item_type is 'pull_request' for 'add_project_item' method). Provide either issue_number or pull_request_number.",
+			},
+			"updated_field": {
+				Type:        "object",
+				Description: "Object consisting of the ID of the project field to update and the new value for the field. To clear the field, set value to null. Example: {\"id\": 123456, \"value\": \"New Value\"}. Required for 'update_project_item' method.",
+			},
+		},
+		Required: []string{"method", "owner"},
+	}
+	maps.Copy(schema.Properties, projectManagementProperties())
+	maps.Copy(schema.Properties, projectBulkProperties())
+
 	tool := NewTool(
 		ToolsetMetadataProjects,
 		mcp.Tool{
 			Name:        "projects_write",
-			Description: t("TOOL_PROJECTS_WRITE_DESCRIPTION", "Add, update, or delete project items in a GitHub Project."),
+			Description: t("TOOL_PROJECTS_WRITE_DESCRIPTION", "Manage GitHub Projects: create, update or close projects, create and edit fields, add, update (one at a time or in bulk), archive or delete project items, create draft issues and convert them to issues, and link projects to repositories and teams."),
 			Annotations: &mcp.ToolAnnotations{
-				Title:           t("TOOL_PROJECTS_WRITE_USER_TITLE", "Modify GitHub Project items"),
+				Title:           t("TOOL_PROJECTS_WRITE_USER_TITLE", "Modify GitHub Projects"),
 				ReadOnlyHint:    false,
 				DestructiveHint: jsonschema.Ptr(true),
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"method": {
-						Type:        "string",
-						Description: "The method to execute",
-						Enum: []any{
-							projectsMethodAddProjectItem,
-							projectsMethodUpdateProjectItem,
-							projectsMethodDeleteProjectItem,
-						},
-					},
-					"owner_type": {
-						Type:        "string",
-						Description: "Owner type (user or org). If not provided, will be automatically detected.",
-						Enum:        []any{"user", "org"},
-					},
-					"owner": {
-						Type:        "string",
-						Description: "The project owner (user or organization login). The name is not case sensitive.",
-					},
-					"project_number": {
-						Type:        "number",
-						Description: "The project's number.",
-					},
-					"item_id": {
-						Type:        "number",
-						Description: "The project item ID. Required for 'update_project_item' and 'delete_project_item' methods.",
-					},
-					"item_type": {
-						Type:        "string",
-						Description: "The item's type, either issue or pull_request. Required for 'add_project_item' method.",
-						Enum:        []any{"issue", "pull_request"},
-					},
-					"item_owner": {
-						Type:        "string",
-						Description: "The owner (user or organization) of the repository containing the issue or pull request. Required for 'add_project_item' method.",
-					},
-					"item_repo": {
-						Type:        "string",
-						Description: "The name of the repository containing the issue or pull request. Required for 'add_project_item' method.",
-					},
-					"issue_number": {
-						Type:        "number",
-						Description: "The issue number (use when item_type is 'issue' for 'add_project_item' method). Provide either issue_number or pull_request_number.",
-					},
-					"pull_request_number": {
-						Type:        "number",
-						Description: "The pull request number (use when item_type is 'pull_request' for 'add_project_item' method). Provide either issue_number or pull_request_number.",
-					},
-					"updated_field": {
-						Type:        "object",
-						Description: "Object consisting of the ID of the project field to update and the new value for the field. To clear the field, set value to null. Example: {\"id\": 123456, \"value\": \"New Value\"}. Required for 'update_project_item' method.",
-					},
-				},
-				Required: []string{"method", "owner", "project_number"},
-			},
+			InputSchema: schema,
 		},
 		[]scopes.Scope{scopes.Project},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1349,17 +1409,22 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			projectNumber, err := RequiredInt(args, "project_number")
+			client, err := deps.GetClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			client, err := deps.GetClient(ctx)
+			gqlClient, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			gqlClient, err := deps.GetGQLClient(ctx)
+			// A new project has no number to detect the owner type from
+			if method == projectsMethodCreateProject {
+				return createProject(ctx, gqlClient, args, owner, ownerType)
+			}
+
+			projectNumber, err := RequiredInt(args, "project_number")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
@@ -1418,12 +1483,32 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return utils.NewToolResultError("updated_field must be an object"), nil, nil
 				}
 				return updateProjectItem(ctx, client, owner, ownerType, projectNumber, itemID, fieldValue)
+			case projectsMethodBulkUpdateItems:
+				return bulkUpdateProjectItems(ctx, client, args, owner, ownerType, projectNumber)
 			case projectsMethodDeleteProjectItem:
 				itemID, err := RequiredBigInt(args, "item_id")
 				if err != nil {
 					return utils.NewToolResultError(err.Error()), nil, nil
 				}
 				return deleteProjectItem(ctx, client, owner, ownerType, projectNumber, itemID)
+			case projectsMethodUpdateProject:
+				return updateProject(ctx, gqlClient, args, owner, ownerType, projectNumber)
+			case projectsMethodCreateField:
+				return createProjectField(ctx, gqlClient, args, owner, ownerType, projectNumber)
+			case projectsMethodUpdateField:
+				return updateProjectField(ctx, client, gqlClient, args, owner, ownerType, projectNumber)
+			case projectsMethodCreateDraftIssue:
+				return createDraftIssue(ctx, gqlClient, args, owner, ownerType, projectNumber)
+			case projectsMethodConvertDraftIssue:
+				return convertDraftIssue(ctx, client, gqlClient, args, owner, ownerType, projectNumber)
+			case projectsMethodArchiveItem, projectsMethodUnarchiveItem:
+				itemID, err := RequiredBigInt(args, "item_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				return setProjectItemArchived(ctx, client, gqlClient, owner, ownerType, projectNumber, itemID, method == projectsMethodArchiveItem)
+			case projectsMethodLinkProject, projectsMethodUnlinkProject:
+				return setProjectLink(ctx, gqlClient, args, owner, ownerType, projectNumber, method == projectsMethodLinkProject)
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -1875,41 +1960,9 @@ func addProjectItem(ctx context.Context, gqlClient *githubv4.Client, owner, owne
 		} `graphql:"addProjectV2ItemById(input: $input)"`
 	}
 
-	// First, get the project ID
-	var projectIDQuery struct {
-		User struct {
-			ProjectV2 struct {
-				ID githubv4.ID
-			} `graphql:"projectV2(number: $projectNumber)"`
-		} `graphql:"user(login: $owner)"`
-	}
-	var projectIDQueryOrg struct {
-		Organization struct {
-			ProjectV2 struct {
-				ID githubv4.ID
-			} `graphql:"projectV2(number: $projectNumber)"`
-		} `graphql:"organization(login: $owner)"`
-	}
-
-	var projectID githubv4.ID
-	if ownerType == "org" {
-		err = gqlClient.Query(ctx, &projectIDQueryOrg, map[string]any{
-			"owner":         githubv4.String(owner),
-			"projectNumber": githubv4.Int(int32(projectNumber)), //nolint:gosec // Project numbers are small integers
-		})
-		if err != nil {
-			return utils.NewToolResultError(fmt.Sprintf("failed to get project ID: %v", err)), nil, nil
-		}
-		projectID = projectIDQueryOrg.Organization.ProjectV2.ID
-	} else {
-		err = gqlClient.Query(ctx, &projectIDQuery, map[string]any{
-			"owner":         githubv4.String(owner),
-			"projectNumber": githubv4.Int(int32(projectNumber)), //nolint:gosec // Project numbers are small integers
-		})
-		if err != nil {
-			return utils.NewToolResultError(fmt.Sprintf("failed to get project ID: %v", err)), nil, nil
-		}
-		projectID = projectIDQuery.User.ProjectV2.ID
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 
 	// Add the item to the project
@@ -2049,6 +2102,40 @@ func extractPaginationOptionsFromArgs(args map[string]any) (github.ListProjectsP
 	return opts, nil
 }
 
+// resolveProjectNodeID resolves a project number to its GraphQL node ID
+func resolveProjectNodeID(ctx context.Context, gqlClient *githubv4.Client, owner, ownerType string, projectNumber int) (githubv4.ID, error) {
+	variables := map[string]any{
+		"owner":         githubv4.String(owner),
+		"projectNumber": githubv4.Int(int32(projectNumber)), //nolint:gosec // Project numbers are small integers
+	}
+
+	if ownerType == "org" {
+		var query struct {
+			Organization struct {
+				ProjectV2 struct {
+					ID githubv4.ID
+				} `graphql:"projectV2(number: $projectNumber)"`
+			} `graphql:"organization(login: $owner)"`
+		}
+		if err := gqlClient.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to get project ID: %w", err)
+		}
+		return query.Organization.ProjectV2.ID, nil
+	}
+
+	var query struct {
+		User struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			} `graphql:"projectV2(number: $projectNumber)"`
+		} `graphql:"user(login: $owner)"`
+	}
+	if err := gqlClient.Query(ctx, &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get project ID: %w", err)
+	}
+	return query.User.ProjectV2.ID, nil
+}
+
 // resolveIssueNodeID resolves an issue number to its GraphQL node ID
 func resolveIssueNodeID(ctx context.Context, gqlClient *githubv4.Client, owner, repo string, issueNumber int) (githubv4.ID, error) {
 	var query struct {
@@ -0,0 +1,398 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxBulkProjectItems is the maximum number of items a single bulk_update_project_items call
+	// can change.
+	maxBulkProjectItems = 100
+	// bulkProjectItemConcurrency is the number of items that are changed at the same time.
+	bulkProjectItemConcurrency = 4
+	// maxSummarizedProjectItems is the maximum number of items summarize_project_items counts.
+	maxSummarizedProjectItems = 1000
+)
+
+// BulkProjectItemResult is the outcome of changing one item of a bulk operation.
+type BulkProjectItemResult struct {
+	ItemID      int64  `json:"item_id"`
+	ContentType string `json:"content_type,omitempty"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BulkProjectItemsResult is the output of a bulk update of project items.
+type BulkProjectItemsResult struct {
+	DryRun    bool                    `json:"dry_run"`
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Skipped   int                     `json:"skipped"`
+	Results   []BulkProjectItemResult `json:"results"`
+}
+
+// ProjectItemGroup is the number of items with one value of a field.
+type ProjectItemGroup struct {
+	Value string `json:"value"`
+	// Empty reports that the group holds the items without a value for the field.
+	Empty bool `json:"empty,omitempty"`
+	Count int  `json:"count"`
+}
+
+// ProjectFieldSummary is the number of items per value of a field. Items with several values,
+// such as assignees or labels, are counted once for every value.
+type ProjectFieldSummary struct {
+	FieldID   int64              `json:"field_id"`
+	FieldName string             `json:"field_name,omitempty"`
+	Groups    []ProjectItemGroup `json:"groups"`
+}
+
+// ProjectItemsSummary is the output of summarize_project_items.
+type ProjectItemsSummary struct {
+	Query string `json:"query,omitempty"`
+	Total int    `json:"total"`
+	// Truncated reports that more items match the query than were counted.
+	Truncated bool                  `json:"truncated"`
+	Fields    []ProjectFieldSummary `json:"fields"`
+}
+
+// projectBulkProperties returns the schema properties of projects_write that are only used to
+// update items in bulk.
+func projectBulkProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"item_ids": {
+			Type:        "array",
+			Description: fmt.Sprintf("IDs of the project items to change, up to %d. Either 'item_ids' or 'query' is required for 'bulk_update_project_items'.", maxBulkProjectItems),
+			Items: &jsonschema.Schema{
+				Type: "number",
+			},
+		},
+		"query": {
+			Type:        "string",
+			Description: `Filter selecting the items to change for 'bulk_update_project_items', using the project filter syntax, e.g. 'status:"In Progress" assignee:@me iteration:@current'.`,
+		},
+		"updated_fields": {
+			Type:        "array",
+			Description: "Field changes applied to every item for 'bulk_update_project_items'. Each entry has the ID of the project field and the new value, or null to clear the field. Example: [{\"id\": 123456, \"value\": \"option_id\"}].",
+			Items: &jsonschema.Schema{
+				Type: "object",
+			},
+		},
+		"dry_run": {
+			Type:        "boolean",
+			Description: "Only list the items 'bulk_update_project_items' would change, without changing anything.",
+		},
+	}
+}
+
+// bulkUpdateProjectItems applies the same field changes to the items listed in item_ids or
+// matching query.
+func bulkUpdateProjectItems(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	itemIDs, err := OptionalIntArrayParam(args, "item_ids")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	query, err := OptionalParam[string](args, "query")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	if (len(itemIDs) == 0) == (query == "") {
+		return utils.NewToolResultError("exactly one of item_ids or query is required"), nil, nil
+	}
+	if len(itemIDs) > maxBulkProjectItems {
+		return utils.NewToolResultError(fmt.Sprintf("at most %d items can be changed in one call, got %d", maxBulkProjectItems, len(itemIDs))), nil, nil
+	}
+	fields, err := buildBulkProjectItemFields(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	dryRun, err := OptionalParam[bool](args, "dry_run")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	var items []*github.ProjectV2Item
+	if query != "" {
+		var truncated bool
+		var resp *github.Response
+		items, truncated, resp, err = listAllProjectItems(ctx, client, owner, ownerType, projectNumber, query, nil, maxBulkProjectItems)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, ProjectListFailedError, resp, err), nil, nil
+		}
+		if truncated {
+			return utils.NewToolResultError(fmt.Sprintf("query matches more than %d items, but at most %d can be changed in one call. Narrow the query and repeat the call for the rest", maxBulkProjectItems, maxBulkProjectItems)), nil, nil
+		}
+	} else {
+		for _, id := range itemIDs {
+			items = append(items, &github.ProjectV2Item{ID: github.Ptr(int64(id))})
+		}
+	}
+
+	output := runBulkProjectItemUpdate(ctx, client, owner, ownerType, projectNumber, items, fields, dryRun)
+	result, err := utils.NewToolResultJSON(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// buildBulkProjectItemFields reads and validates the updated_fields parameter.
+func buildBulkProjectItemFields(args map[string]any) (*github.UpdateProjectItemOptions, error) {
+	raw, ok := args["updated_fields"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: updated_fields")
+	}
+	entries, ok := raw.([]any)
+	if !ok || len(entries) == 0 {
+		return nil, fmt.Errorf("updated_fields must be a non-empty array")
+	}
+
+	payload := &github.UpdateProjectItemOptions{}
+	seen := make(map[int64]bool)
+	for i, entry := range entries {
+		field, ok := entry.(map[string]any)
+		if !ok || field == nil {
+			return nil, fmt.Errorf("updated_fields[%d] must be an object", i)
+		}
+		idField, ok := field["id"]
+		if !ok {
+			return nil, fmt.Errorf("updated_fields[%d].id is required", i)
+		}
+		fieldID, err := validateAndConvertToInt64(idField)
+		if err != nil {
+			return nil, fmt.Errorf("updated_fields[%d].id: %w", i, err)
+		}
+		value, ok := field["value"]
+		if !ok {
+			return nil, fmt.Errorf("updated_fields[%d].value is required", i)
+		}
+		if seen[fieldID] {
+			return nil, fmt.Errorf("updated_fields contains field %d more than once", fieldID)
+		}
+		seen[fieldID] = true
+		payload.Fields = append(payload.Fields, &github.UpdateProjectV2Field{ID: fieldID, Value: value})
+	}
+	return payload, nil
+}
+
+// runBulkProjectItemUpdate applies fields to items with bounded concurrency and returns the
+// outcome for each item in the order of items.
+func runBulkProjectItemUpdate(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, items []*github.ProjectV2Item, fields *github.UpdateProjectItemOptions, dryRun bool) BulkProjectItemsResult {
+	output := BulkProjectItemsResult{
+		DryRun:  dryRun,
+		Total:   len(items),
+		Results: make([]BulkProjectItemResult, len(items)),
+	}
+	for i, item := range items {
+		output.Results[i] = BulkProjectItemResult{
+			ItemID:      item.GetID(),
+			ContentType: item.GetContentType(),
+			Status:      bulkStatusPlanned,
+		}
+	}
+	if dryRun {
+		return output
+	}
+
+	run := runBulk(len(items), bulkProjectItemConcurrency, func(i int, checkRateLimit func(*github.Response, error)) error {
+		var resp *github.Response
+		var err error
+		if ownerType == "org" {
+			_, resp, err = client.Projects.UpdateOrganizationProjectItem(ctx, owner, projectNumber, output.Results[i].ItemID, fields)
+		} else {
+			_, resp, err = client.Projects.UpdateUserProjectItem(ctx, owner, projectNumber, output.Results[i].ItemID, fields)
+		}
+		checkRateLimit(resp, err)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", ProjectUpdateFailedError, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: unexpected status %d", ProjectUpdateFailedError, resp.StatusCode)
+		}
+		return nil
+	})
+	for i, outcome := range run.Outcomes {
+		output.Results[i].Status = outcome.Status
+		output.Results[i].Error = outcome.Error
+	}
+	output.Succeeded, output.Failed, output.Skipped = run.Succeeded, run.Failed, run.Skipped
+	return output
+}
+
+// summarizeProjectItems counts the items matching query per value of each group_by field, the
+// way a board view groups its columns. The filter is evaluated by GitHub, so qualifiers such as
+// @me and @current resolve as they do in project views.
+func summarizeProjectItems(ctx context.Context, client *github.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
+	projectNumber, err := RequiredInt(args, "project_number")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	query, err := OptionalParam[string](args, "query")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	groupBy, err := OptionalBigIntArrayParam(args, "group_by")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	if len(groupBy) == 0 {
+		return utils.NewToolResultError("missing required parameter: group_by"), nil, nil
+	}
+
+	items, truncated, resp, err := listAllProjectItems(ctx, client, owner, ownerType, projectNumber, query, groupBy, maxSummarizedProjectItems)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, ProjectListFailedError, resp, err), nil, nil
+	}
+
+	summary := ProjectItemsSummary{
+		Query:     query,
+		Total:     len(items),
+		Truncated: truncated,
+		Fields:    make([]ProjectFieldSummary, 0, len(groupBy)),
+	}
+	for _, fieldID := range groupBy {
+		summary.Fields = append(summary.Fields, summarizeProjectField(items, fieldID))
+	}
+
+	result, err := utils.NewToolResultJSON(summary)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// summarizeProjectField counts items per value of the field fieldID. Groups are ordered by
+// count, largest first, with the items without a value last.
+func summarizeProjectField(items []*github.ProjectV2Item, fieldID int64) ProjectFieldSummary {
+	summary := ProjectFieldSummary{FieldID: fieldID, Groups: []ProjectItemGroup{}}
+	counts := make(map[string]int)
+	empty := 0
+	for _, item := range items {
+		var values []string
+		for _, field := range item.Fields {
+			if field.GetID() != fieldID {
+				continue
+			}
+			if summary.FieldName == "" {
+				summary.FieldName = field.Name
+			}
+			values = projectFieldValueKeys(field.Value)
+			break
+		}
+		if len(values) == 0 {
+			empty++
+			continue
+		}
+		for _, value := range values {
+			counts[value]++
+		}
+	}
+
+	for value, count := range counts {
+		summary.Groups = append(summary.Groups, ProjectItemGroup{Value: sanitize.Sanitize(value), Count: count})
+	}
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		if summary.Groups[i].Count != summary.Groups[j].Count {
+			return summary.Groups[i].Count > summary.Groups[j].Count
+		}
+		return summary.Groups[i].Value < summary.Groups[j].Value
+	})
+	if empty > 0 {
+		summary.Groups = append(summary.Groups, ProjectItemGroup{Empty: true, Count: empty})
+	}
+	return summary
+}
+
+// projectFieldValueKeys returns the values an item is grouped by for a field value, as
+// returned by the REST API: one per element for assignees and labels, and the display text
+// of the option, iteration, milestone or repository for object values.
+func projectFieldValueKeys(value any) []string {
+	if values, ok := value.([]any); ok {
+		var keys []string
+		for _, v := range values {
+			if key := projectFieldValueText(v); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+	if key := projectFieldValueText(value); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+func projectFieldValueText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any:
+		for _, key := range []string{"raw", "text", "name", "title", "login", "full_name"} {
+			if text := projectFieldValueText(v[key]); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// listAllProjectItems pages through the items of a project matching query, with the values of
+// fields, and stops after limit items. It reports whether more items match.
+func listAllProjectItems(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, query string, fields []int64, limit int) ([]*github.ProjectV2Item, bool, *github.Response, error) {
+	perPage := MaxProjectsPerPage
+	opts := &github.ListProjectItemsOptions{
+		Fields: fields,
+		ListProjectsOptions: github.ListProjectsOptions{
+			ListProjectsPaginationOptions: github.ListProjectsPaginationOptions{PerPage: &perPage},
+		},
+	}
+	if query != "" {
+		opts.Query = &query
+	}
+
+	var items []*github.ProjectV2Item
+	for {
+		var page []*github.ProjectV2Item
+		var resp *github.Response
+		var err error
+		if ownerType == "org" {
+			page, resp, err = client.Projects.ListOrganizationProjectItems(ctx, owner, projectNumber, opts)
+		} else {
+			page, resp, err = client.Projects.ListUserProjectItems(ctx, owner, projectNumber, opts)
+		}
+		if err != nil {
+			return nil, false, resp, err
+		}
+		_ = resp.Body.Close()
+
+		items = append(items, page...)
+		if len(items) > limit {
+			return items[:limit], true, resp, nil
+		}
+		if resp.After == "" || len(page) == 0 {
+			return items, false, resp, nil
+		}
+		after := resp.After
+		opts.After = &after
+	}
+}
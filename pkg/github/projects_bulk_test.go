@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_projectFieldValueKeys(t *testing.T) {
+	assert.Equal(t, []string{"In Progress"}, projectFieldValueKeys(map[string]any{
+		"id":   "47fc9ee4",
+		"name": map[string]any{"raw": "In Progress", "html": "In Progress"},
+	}))
+	assert.Equal(t, []string{"Sprint 3"}, projectFieldValueKeys(map[string]any{
+		"title":      map[string]any{"raw": "Sprint 3"},
+		"start_date": "2025-01-06",
+	}))
+	assert.Equal(t, []string{"octocat", "hubot"}, projectFieldValueKeys([]any{
+		map[string]any{"login": "octocat"},
+		map[string]any{"login": "hubot"},
+	}))
+	assert.Equal(t, []string{"3.5"}, projectFieldValueKeys(3.5))
+	assert.Nil(t, projectFieldValueKeys(nil))
+	assert.Nil(t, projectFieldValueKeys([]any{}))
+}
+
+func Test_ProjectsList_SummarizeProjectItems(t *testing.T) {
+	toolDef := ProjectsList(translations.NullTranslationHelper)
+
+	status := func(name string) *github.ProjectV2ItemFieldValue {
+		field := &github.ProjectV2ItemFieldValue{ID: github.Ptr(int64(101)), Name: "Status", DataType: "single_select"}
+		if name != "" {
+			field.Value = map[string]any{"name": map[string]any{"raw": name, "html": name}}
+		}
+		return field
+	}
+	assignees := func(logins ...string) *github.ProjectV2ItemFieldValue {
+		value := make([]any, 0, len(logins))
+		for _, login := range logins {
+			value = append(value, map[string]any{"login": login})
+		}
+		return &github.ProjectV2ItemFieldValue{ID: github.Ptr(int64(102)), Name: "Assignees", DataType: "assignees", Value: value}
+	}
+	pages := [][]*github.ProjectV2Item{
+		{
+			{ID: github.Ptr(int64(1)), Fields: []*github.ProjectV2ItemFieldValue{status("In Progress"), assignees("octocat")}},
+			{ID: github.Ptr(int64(2)), Fields: []*github.ProjectV2ItemFieldValue{status("Done"), assignees("octocat", "hubot")}},
+		},
+		{
+			{ID: github.Ptr(int64(3)), Fields: []*github.ProjectV2ItemFieldValue{status("In Progress"), assignees()}},
+			{ID: github.Ptr(int64(4)), Fields: []*github.ProjectV2ItemFieldValue{status("")}},
+		},
+	}
+
+	var mu sync.Mutex
+	var queries []string
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetOrgsProjectsV2ItemsByProject: func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			queries = append(queries, r.URL.RawQuery)
+			mu.Unlock()
+			if r.URL.Query().Get("after") == "" {
+				w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/projectsV2/1/items?after=cursor2>; rel="next"`)
+				mockResponse(t, http.StatusOK, pages[0])(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, pages[1])(w, r)
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	t.Run("counts items per field value", func(t *testing.T) {
+		request := createMCPRequest(map[string]any{
+			"method":         "summarize_project_items",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+			"query":          "iteration:@current",
+			"group_by":       []any{"101", "102"},
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var summary ProjectItemsSummary
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+		assert.Equal(t, 4, summary.Total)
+		assert.False(t, summary.Truncated)
+		require.Len(t, summary.Fields, 2)
+
+		assert.Equal(t, "Status", summary.Fields[0].FieldName)
+		assert.Equal(t, []ProjectItemGroup{
+			{Value: "In Progress", Count: 2},
+			{Value: "Done", Count: 1},
+			{Empty: true, Count: 1},
+		}, summary.Fields[0].Groups)
+
+		assert.Equal(t, []ProjectItemGroup{
+			{Value: "octocat", Count: 2},
+			{Value: "hubot", Count: 1},
+			{Empty: true, Count: 2},
+		}, summary.Fields[1].Groups)
+
+		require.Len(t, queries, 2)
+		assert.Contains(t, queries[0], "q=iteration%3A%40current")
+		assert.Contains(t, queries[0], "fields=101%2C102")
+	})
+
+	t.Run("requires group_by", func(t *testing.T) {
+		request := createMCPRequest(map[string]any{
+			"method":         "summarize_project_items",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "missing required parameter: group_by")
+	})
+}
+
+func Test_ProjectsWrite_BulkUpdateProjectItems(t *testing.T) {
+	toolDef := ProjectsWrite(translations.NullTranslationHelper)
+
+	updatedFields := []any{
+		map[string]any{"id": float64(101), "value": "47fc9ee4"},
+		map[string]any{"id": float64(103), "value": nil},
+	}
+
+	t.Run("updates items matching a query", func(t *testing.T) {
+		var mu sync.Mutex
+		var bodies []string
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetOrgsProjectsV2ItemsByProject: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, `status:"Todo"`, r.URL.Query().Get("q"))
+				mockResponse(t, http.StatusOK, []*github.ProjectV2Item{
+					{ID: github.Ptr(int64(1001)), ContentType: github.Ptr("Issue")},
+					{ID: github.Ptr(int64(1002)), ContentType: github.Ptr("DraftIssue")},
+				})(w, r)
+			},
+			PatchOrgsProjectsV2ItemsByProjectByItemID: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				bodies = append(bodies, string(body))
+				mu.Unlock()
+				if strings.HasSuffix(r.URL.Path, "/1002") {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, map[string]any{"id": 1001})(w, r)
+			},
+		})
+		deps := BaseDeps{Client: github.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":         "bulk_update_project_items",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+			"query":          `status:"Todo"`,
+			"updated_fields": updatedFields,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response BulkProjectItemsResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, 2, response.Total)
+		assert.Equal(t, 1, response.Succeeded)
+		assert.Equal(t, 1, response.Failed)
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, int64(1001), response.Results[0].ItemID)
+		assert.Equal(t, "updated", response.Results[0].Status)
+		assert.Equal(t, "failed", response.Results[1].Status)
+		assert.Contains(t, response.Results[1].Error, ProjectUpdateFailedError)
+
+		require.Len(t, bodies, 2)
+		assert.JSONEq(t, `{"fields":[{"id":101,"value":"47fc9ee4"},{"id":103,"value":null}]}`, bodies[0])
+	})
+
+	t.Run("query matching exactly the maximum number of items", func(t *testing.T) {
+		page := func(firstID int64) []*github.ProjectV2Item {
+			items := make([]*github.ProjectV2Item, MaxProjectsPerPage)
+			for i := range items {
+				items[i] = &github.ProjectV2Item{ID: github.Ptr(firstID + int64(i))}
+			}
+			return items
+		}
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetOrgsProjectsV2ItemsByProject: func(w http.ResponseWriter, r *http.Request) {
+				// The last page of items still links to an empty page.
+				switch r.URL.Query().Get("after") {
+				case "":
+					w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/projectsV2/1/items?after=cursor2>; rel="next"`)
+					mockResponse(t, http.StatusOK, page(1))(w, r)
+				case "cursor2":
+					w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/projectsV2/1/items?after=cursor3>; rel="next"`)
+					mockResponse(t, http.StatusOK, page(1+MaxProjectsPerPage))(w, r)
+				default:
+					mockResponse(t, http.StatusOK, []*github.ProjectV2Item{})(w, r)
+				}
+			},
+		})
+		deps := BaseDeps{Client: github.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":         "bulk_update_project_items",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+			"query":          `status:"Todo"`,
+			"updated_fields": updatedFields,
+			"dry_run":        true,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response BulkProjectItemsResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, maxBulkProjectItems, response.Total)
+	})
+
+	t.Run("dry run lists items without changing them", func(t *testing.T) {
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			PatchOrgsProjectsV2ItemsByProjectByItemID: func(w http.ResponseWriter, _ *http.Request) {
+				t.Error("item should not be changed")
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		})
+		deps := BaseDeps{Client: github.NewClient(mockedClient)}
+		handler := toolDef.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":         "bulk_update_project_items",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+			"item_ids":       []any{float64(1001), float64(1002)},
+			"updated_fields": updatedFields,
+			"dry_run":        true,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response BulkProjectItemsResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.True(t, response.DryRun)
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, "planned", response.Results[1].Status)
+	})
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "neither item_ids nor query",
+			args:        map[string]any{"updated_fields": updatedFields},
+			expectedErr: "exactly one of item_ids or query is required",
+		},
+		{
+			name:        "missing updated_fields",
+			args:        map[string]any{"item_ids": []any{float64(1001)}},
+			expectedErr: "missing required parameter: updated_fields",
+		},
+		{
+			name: "field without value",
+			args: map[string]any{
+				"item_ids":       []any{float64(1001)},
+				"updated_fields": []any{map[string]any{"id": float64(101)}},
+			},
+			expectedErr: "updated_fields[0].value is required",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+			handler := toolDef.Handler(deps)
+
+			args := map[string]any{
+				"method":         "bulk_update_project_items",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+			}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,1027 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// Project field data types that can be created with projects_write.
+var projectFieldDataTypes = []any{"TEXT", "NUMBER", "DATE", "SINGLE_SELECT", "ITERATION"}
+
+// Colors of single select field options.
+var projectFieldOptionColors = []any{"GRAY", "BLUE", "GREEN", "YELLOW", "ORANGE", "RED", "PINK", "PURPLE"}
+
+// CreateProjectV2FieldInput represents the input for creating a project field via the GraphQL API.
+// Used to extend the functionality of the githubv4 library to support iteration fields.
+type CreateProjectV2FieldInput struct {
+	ProjectID              githubv4.ID                                `json:"projectId"`
+	DataType               githubv4.ProjectV2CustomFieldType          `json:"dataType"`
+	Name                   githubv4.String                            `json:"name"`
+	SingleSelectOptions    *[]ProjectV2SingleSelectFieldOptionInput   `json:"singleSelectOptions,omitempty"`
+	IterationConfiguration *ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration,omitempty"`
+}
+
+// UpdateProjectV2FieldInput represents the input for updating a project field via the GraphQL API.
+// Used to extend the functionality of the githubv4 library, which lacks the updateProjectV2Field mutation.
+type UpdateProjectV2FieldInput struct {
+	FieldID                githubv4.ID                                `json:"fieldId"`
+	Name                   *githubv4.String                           `json:"name,omitempty"`
+	SingleSelectOptions    *[]ProjectV2SingleSelectFieldOptionInput   `json:"singleSelectOptions,omitempty"`
+	IterationConfiguration *ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration,omitempty"`
+}
+
+// ProjectV2SingleSelectFieldOptionInput represents an option of a single select field. Options
+// passed with the ID of an existing option keep their values on project items when the options
+// of a field are replaced.
+type ProjectV2SingleSelectFieldOptionInput struct {
+	ID          *githubv4.String                               `json:"id,omitempty"`
+	Name        githubv4.String                                `json:"name"`
+	Color       githubv4.ProjectV2SingleSelectFieldOptionColor `json:"color"`
+	Description githubv4.String                                `json:"description"`
+}
+
+// ProjectV2IterationFieldConfigurationInput represents the iteration schedule of an iteration field.
+type ProjectV2IterationFieldConfigurationInput struct {
+	StartDate  githubv4.String           `json:"startDate"`
+	Duration   githubv4.Int              `json:"duration"`
+	Iterations []ProjectV2IterationInput `json:"iterations"`
+}
+
+// ProjectV2IterationInput represents a single iteration of an iteration field.
+type ProjectV2IterationInput struct {
+	Title     githubv4.String `json:"title"`
+	StartDate githubv4.String `json:"startDate"`
+	Duration  githubv4.Int    `json:"duration"`
+}
+
+// projectV2Fragment is the project returned by project mutations.
+type projectV2Fragment struct {
+	ID               githubv4.ID
+	DatabaseID       githubv4.Int
+	Number           githubv4.Int
+	Title            githubv4.String
+	ShortDescription githubv4.String
+	Public           githubv4.Boolean
+	ClosedAt         *githubv4.DateTime
+}
+
+func (p projectV2Fragment) toMinimalProject(ownerType string) *MinimalProject {
+	project := &MinimalProject{
+		ID:               github.Ptr(int64(p.DatabaseID)),
+		NodeID:           github.Ptr(fmt.Sprint(p.ID)),
+		Title:            github.Ptr(string(p.Title)),
+		Public:           github.Ptr(bool(p.Public)),
+		Number:           github.Ptr(int(p.Number)),
+		ShortDescription: github.Ptr(string(p.ShortDescription)),
+		OwnerType:        ownerType,
+	}
+	if p.ClosedAt != nil {
+		project.ClosedAt = &github.Timestamp{Time: p.ClosedAt.Time}
+	}
+	return project
+}
+
+// projectV2FieldFragment is the field returned by field mutations. Only the fragment matching the
+// type of the field is meaningful.
+type projectV2FieldFragment struct {
+	Field struct {
+		ID         githubv4.ID
+		DatabaseID githubv4.Int
+		Name       githubv4.String
+		DataType   githubv4.String
+	} `graphql:"... on ProjectV2Field"`
+	SingleSelectField struct {
+		ID         githubv4.ID
+		DatabaseID githubv4.Int
+		Name       githubv4.String
+		DataType   githubv4.String
+		Options    []struct {
+			ID          githubv4.String
+			Name        githubv4.String
+			Color       githubv4.String
+			Description githubv4.String
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	IterationField struct {
+		ID            githubv4.ID
+		DatabaseID    githubv4.Int
+		Name          githubv4.String
+		DataType      githubv4.String
+		Configuration struct {
+			Duration   githubv4.Int
+			StartDay   githubv4.Int
+			Iterations []struct {
+				ID        githubv4.String
+				Title     githubv4.String
+				StartDate githubv4.String
+				Duration  githubv4.Int
+			}
+		}
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+// ProjectField is a project field created or updated with projects_write. ID is the field ID
+// used by the other project tools.
+type ProjectField struct {
+	ID            int64                          `json:"id"`
+	NodeID        string                         `json:"node_id"`
+	Name          string                         `json:"name"`
+	DataType      string                         `json:"data_type"`
+	Options       []ProjectFieldOption           `json:"options,omitempty"`
+	Configuration *ProjectIterationConfiguration `json:"configuration,omitempty"`
+}
+
+// ProjectFieldOption is an option of a single select field.
+type ProjectFieldOption struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectIterationConfiguration is the iteration schedule of an iteration field.
+type ProjectIterationConfiguration struct {
+	Duration   int                `json:"duration"`
+	StartDay   int                `json:"start_day"`
+	Iterations []ProjectIteration `json:"iterations"`
+}
+
+// ProjectIteration is an iteration of an iteration field.
+type ProjectIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	Duration  int    `json:"duration"`
+}
+
+func (f projectV2FieldFragment) toProjectField() ProjectField {
+	switch string(f.Field.DataType) {
+	case "SINGLE_SELECT":
+		field := ProjectField{
+			ID:       int64(f.SingleSelectField.DatabaseID),
+			NodeID:   fmt.Sprint(f.SingleSelectField.ID),
+			Name:     string(f.SingleSelectField.Name),
+			DataType: string(f.SingleSelectField.DataType),
+			Options:  make([]ProjectFieldOption, 0, len(f.SingleSelectField.Options)),
+		}
+		for _, option := range f.SingleSelectField.Options {
+			field.Options = append(field.Options, ProjectFieldOption{
+				ID:          string(option.ID),
+				Name:        string(option.Name),
+				Color:       string(option.Color),
+				Description: string(option.Description),
+			})
+		}
+		return field
+	case "ITERATION":
+		configuration := f.IterationField.Configuration
+		field := ProjectField{
+			ID:       int64(f.IterationField.DatabaseID),
+			NodeID:   fmt.Sprint(f.IterationField.ID),
+			Name:     string(f.IterationField.Name),
+			DataType: string(f.IterationField.DataType),
+			Configuration: &ProjectIterationConfiguration{
+				Duration:   int(configuration.Duration),
+				StartDay:   int(configuration.StartDay),
+				Iterations: make([]ProjectIteration, 0, len(configuration.Iterations)),
+			},
+		}
+		for _, iteration := range configuration.Iterations {
+			field.Configuration.Iterations = append(field.Configuration.Iterations, ProjectIteration{
+				ID:        string(iteration.ID),
+				Title:     string(iteration.Title),
+				StartDate: string(iteration.StartDate),
+				Duration:  int(iteration.Duration),
+			})
+		}
+		return field
+	default:
+		return ProjectField{
+			ID:       int64(f.Field.DatabaseID),
+			NodeID:   fmt.Sprint(f.Field.ID),
+			Name:     string(f.Field.Name),
+			DataType: string(f.Field.DataType),
+		}
+	}
+}
+
+// projectManagementProperties returns the projects_write parameters used to manage projects,
+// fields and draft issues.
+func projectManagementProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"title": {
+			Type:        "string",
+			Description: "The title of the project for 'create_project' and 'update_project', or of the draft issue for 'create_draft_issue'.",
+		},
+		"short_description": {
+			Type:        "string",
+			Description: "The short description of the project. Used by 'update_project'.",
+		},
+		"readme": {
+			Type:        "string",
+			Description: "The README of the project, in Markdown. Used by 'update_project'.",
+		},
+		"public": {
+			Type:        "boolean",
+			Description: "Whether the project is public. Used by 'update_project'.",
+		},
+		"closed": {
+			Type:        "boolean",
+			Description: "Set to true to close the project or false to reopen it. Used by 'update_project'.",
+		},
+		"body": {
+			Type:        "string",
+			Description: "The body of the draft issue, in Markdown. Used by 'create_draft_issue'.",
+		},
+		"field_id": {
+			Type:        "number",
+			Description: "The ID of the project field. Required for 'update_field'.",
+		},
+		"field_name": {
+			Type:        "string",
+			Description: "The name of the project field. Required for 'create_field', optional for 'update_field'.",
+		},
+		"data_type": {
+			Type:        "string",
+			Description: "The data type of the project field. Required for 'create_field'.",
+			Enum:        projectFieldDataTypes,
+		},
+		"single_select_options": {
+			Type:        "array",
+			Description: "The options of a SINGLE_SELECT field for 'create_field' and 'update_field'. On update, the list replaces all options; pass the 'id' of existing options to keep their values on items.",
+			Items: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "The ID of an existing option, to keep it when updating",
+					},
+					"name": {
+						Type:        "string",
+						Description: "The name of the option",
+					},
+					"color": {
+						Type:        "string",
+						Description: "The color of the option (default GRAY)",
+						Enum:        projectFieldOptionColors,
+					},
+					"description": {
+						Type:        "string",
+						Description: "The description of the option",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		"iteration_configuration": {
+			Type:        "object",
+			Description: "The iteration schedule of an ITERATION field for 'create_field' and 'update_field'. Required when creating an ITERATION field.",
+			Properties: map[string]*jsonschema.Schema{
+				"start_date": {
+					Type:        "string",
+					Description: "The start date of the first iteration (YYYY-MM-DD)",
+				},
+				"duration": {
+					Type:        "number",
+					Description: "The default duration of an iteration, in days",
+				},
+				"iterations": {
+					Type:        "array",
+					Description: "The iterations of the field. When omitted, GitHub generates iterations from the start date and duration.",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"title": {
+								Type:        "string",
+								Description: "The title of the iteration",
+							},
+							"start_date": {
+								Type:        "string",
+								Description: "The start date of the iteration (YYYY-MM-DD)",
+							},
+							"duration": {
+								Type:        "number",
+								Description: "The duration of the iteration, in days (default: the configuration duration)",
+							},
+						},
+						Required: []string{"title", "start_date"},
+					},
+				},
+			},
+			Required: []string{"start_date", "duration"},
+		},
+		"target_owner": {
+			Type:        "string",
+			Description: "The owner of the repository for 'convert_draft_issue', 'link_project' and 'unlink_project', or of the repository to link the new project to for 'create_project'.",
+		},
+		"target_repo": {
+			Type:        "string",
+			Description: "The name of the repository to create the issue in for 'convert_draft_issue', or to link the project to for 'create_project', 'link_project' and 'unlink_project'.",
+		},
+		"team_slug": {
+			Type:        "string",
+			Description: "The slug of a team in the organization that owns the project, to link the project to for 'create_project', 'link_project' and 'unlink_project'.",
+		},
+	}
+}
+
+// createProject creates a project owned by a user or organization, optionally linked to a
+// repository or team.
+func createProject(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string) (*mcp.CallToolResult, any, error) {
+	title, err := RequiredParam[string](args, "title")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	var ownerQuery struct {
+		RepositoryOwner struct {
+			Typename githubv4.String `graphql:"__typename"`
+			ID       githubv4.ID
+			Login    githubv4.String
+		} `graphql:"repositoryOwner(login: $owner)"`
+	}
+	if err := gqlClient.Query(ctx, &ownerQuery, map[string]any{"owner": githubv4.String(owner)}); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project owner", err), nil, nil
+	}
+	// owner_type is optional for this method, so report the type of the owner that was found.
+	switch ownerQuery.RepositoryOwner.Typename {
+	case "Organization":
+		ownerType = "org"
+	case "User":
+		ownerType = "user"
+	}
+
+	input := githubv4.CreateProjectV2Input{
+		OwnerID: ownerQuery.RepositoryOwner.ID,
+		Title:   githubv4.String(title),
+	}
+	repositoryID, teamID, result := resolveProjectLinkTarget(ctx, gqlClient, args, owner, false)
+	if result != nil {
+		return result, nil, nil
+	}
+	if repositoryID != nil {
+		input.RepositoryID = &repositoryID
+	}
+	if teamID != nil {
+		input.TeamID = &teamID
+	}
+
+	var mutation struct {
+		CreateProjectV2 struct {
+			ProjectV2 projectV2Fragment
+		} `graphql:"createProjectV2(input: $input)"`
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create project", err), nil, nil
+	}
+
+	result, err = utils.NewToolResultJSON(mutation.CreateProjectV2.ProjectV2.toMinimalProject(ownerType))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// updateProject updates the settings of a project, and closes or reopens it.
+func updateProject(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	input := githubv4.UpdateProjectV2Input{}
+	if _, ok := args["title"]; ok {
+		title, err := OptionalParam[string](args, "title")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		input.Title = githubv4.NewString(githubv4.String(title))
+	}
+	if _, ok := args["short_description"]; ok {
+		shortDescription, err := OptionalParam[string](args, "short_description")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		input.ShortDescription = githubv4.NewString(githubv4.String(shortDescription))
+	}
+	if _, ok := args["readme"]; ok {
+		readme, err := OptionalParam[string](args, "readme")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		input.Readme = githubv4.NewString(githubv4.String(readme))
+	}
+	if _, ok := args["public"]; ok {
+		public, err := OptionalParam[bool](args, "public")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		input.Public = githubv4.NewBoolean(githubv4.Boolean(public))
+	}
+	if _, ok := args["closed"]; ok {
+		closed, err := OptionalParam[bool](args, "closed")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		input.Closed = githubv4.NewBoolean(githubv4.Boolean(closed))
+	}
+	if input.Title == nil && input.ShortDescription == nil && input.Readme == nil && input.Public == nil && input.Closed == nil {
+		return utils.NewToolResultError("at least one of title, short_description, readme, public or closed is required"), nil, nil
+	}
+
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	input.ProjectID = projectID
+
+	var mutation struct {
+		UpdateProjectV2 struct {
+			ProjectV2 projectV2Fragment
+		} `graphql:"updateProjectV2(input: $input)"`
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update project", err), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(mutation.UpdateProjectV2.ProjectV2.toMinimalProject(ownerType))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// createProjectField creates a field in a project.
+func createProjectField(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	name, err := RequiredParam[string](args, "field_name")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	dataType, err := RequiredParam[string](args, "data_type")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	dataType = strings.ToUpper(dataType)
+	if !slices.Contains(projectFieldDataTypes, any(dataType)) {
+		return utils.NewToolResultError(fmt.Sprintf("unsupported data_type %q", dataType)), nil, nil
+	}
+	options, err := optionalSingleSelectOptions(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	iterationConfiguration, err := optionalIterationConfiguration(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	switch {
+	case dataType == "SINGLE_SELECT" && options == nil:
+		return utils.NewToolResultError("single_select_options is required for SINGLE_SELECT fields"), nil, nil
+	case dataType != "SINGLE_SELECT" && options != nil:
+		return utils.NewToolResultError("single_select_options can only be used with SINGLE_SELECT fields"), nil, nil
+	case dataType == "ITERATION" && iterationConfiguration == nil:
+		return utils.NewToolResultError("iteration_configuration is required for ITERATION fields"), nil, nil
+	case dataType != "ITERATION" && iterationConfiguration != nil:
+		return utils.NewToolResultError("iteration_configuration can only be used with ITERATION fields"), nil, nil
+	}
+
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	var mutation struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectV2FieldFragment
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+	input := CreateProjectV2FieldInput{
+		ProjectID:              projectID,
+		DataType:               githubv4.ProjectV2CustomFieldType(dataType),
+		Name:                   githubv4.String(name),
+		SingleSelectOptions:    options,
+		IterationConfiguration: iterationConfiguration,
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create project field", err), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(mutation.CreateProjectV2Field.ProjectV2Field.toProjectField())
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// updateProjectField renames a project field, or replaces its options or iteration schedule.
+func updateProjectField(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	fieldID, err := RequiredBigInt(args, "field_id")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	name, err := OptionalParam[string](args, "field_name")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	options, err := optionalSingleSelectOptions(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	iterationConfiguration, err := optionalIterationConfiguration(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	if name == "" && options == nil && iterationConfiguration == nil {
+		return utils.NewToolResultError("at least one of field_name, single_select_options or iteration_configuration is required"), nil, nil
+	}
+
+	field, resp, err := getProjectFieldByID(ctx, client, owner, ownerType, projectNumber, fieldID)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get project field", resp, err), nil, nil
+	}
+
+	input := UpdateProjectV2FieldInput{
+		FieldID:                githubv4.ID(field.GetNodeID()),
+		SingleSelectOptions:    options,
+		IterationConfiguration: iterationConfiguration,
+	}
+	if name != "" {
+		input.Name = githubv4.NewString(githubv4.String(name))
+	}
+
+	var mutation struct {
+		UpdateProjectV2Field struct {
+			ProjectV2Field projectV2FieldFragment
+		} `graphql:"updateProjectV2Field(input: $input)"`
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update project field", err), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(mutation.UpdateProjectV2Field.ProjectV2Field.toProjectField())
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// createDraftIssue adds a draft issue to a project.
+func createDraftIssue(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	title, err := RequiredParam[string](args, "title")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	body, err := OptionalParam[string](args, "body")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	input := githubv4.AddProjectV2DraftIssueInput{
+		ProjectID: projectID,
+		Title:     githubv4.String(title),
+	}
+	if body != "" {
+		input.Body = githubv4.NewString(githubv4.String(body))
+	}
+
+	var mutation struct {
+		AddProjectV2DraftIssue struct {
+			ProjectItem struct {
+				ID         githubv4.ID
+				DatabaseID githubv4.Int
+			}
+		} `graphql:"addProjectV2DraftIssue(input: $input)"`
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create draft issue", err), nil, nil
+	}
+
+	item := mutation.AddProjectV2DraftIssue.ProjectItem
+	result, err := utils.NewToolResultJSON(map[string]any{
+		"id":      int64(item.DatabaseID),
+		"node_id": item.ID,
+		"message": fmt.Sprintf("Successfully added draft issue %q to project %s/%d", title, owner, projectNumber),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// convertDraftIssue converts a draft issue in a project to an issue in a repository.
+func convertDraftIssue(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int) (*mcp.CallToolResult, any, error) {
+	itemID, err := RequiredBigInt(args, "item_id")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	targetOwner, err := RequiredParam[string](args, "target_owner")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	targetRepo, err := RequiredParam[string](args, "target_repo")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	item, resp, err := getProjectItemByID(ctx, client, owner, ownerType, projectNumber, itemID)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get project item", resp, err), nil, nil
+	}
+	repositoryID, err := resolveRepositoryNodeID(ctx, gqlClient, targetOwner, targetRepo)
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get repository", err), nil, nil
+	}
+
+	var mutation struct {
+		ConvertProjectV2DraftIssueItemToIssue struct {
+			Item struct {
+				Content struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.URI
+					} `graphql:"... on Issue"`
+				}
+			}
+		} `graphql:"convertProjectV2DraftIssueItemToIssue(input: $input)"`
+	}
+	input := githubv4.ConvertProjectV2DraftIssueItemToIssueInput{
+		ItemID:       githubv4.ID(item.GetNodeID()),
+		RepositoryID: repositoryID,
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to convert draft issue", err), nil, nil
+	}
+
+	issue := mutation.ConvertProjectV2DraftIssueItemToIssue.Item.Content.Issue
+	result, err := utils.NewToolResultJSON(map[string]any{
+		"id":         itemID,
+		"number":     int(issue.Number),
+		"repository": fmt.Sprintf("%s/%s", targetOwner, targetRepo),
+		"url":        issue.URL.String(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// setProjectItemArchived archives or unarchives a project item.
+func setProjectItemArchived(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, ownerType string, projectNumber int, itemID int64, archived bool) (*mcp.CallToolResult, any, error) {
+	item, resp, err := getProjectItemByID(ctx, client, owner, ownerType, projectNumber, itemID)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get project item", resp, err), nil, nil
+	}
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	if archived {
+		var mutation struct {
+			ArchiveProjectV2Item struct {
+				Item struct {
+					ID githubv4.ID
+				}
+			} `graphql:"archiveProjectV2Item(input: $input)"`
+		}
+		input := githubv4.ArchiveProjectV2ItemInput{ProjectID: projectID, ItemID: githubv4.ID(item.GetNodeID())}
+		if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to archive project item", err), nil, nil
+		}
+		return utils.NewToolResultText("project item successfully archived"), nil, nil
+	}
+
+	var mutation struct {
+		UnarchiveProjectV2Item struct {
+			Item struct {
+				ID githubv4.ID
+			}
+		} `graphql:"unarchiveProjectV2Item(input: $input)"`
+	}
+	input := githubv4.UnarchiveProjectV2ItemInput{ProjectID: projectID, ItemID: githubv4.ID(item.GetNodeID())}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unarchive project item", err), nil, nil
+	}
+	return utils.NewToolResultText("project item successfully unarchived"), nil, nil
+}
+
+// setProjectLink links a project to, or unlinks it from, a repository or a team.
+func setProjectLink(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner, ownerType string, projectNumber int, linked bool) (*mcp.CallToolResult, any, error) {
+	repositoryID, teamID, result := resolveProjectLinkTarget(ctx, gqlClient, args, owner, true)
+	if result != nil {
+		return result, nil, nil
+	}
+	projectID, err := resolveProjectNodeID(ctx, gqlClient, owner, ownerType, projectNumber)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	var target string
+	var input any
+	var mutation any
+	if repositoryID != nil {
+		targetOwner, _ := OptionalParam[string](args, "target_owner")
+		targetRepo, _ := OptionalParam[string](args, "target_repo")
+		target = fmt.Sprintf("repository %s/%s", targetOwner, targetRepo)
+		if linked {
+			input = githubv4.LinkProjectV2ToRepositoryInput{ProjectID: projectID, RepositoryID: repositoryID}
+			mutation = &struct {
+				LinkProjectV2ToRepository struct {
+					Repository struct {
+						ID githubv4.ID
+					}
+				} `graphql:"linkProjectV2ToRepository(input: $input)"`
+			}{}
+		} else {
+			input = githubv4.UnlinkProjectV2FromRepositoryInput{ProjectID: projectID, RepositoryID: repositoryID}
+			mutation = &struct {
+				UnlinkProjectV2FromRepository struct {
+					Repository struct {
+						ID githubv4.ID
+					}
+				} `graphql:"unlinkProjectV2FromRepository(input: $input)"`
+			}{}
+		}
+	} else {
+		teamSlug, _ := OptionalParam[string](args, "team_slug")
+		target = fmt.Sprintf("team %s/%s", owner, teamSlug)
+		if linked {
+			input = githubv4.LinkProjectV2ToTeamInput{ProjectID: projectID, TeamID: teamID}
+			mutation = &struct {
+				LinkProjectV2ToTeam struct {
+					Team struct {
+						ID githubv4.ID
+					}
+				} `graphql:"linkProjectV2ToTeam(input: $input)"`
+			}{}
+		} else {
+			input = githubv4.UnlinkProjectV2FromTeamInput{ProjectID: projectID, TeamID: teamID}
+			mutation = &struct {
+				UnlinkProjectV2FromTeam struct {
+					Team struct {
+						ID githubv4.ID
+					}
+				} `graphql:"unlinkProjectV2FromTeam(input: $input)"`
+			}{}
+		}
+	}
+
+	if err := gqlClient.Mutate(ctx, mutation, input, nil); err != nil {
+		if linked {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to link project to %s", target), err), nil, nil
+		}
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to unlink project from %s", target), err), nil, nil
+	}
+	if linked {
+		return utils.NewToolResultText(fmt.Sprintf("project %s/%d linked to %s", owner, projectNumber, target)), nil, nil
+	}
+	return utils.NewToolResultText(fmt.Sprintf("project %s/%d unlinked from %s", owner, projectNumber, target)), nil, nil
+}
+
+// resolveProjectLinkTarget resolves the repository (target_owner and target_repo) or team
+// (team_slug) a project is linked to. At most one of them may be given, and one is required when
+// required is set. A non-nil result reports an error to return to the caller.
+func resolveProjectLinkTarget(ctx context.Context, gqlClient *githubv4.Client, args map[string]any, owner string, required bool) (githubv4.ID, githubv4.ID, *mcp.CallToolResult) {
+	targetOwner, err := OptionalParam[string](args, "target_owner")
+	if err != nil {
+		return nil, nil, utils.NewToolResultError(err.Error())
+	}
+	targetRepo, err := OptionalParam[string](args, "target_repo")
+	if err != nil {
+		return nil, nil, utils.NewToolResultError(err.Error())
+	}
+	teamSlug, err := OptionalParam[string](args, "team_slug")
+	if err != nil {
+		return nil, nil, utils.NewToolResultError(err.Error())
+	}
+
+	hasRepository := targetOwner != "" || targetRepo != ""
+	switch {
+	case hasRepository && teamSlug != "":
+		return nil, nil, utils.NewToolResultError("provide either target_owner and target_repo or team_slug, not both")
+	case hasRepository && (targetOwner == "" || targetRepo == ""):
+		return nil, nil, utils.NewToolResultError("target_owner and target_repo must be provided together")
+	case !hasRepository && teamSlug == "":
+		if required {
+			return nil, nil, utils.NewToolResultError("either target_owner and target_repo or team_slug is required")
+		}
+		return nil, nil, nil
+	}
+
+	if hasRepository {
+		repositoryID, err := resolveRepositoryNodeID(ctx, gqlClient, targetOwner, targetRepo)
+		if err != nil {
+			return nil, nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get repository", err)
+		}
+		return repositoryID, nil, nil
+	}
+
+	var query struct {
+		Organization struct {
+			Team *struct {
+				ID githubv4.ID
+			} `graphql:"team(slug: $teamSlug)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+	if err := gqlClient.Query(ctx, &query, map[string]any{
+		"owner":    githubv4.String(owner),
+		"teamSlug": githubv4.String(teamSlug),
+	}); err != nil {
+		return nil, nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get team", err)
+	}
+	if query.Organization.Team == nil {
+		return nil, nil, utils.NewToolResultError(fmt.Sprintf("team %s/%s not found", owner, teamSlug))
+	}
+	return nil, query.Organization.Team.ID, nil
+}
+
+// optionalSingleSelectOptions returns the single_select_options parameter, or nil when it is absent.
+func optionalSingleSelectOptions(args map[string]any) (*[]ProjectV2SingleSelectFieldOptionInput, error) {
+	raw, ok := args["single_select_options"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("single_select_options must be a non-empty array of options")
+	}
+
+	options := make([]ProjectV2SingleSelectFieldOptionInput, 0, len(items))
+	for i, item := range items {
+		values, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("single_select_options[%d] must be an object", i)
+		}
+		name, err := RequiredParam[string](values, "name")
+		if err != nil {
+			return nil, fmt.Errorf("single_select_options[%d]: %w", i, err)
+		}
+		color, err := OptionalParam[string](values, "color")
+		if err != nil {
+			return nil, fmt.Errorf("single_select_options[%d]: %w", i, err)
+		}
+		color = strings.ToUpper(color)
+		if color == "" {
+			color = "GRAY"
+		}
+		if !slices.Contains(projectFieldOptionColors, any(color)) {
+			return nil, fmt.Errorf("single_select_options[%d]: unsupported color %q", i, color)
+		}
+		description, err := OptionalParam[string](values, "description")
+		if err != nil {
+			return nil, fmt.Errorf("single_select_options[%d]: %w", i, err)
+		}
+		id, err := OptionalParam[string](values, "id")
+		if err != nil {
+			return nil, fmt.Errorf("single_select_options[%d]: %w", i, err)
+		}
+
+		option := ProjectV2SingleSelectFieldOptionInput{
+			Name:        githubv4.String(name),
+			Color:       githubv4.ProjectV2SingleSelectFieldOptionColor(color),
+			Description: githubv4.String(description),
+		}
+		if id != "" {
+			option.ID = githubv4.NewString(githubv4.String(id))
+		}
+		options = append(options, option)
+	}
+	return &options, nil
+}
+
+// optionalIterationConfiguration returns the iteration_configuration parameter, or nil when it is absent.
+func optionalIterationConfiguration(args map[string]any) (*ProjectV2IterationFieldConfigurationInput, error) {
+	raw, ok := args["iteration_configuration"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("iteration_configuration must be an object")
+	}
+
+	startDate, err := requiredDateParam(values, "start_date")
+	if err != nil {
+		return nil, fmt.Errorf("iteration_configuration: %w", err)
+	}
+	duration, err := RequiredInt(values, "duration")
+	if err != nil {
+		return nil, fmt.Errorf("iteration_configuration: %w", err)
+	}
+	if duration < 1 {
+		return nil, fmt.Errorf("iteration_configuration: duration must be at least 1 day")
+	}
+
+	configuration := &ProjectV2IterationFieldConfigurationInput{
+		StartDate:  githubv4.String(startDate),
+		Duration:   githubv4.Int(int32(duration)), //nolint:gosec // Iteration durations are small integers
+		Iterations: []ProjectV2IterationInput{},
+	}
+	rawIterations, ok := values["iterations"]
+	if !ok || rawIterations == nil {
+		return configuration, nil
+	}
+	iterations, ok := rawIterations.([]any)
+	if !ok {
+		return nil, fmt.Errorf("iteration_configuration: iterations must be an array")
+	}
+	for i, rawIteration := range iterations {
+		iteration, ok := rawIteration.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("iteration_configuration: iterations[%d] must be an object", i)
+		}
+		title, err := RequiredParam[string](iteration, "title")
+		if err != nil {
+			return nil, fmt.Errorf("iteration_configuration: iterations[%d]: %w", i, err)
+		}
+		iterationStart, err := requiredDateParam(iteration, "start_date")
+		if err != nil {
+			return nil, fmt.Errorf("iteration_configuration: iterations[%d]: %w", i, err)
+		}
+		iterationDuration, err := OptionalIntParamWithDefault(iteration, "duration", duration)
+		if err != nil {
+			return nil, fmt.Errorf("iteration_configuration: iterations[%d]: %w", i, err)
+		}
+		if iterationDuration < 1 {
+			return nil, fmt.Errorf("iteration_configuration: iterations[%d]: duration must be at least 1 day", i)
+		}
+		configuration.Iterations = append(configuration.Iterations, ProjectV2IterationInput{
+			Title:     githubv4.String(title),
+			StartDate: githubv4.String(iterationStart),
+			Duration:  githubv4.Int(int32(iterationDuration)), //nolint:gosec // Iteration durations are small integers
+		})
+	}
+	return configuration, nil
+}
+
+// requiredDateParam returns a required YYYY-MM-DD date parameter.
+func requiredDateParam(args map[string]any, name string) (string, error) {
+	value, err := RequiredParam[string](args, name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		return "", fmt.Errorf("%s must be a date in YYYY-MM-DD format", name)
+	}
+	return value, nil
+}
+
+// getProjectFieldByID returns a project field, to resolve its node ID.
+func getProjectFieldByID(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, fieldID int64) (*github.ProjectV2Field, *github.Response, error) {
+	var field *github.ProjectV2Field
+	var resp *github.Response
+	var err error
+	if ownerType == "org" {
+		field, resp, err = client.Projects.GetOrganizationProjectField(ctx, owner, projectNumber, fieldID)
+	} else {
+		field, resp, err = client.Projects.GetUserProjectField(ctx, owner, projectNumber, fieldID)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	return field, resp, nil
+}
+
+// getProjectItemByID returns a project item, to resolve its node ID.
+func getProjectItemByID(ctx context.Context, client *github.Client, owner, ownerType string, projectNumber int, itemID int64) (*github.ProjectV2Item, *github.Response, error) {
+	var item *github.ProjectV2Item
+	var resp *github.Response
+	var err error
+	if ownerType == "org" {
+		item, resp, err = client.Projects.GetOrganizationProjectItem(ctx, owner, projectNumber, itemID, nil)
+	} else {
+		item, resp, err = client.Projects.GetUserProjectItem(ctx, owner, projectNumber, itemID, nil)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	return item, resp, nil
+}
+
+// resolveRepositoryNodeID resolves a repository to its GraphQL node ID
+func resolveRepositoryNodeID(ctx context.Context, gqlClient *githubv4.Client, owner, repo string) (githubv4.ID, error) {
+	var query struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := gqlClient.Query(ctx, &query, map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+	}); err != nil {
+		return nil, err
+	}
+	return query.Repository.ID, nil
+}
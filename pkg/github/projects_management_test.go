@@ -0,0 +1,714 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orgProjectIDMatcher mocks the project ID lookup of project 1 owned by octo-org.
+func orgProjectIDMatcher() githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Organization struct {
+				ProjectV2 struct {
+					ID githubv4.ID
+				} `graphql:"projectV2(number: $projectNumber)"`
+			} `graphql:"organization(login: $owner)"`
+		}{},
+		map[string]any{
+			"owner":         githubv4.String("octo-org"),
+			"projectNumber": githubv4.Int(1),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{"id": "PVT_project1"},
+			},
+		}),
+	)
+}
+
+func repositoryOwnerMatcher(login, typename, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			RepositoryOwner struct {
+				Typename githubv4.String `graphql:"__typename"`
+				ID       githubv4.ID
+				Login    githubv4.String
+			} `graphql:"repositoryOwner(login: $owner)"`
+		}{},
+		map[string]any{"owner": githubv4.String(login)},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"__typename": typename, "id": id, "login": login},
+		}),
+	)
+}
+
+func repositoryIDMatcher() githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("octo-org"),
+			"repo":  githubv4.String("app"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"id": "R_app"},
+		}),
+	)
+}
+
+var projectResponse = map[string]any{
+	"id":               "PVT_project1",
+	"databaseId":       101,
+	"number":           1,
+	"title":            "Roadmap",
+	"shortDescription": "Planning",
+	"public":           false,
+	"closedAt":         nil,
+}
+
+func Test_ProjectsWrite_ManageProjects(t *testing.T) {
+	toolDef := ProjectsWrite(translations.NullTranslationHelper)
+	schema := toolDef.Tool.InputSchema.(*jsonschema.Schema)
+	for _, method := range []string{"create_project", "update_project", "create_field", "update_field", "create_draft_issue", "convert_draft_issue", "archive_item", "unarchive_item", "link_project", "unlink_project"} {
+		assert.Contains(t, schema.Properties["method"].Enum, method)
+	}
+
+	tests := []struct {
+		name               string
+		gqlMatchers        []githubv4mock.Matcher
+		restHandlers       map[string]http.HandlerFunc
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedText       string
+		check              func(t *testing.T, text string)
+	}{
+		{
+			name: "create project linked to a repository",
+			gqlMatchers: []githubv4mock.Matcher{
+				repositoryOwnerMatcher("octo-org", "Organization", "O_octo"),
+				repositoryIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CreateProjectV2 struct {
+							ProjectV2 projectV2Fragment
+						} `graphql:"createProjectV2(input: $input)"`
+					}{},
+					githubv4.CreateProjectV2Input{
+						OwnerID:      githubv4.ID("O_octo"),
+						Title:        githubv4.String("Roadmap"),
+						RepositoryID: githubv4.NewID(githubv4.ID("R_app")),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createProjectV2": map[string]any{"projectV2": projectResponse},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":       "create_project",
+				"owner":        "octo-org",
+				"owner_type":   "org",
+				"title":        "Roadmap",
+				"target_owner": "octo-org",
+				"target_repo":  "app",
+			},
+			check: func(t *testing.T, text string) {
+				var project MinimalProject
+				require.NoError(t, json.Unmarshal([]byte(text), &project))
+				assert.Equal(t, 1, *project.Number)
+				assert.Equal(t, int64(101), *project.ID)
+				assert.Equal(t, "PVT_project1", *project.NodeID)
+				assert.Equal(t, "org", project.OwnerType)
+			},
+		},
+		{
+			name: "create project reports the owner type it found",
+			gqlMatchers: []githubv4mock.Matcher{
+				repositoryOwnerMatcher("octocat", "User", "U_octocat"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CreateProjectV2 struct {
+							ProjectV2 projectV2Fragment
+						} `graphql:"createProjectV2(input: $input)"`
+					}{},
+					githubv4.CreateProjectV2Input{
+						OwnerID: githubv4.ID("U_octocat"),
+						Title:   githubv4.String("Roadmap"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createProjectV2": map[string]any{"projectV2": projectResponse},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method": "create_project",
+				"owner":  "octocat",
+				"title":  "Roadmap",
+			},
+			check: func(t *testing.T, text string) {
+				var project MinimalProject
+				require.NoError(t, json.Unmarshal([]byte(text), &project))
+				assert.Equal(t, "user", project.OwnerType)
+			},
+		},
+		{
+			name: "close project",
+			gqlMatchers: []githubv4mock.Matcher{
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						UpdateProjectV2 struct {
+							ProjectV2 projectV2Fragment
+						} `graphql:"updateProjectV2(input: $input)"`
+					}{},
+					githubv4.UpdateProjectV2Input{
+						ProjectID: githubv4.ID("PVT_project1"),
+						Closed:    githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"updateProjectV2": map[string]any{"projectV2": map[string]any{
+							"id":         "PVT_project1",
+							"databaseId": 101,
+							"number":     1,
+							"title":      "Roadmap",
+							"closedAt":   "2026-01-02T03:04:05Z",
+						}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "update_project",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"closed":         true,
+			},
+			check: func(t *testing.T, text string) {
+				var project MinimalProject
+				require.NoError(t, json.Unmarshal([]byte(text), &project))
+				require.NotNil(t, project.ClosedAt)
+				assert.Equal(t, 2026, project.ClosedAt.Year())
+			},
+		},
+		{
+			name: "update project without changes",
+			requestArgs: map[string]any{
+				"method":         "update_project",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "at least one of title, short_description, readme, public or closed is required",
+		},
+		{
+			name: "create single select field",
+			gqlMatchers: []githubv4mock.Matcher{
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CreateProjectV2Field struct {
+							ProjectV2Field projectV2FieldFragment
+						} `graphql:"createProjectV2Field(input: $input)"`
+					}{},
+					CreateProjectV2FieldInput{
+						ProjectID: githubv4.ID("PVT_project1"),
+						DataType:  githubv4.ProjectV2CustomFieldTypeSingleSelect,
+						Name:      githubv4.String("Priority"),
+						SingleSelectOptions: &[]ProjectV2SingleSelectFieldOptionInput{
+							{Name: "P0", Color: githubv4.ProjectV2SingleSelectFieldOptionColorRed, Description: "Drop everything"},
+							{Name: "P1", Color: githubv4.ProjectV2SingleSelectFieldOptionColorGray},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createProjectV2Field": map[string]any{"projectV2Field": map[string]any{
+							"id":         "PVTSSF_priority",
+							"databaseId": 202,
+							"name":       "Priority",
+							"dataType":   "SINGLE_SELECT",
+							"options": []any{
+								map[string]any{"id": "opt0", "name": "P0", "color": "RED", "description": "Drop everything"},
+								map[string]any{"id": "opt1", "name": "P1", "color": "GRAY", "description": ""},
+							},
+						}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "create_field",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"field_name":     "Priority",
+				"data_type":      "single_select",
+				"single_select_options": []any{
+					map[string]any{"name": "P0", "color": "red", "description": "Drop everything"},
+					map[string]any{"name": "P1"},
+				},
+			},
+			check: func(t *testing.T, text string) {
+				var field ProjectField
+				require.NoError(t, json.Unmarshal([]byte(text), &field))
+				assert.Equal(t, int64(202), field.ID)
+				assert.Equal(t, "SINGLE_SELECT", field.DataType)
+				require.Len(t, field.Options, 2)
+				assert.Equal(t, "opt0", field.Options[0].ID)
+				assert.Nil(t, field.Configuration)
+			},
+		},
+		{
+			name: "create iteration field",
+			gqlMatchers: []githubv4mock.Matcher{
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						CreateProjectV2Field struct {
+							ProjectV2Field projectV2FieldFragment
+						} `graphql:"createProjectV2Field(input: $input)"`
+					}{},
+					CreateProjectV2FieldInput{
+						ProjectID: githubv4.ID("PVT_project1"),
+						DataType:  githubv4.ProjectV2CustomFieldType("ITERATION"),
+						Name:      githubv4.String("Sprint"),
+						IterationConfiguration: &ProjectV2IterationFieldConfigurationInput{
+							StartDate: "2026-01-05",
+							Duration:  14,
+							Iterations: []ProjectV2IterationInput{
+								{Title: "Sprint 1", StartDate: "2026-01-05", Duration: 14},
+								{Title: "Hardening", StartDate: "2026-01-19", Duration: 7},
+							},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createProjectV2Field": map[string]any{"projectV2Field": map[string]any{
+							"id":         "PVTIF_sprint",
+							"databaseId": 303,
+							"name":       "Sprint",
+							"dataType":   "ITERATION",
+							"configuration": map[string]any{
+								"duration": 14,
+								"startDay": 1,
+								"iterations": []any{
+									map[string]any{"id": "it1", "title": "Sprint 1", "startDate": "2026-01-05", "duration": 14},
+									map[string]any{"id": "it2", "title": "Hardening", "startDate": "2026-01-19", "duration": 7},
+								},
+							},
+						}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "create_field",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"field_name":     "Sprint",
+				"data_type":      "ITERATION",
+				"iteration_configuration": map[string]any{
+					"start_date": "2026-01-05",
+					"duration":   float64(14),
+					"iterations": []any{
+						map[string]any{"title": "Sprint 1", "start_date": "2026-01-05"},
+						map[string]any{"title": "Hardening", "start_date": "2026-01-19", "duration": float64(7)},
+					},
+				},
+			},
+			check: func(t *testing.T, text string) {
+				var field ProjectField
+				require.NoError(t, json.Unmarshal([]byte(text), &field))
+				assert.Equal(t, int64(303), field.ID)
+				require.NotNil(t, field.Configuration)
+				assert.Equal(t, 14, field.Configuration.Duration)
+				require.Len(t, field.Configuration.Iterations, 2)
+				assert.Equal(t, "2026-01-19", field.Configuration.Iterations[1].StartDate)
+			},
+		},
+		{
+			name: "single select field without options",
+			requestArgs: map[string]any{
+				"method":         "create_field",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"field_name":     "Priority",
+				"data_type":      "SINGLE_SELECT",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "single_select_options is required for SINGLE_SELECT fields",
+		},
+		{
+			name: "iteration with invalid start date",
+			requestArgs: map[string]any{
+				"method":         "create_field",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"field_name":     "Sprint",
+				"data_type":      "ITERATION",
+				"iteration_configuration": map[string]any{
+					"start_date": "next monday",
+					"duration":   float64(14),
+				},
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "start_date must be a date in YYYY-MM-DD format",
+		},
+		{
+			name: "rename field and keep existing options",
+			restHandlers: map[string]http.HandlerFunc{
+				GetOrgsProjectsV2FieldsByProjectByFieldID: mockResponse(t, http.StatusOK, &github.ProjectV2Field{
+					ID:     github.Ptr(int64(202)),
+					NodeID: github.Ptr("PVTSSF_priority"),
+				}),
+			},
+			gqlMatchers: []githubv4mock.Matcher{
+				githubv4mock.NewMutationMatcher(
+					struct {
+						UpdateProjectV2Field struct {
+							ProjectV2Field projectV2FieldFragment
+						} `graphql:"updateProjectV2Field(input: $input)"`
+					}{},
+					UpdateProjectV2FieldInput{
+						FieldID: githubv4.ID("PVTSSF_priority"),
+						Name:    githubv4.NewString("Urgency"),
+						SingleSelectOptions: &[]ProjectV2SingleSelectFieldOptionInput{
+							{ID: githubv4.NewString("opt0"), Name: "P0", Color: githubv4.ProjectV2SingleSelectFieldOptionColorRed},
+							{Name: "P2", Color: githubv4.ProjectV2SingleSelectFieldOptionColorBlue},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"updateProjectV2Field": map[string]any{"projectV2Field": map[string]any{
+							"id":         "PVTSSF_priority",
+							"databaseId": 202,
+							"name":       "Urgency",
+							"dataType":   "SINGLE_SELECT",
+							"options": []any{
+								map[string]any{"id": "opt0", "name": "P0", "color": "RED", "description": ""},
+								map[string]any{"id": "opt2", "name": "P2", "color": "BLUE", "description": ""},
+							},
+						}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "update_field",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"field_id":       float64(202),
+				"field_name":     "Urgency",
+				"single_select_options": []any{
+					map[string]any{"id": "opt0", "name": "P0", "color": "RED"},
+					map[string]any{"name": "P2", "color": "BLUE"},
+				},
+			},
+			check: func(t *testing.T, text string) {
+				var field ProjectField
+				require.NoError(t, json.Unmarshal([]byte(text), &field))
+				assert.Equal(t, "Urgency", field.Name)
+				assert.Len(t, field.Options, 2)
+			},
+		},
+		{
+			name: "create draft issue",
+			gqlMatchers: []githubv4mock.Matcher{
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddProjectV2DraftIssue struct {
+							ProjectItem struct {
+								ID         githubv4.ID
+								DatabaseID githubv4.Int
+							}
+						} `graphql:"addProjectV2DraftIssue(input: $input)"`
+					}{},
+					githubv4.AddProjectV2DraftIssueInput{
+						ProjectID: githubv4.ID("PVT_project1"),
+						Title:     githubv4.String("Investigate flaky test"),
+						Body:      githubv4.NewString("Seen on main"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addProjectV2DraftIssue": map[string]any{
+							"projectItem": map[string]any{"id": "PVTI_draft", "databaseId": 404},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "create_draft_issue",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"title":          "Investigate flaky test",
+				"body":           "Seen on main",
+			},
+			check: func(t *testing.T, text string) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &response))
+				assert.Equal(t, float64(404), response["id"])
+				assert.Equal(t, "PVTI_draft", response["node_id"])
+			},
+		},
+		{
+			name: "convert draft issue",
+			restHandlers: map[string]http.HandlerFunc{
+				GetOrgsProjectsV2ItemsByProjectByItemID: mockResponse(t, http.StatusOK, &github.ProjectV2Item{
+					ID:     github.Ptr(int64(404)),
+					NodeID: github.Ptr("PVTI_draft"),
+				}),
+			},
+			gqlMatchers: []githubv4mock.Matcher{
+				repositoryIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						ConvertProjectV2DraftIssueItemToIssue struct {
+							Item struct {
+								Content struct {
+									Issue struct {
+										Number githubv4.Int
+										URL    githubv4.URI
+									} `graphql:"... on Issue"`
+								}
+							}
+						} `graphql:"convertProjectV2DraftIssueItemToIssue(input: $input)"`
+					}{},
+					githubv4.ConvertProjectV2DraftIssueItemToIssueInput{
+						ItemID:       githubv4.ID("PVTI_draft"),
+						RepositoryID: githubv4.ID("R_app"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"convertProjectV2DraftIssueItemToIssue": map[string]any{
+							"item": map[string]any{
+								"content": map[string]any{"number": 42, "url": "https://github.com/octo-org/app/issues/42"},
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "convert_draft_issue",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"item_id":        float64(404),
+				"target_owner":   "octo-org",
+				"target_repo":    "app",
+			},
+			check: func(t *testing.T, text string) {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &response))
+				assert.Equal(t, float64(42), response["number"])
+				assert.Equal(t, "octo-org/app", response["repository"])
+				assert.Equal(t, "https://github.com/octo-org/app/issues/42", response["url"])
+			},
+		},
+		{
+			name: "archive item",
+			restHandlers: map[string]http.HandlerFunc{
+				GetOrgsProjectsV2ItemsByProjectByItemID: mockResponse(t, http.StatusOK, &github.ProjectV2Item{
+					ID:     github.Ptr(int64(404)),
+					NodeID: github.Ptr("PVTI_item"),
+				}),
+			},
+			gqlMatchers: []githubv4mock.Matcher{
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						ArchiveProjectV2Item struct {
+							Item struct {
+								ID githubv4.ID
+							}
+						} `graphql:"archiveProjectV2Item(input: $input)"`
+					}{},
+					githubv4.ArchiveProjectV2ItemInput{
+						ProjectID: githubv4.ID("PVT_project1"),
+						ItemID:    githubv4.ID("PVTI_item"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"archiveProjectV2Item": map[string]any{"item": map[string]any{"id": "PVTI_item"}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "archive_item",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"item_id":        float64(404),
+			},
+			expectedText: "project item successfully archived",
+		},
+		{
+			name: "archive missing item",
+			restHandlers: map[string]http.HandlerFunc{
+				GetOrgsProjectsV2ItemsByProjectByItemID: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			requestArgs: map[string]any{
+				"method":         "archive_item",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"item_id":        float64(999),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get project item",
+		},
+		{
+			name: "link project to team",
+			gqlMatchers: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(
+					struct {
+						Organization struct {
+							Team *struct {
+								ID githubv4.ID
+							} `graphql:"team(slug: $teamSlug)"`
+						} `graphql:"organization(login: $owner)"`
+					}{},
+					map[string]any{
+						"owner":    githubv4.String("octo-org"),
+						"teamSlug": githubv4.String("platform"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{"team": map[string]any{"id": "T_platform"}},
+					}),
+				),
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						LinkProjectV2ToTeam struct {
+							Team struct {
+								ID githubv4.ID
+							}
+						} `graphql:"linkProjectV2ToTeam(input: $input)"`
+					}{},
+					githubv4.LinkProjectV2ToTeamInput{
+						ProjectID: githubv4.ID("PVT_project1"),
+						TeamID:    githubv4.ID("T_platform"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"linkProjectV2ToTeam": map[string]any{"team": map[string]any{"id": "T_platform"}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "link_project",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"team_slug":      "platform",
+			},
+			expectedText: "project octo-org/1 linked to team octo-org/platform",
+		},
+		{
+			name: "unlink project from repository",
+			gqlMatchers: []githubv4mock.Matcher{
+				repositoryIDMatcher(),
+				orgProjectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						UnlinkProjectV2FromRepository struct {
+							Repository struct {
+								ID githubv4.ID
+							}
+						} `graphql:"unlinkProjectV2FromRepository(input: $input)"`
+					}{},
+					githubv4.UnlinkProjectV2FromRepositoryInput{
+						ProjectID:    githubv4.ID("PVT_project1"),
+						RepositoryID: githubv4.ID("R_app"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unlinkProjectV2FromRepository": map[string]any{"repository": map[string]any{"id": "R_app"}},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":         "unlink_project",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"target_owner":   "octo-org",
+				"target_repo":    "app",
+			},
+			expectedText: "project octo-org/1 unlinked from repository octo-org/app",
+		},
+		{
+			name: "link project to repository and team",
+			requestArgs: map[string]any{
+				"method":         "link_project",
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(1),
+				"target_owner":   "octo-org",
+				"target_repo":    "app",
+				"team_slug":      "platform",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "provide either target_owner and target_repo or team_slug, not both",
+		},
+		{
+			name: "project number required",
+			requestArgs: map[string]any{
+				"method":     "create_draft_issue",
+				"owner":      "octo-org",
+				"owner_type": "org",
+				"title":      "Draft",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: project_number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:    github.NewClient(MockHTTPClientWithHandlers(tc.restHandlers)),
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...)),
+			}
+			handler := toolDef.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+			}
+			if tc.check != nil {
+				tc.check(t, textContent.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,638 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	mboxFromLineRegexp   = regexp.MustCompile(`^From [0-9a-f]{40} `)
+	patchSubjectPrefixRe = regexp.MustCompile(`^\[PATCH[^\]]*\]\s*`)
+)
+
+// patchCommit is a single commit of a patch series.
+type patchCommit struct {
+	Message string
+	Author  *github.CommitAuthor
+	Files   []*fileDiff
+}
+
+// isFormatPatch reports whether a patch is a git format-patch series rather than a plain diff.
+func isFormatPatch(patch string) bool {
+	firstLine, _, _ := strings.Cut(strings.TrimLeft(patch, "\n"), "\n")
+	return mboxFromLineRegexp.MatchString(firstLine) || strings.HasPrefix(firstLine, "From: ")
+}
+
+// parsePatchSeries parses a plain unified diff into a single commit with the given message, or
+// a git format-patch series into one commit per patch with its own message and author.
+func parsePatchSeries(patch, message string) ([]*patchCommit, error) {
+	if !isFormatPatch(patch) {
+		files, err := parsePullRequestDiff(patch)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, errors.New("patch does not contain any file diffs")
+		}
+		return []*patchCommit{{Message: message, Files: files}}, nil
+	}
+
+	var messages []string
+	var current []string
+	for _, line := range strings.Split(patch, "\n") {
+		if mboxFromLineRegexp.MatchString(line) {
+			if len(current) > 0 {
+				messages = append(messages, strings.Join(current, "\n"))
+			}
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		messages = append(messages, strings.Join(current, "\n"))
+	}
+
+	var commits []*patchCommit
+	for i, raw := range messages {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		commit, err := parseFormatPatchMessage(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse patch %d: %w", i+1, err)
+		}
+		commits = append(commits, commit)
+	}
+	if len(commits) == 0 {
+		return nil, errors.New("patch does not contain any commits")
+	}
+	return commits, nil
+}
+
+// parseFormatPatchMessage parses a single email of a git format-patch series.
+func parseFormatPatchMessage(raw string) (*patchCommit, error) {
+	msg, err := mail.ReadMessage(strings.NewReader(strings.TrimLeft(raw, "\n")))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoder mime.WordDecoder
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	subject = patchSubjectPrefixRe.ReplaceAllString(subject, "")
+
+	// The commit message body ends at the "---" line before the diffstat, and the diff ends at
+	// the "-- " line before the git version signature.
+	description, diff, found := strings.Cut(string(body), "\n---\n")
+	if !found {
+		if strings.HasPrefix(string(body), "---\n") {
+			description, diff = "", strings.TrimPrefix(string(body), "---\n")
+		} else {
+			return nil, fmt.Errorf("patch %q has no diff", subject)
+		}
+	}
+	if idx := strings.LastIndex(diff, "\n-- \n"); idx >= 0 {
+		diff = diff[:idx+1]
+	}
+
+	files, err := parsePullRequestDiff(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := &patchCommit{
+		Message: strings.TrimSpace(subject),
+		Files:   files,
+	}
+	if description = strings.TrimSpace(description); description != "" {
+		commit.Message += "\n\n" + description
+	}
+	if from, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		commit.Author = &github.CommitAuthor{
+			Name:  github.Ptr(from.Name),
+			Email: github.Ptr(from.Address),
+		}
+		if date, err := msg.Header.Date(); err == nil {
+			commit.Author.Date = &github.Timestamp{Time: date}
+		}
+	}
+	return commit, nil
+}
+
+// applyHunks applies the hunks of a file diff to the content of a file. Hunks may apply at a
+// different line than the one in their header, as long as their context matches. It returns
+// the patched content and, for each hunk that could not be applied, a failure message.
+func applyHunks(content string, hunks []diffHunk) (string, []string) {
+	var lines []string
+	trailingNewline := true
+	if content != "" {
+		trailingNewline = strings.HasSuffix(content, "\n")
+		lines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+
+	var failures []string
+	offset, searchStart := 0, 0
+	for _, hunk := range hunks {
+		var oldBlock, newBlock []string
+		for _, line := range hunk.Lines {
+			if line.Type != diffLineAdd {
+				oldBlock = append(oldBlock, line.Content)
+			}
+			if line.Type != diffLineDelete {
+				newBlock = append(newBlock, line.Content)
+			}
+		}
+
+		expected := hunk.OldStart - 1 + offset
+		if hunk.OldLines == 0 {
+			// Pure insertions start after the line in their header.
+			expected = hunk.OldStart + offset
+		}
+		at := findBlock(lines, oldBlock, expected, searchStart)
+		if at < 0 {
+			failures = append(failures, fmt.Sprintf("%s: the lines to change do not match the file", hunk.Header))
+			continue
+		}
+
+		endsFile := at+len(oldBlock) == len(lines)
+		lines = slices.Concat(lines[:at], newBlock, lines[at+len(oldBlock):])
+		offset += at - expected + len(newBlock) - len(oldBlock)
+		searchStart = at + len(newBlock)
+		if endsFile {
+			trailingNewline = !hunk.newNoNewline
+		}
+	}
+
+	if len(lines) == 0 {
+		return "", failures
+	}
+	result := strings.Join(lines, "\n")
+	if trailingNewline {
+		result += "\n"
+	}
+	return result, failures
+}
+
+// findBlock finds the position of block in lines at or after start that is closest to expected,
+// or returns -1.
+func findBlock(lines, block []string, expected, start int) int {
+	if len(block) == 0 {
+		// Insertions into an empty file or without context can only go where the header says.
+		if expected < start || expected > len(lines) {
+			return -1
+		}
+		return expected
+	}
+	matches := func(at int) bool {
+		return at >= start && at+len(block) <= len(lines) && slices.Equal(lines[at:at+len(block)], block)
+	}
+	for distance := 0; distance <= len(lines); distance++ {
+		if matches(expected - distance) {
+			return expected - distance
+		}
+		if distance > 0 && matches(expected+distance) {
+			return expected + distance
+		}
+	}
+	return -1
+}
+
+// baseTree looks up files in a git tree, fetching only the subtrees on the way to a file.
+type baseTree struct {
+	client  *github.Client
+	owner   string
+	repo    string
+	rootSHA string
+	dirs    map[string]map[string]*github.TreeEntry
+}
+
+func newBaseTree(client *github.Client, owner, repo, rootSHA string) *baseTree {
+	return &baseTree{
+		client:  client,
+		owner:   owner,
+		repo:    repo,
+		rootSHA: rootSHA,
+		dirs:    map[string]map[string]*github.TreeEntry{},
+	}
+}
+
+// lookup returns the tree entry of a file, or nil if it does not exist.
+func (b *baseTree) lookup(ctx context.Context, filePath string) (*github.TreeEntry, error) {
+	dir, name := path.Split(filePath)
+	entries, err := b.dir(ctx, strings.TrimSuffix(dir, "/"))
+	if err != nil || entries == nil {
+		return nil, err
+	}
+	return entries[name], nil
+}
+
+// dir returns the entries of a directory by name, or nil if it does not exist.
+func (b *baseTree) dir(ctx context.Context, dirPath string) (map[string]*github.TreeEntry, error) {
+	if entries, ok := b.dirs[dirPath]; ok {
+		return entries, nil
+	}
+
+	sha := b.rootSHA
+	if dirPath != "" {
+		parentPath, name := path.Split(dirPath)
+		parent, err := b.dir(ctx, strings.TrimSuffix(parentPath, "/"))
+		if err != nil {
+			return nil, err
+		}
+		entry := parent[name]
+		if entry == nil || entry.GetType() != "tree" {
+			b.dirs[dirPath] = nil
+			return nil, nil
+		}
+		sha = entry.GetSHA()
+	}
+
+	tree, resp, err := b.client.Git.GetTree(ctx, b.owner, b.repo, sha, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of %q: %w", "/"+dirPath, err)
+	}
+	_ = resp.Body.Close()
+
+	entries := make(map[string]*github.TreeEntry, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		entries[entry.GetPath()] = entry
+	}
+	b.dirs[dirPath] = entries
+	return entries, nil
+}
+
+// patchedFile is the state of a file after applying some of the commits of a patch series.
+// Files are either unchanged blobs of the base tree, patched contents or deleted.
+type patchedFile struct {
+	sha     string
+	content *string
+	mode    string
+	deleted bool
+}
+
+// patchApplier applies the commits of a patch series to a base tree in memory, so that nothing
+// is written to the repository unless the whole series applies.
+type patchApplier struct {
+	base   *baseTree
+	files  map[string]*patchedFile
+	blobFn func(ctx context.Context, sha string) (string, error)
+}
+
+// file returns the current state of a file, or nil if it does not exist.
+func (a *patchApplier) file(ctx context.Context, filePath string) (*patchedFile, error) {
+	if file, ok := a.files[filePath]; ok {
+		if file.deleted {
+			return nil, nil
+		}
+		return file, nil
+	}
+	entry, err := a.base.lookup(ctx, filePath)
+	if err != nil || entry == nil || entry.GetType() != "blob" {
+		return nil, err
+	}
+	file := &patchedFile{sha: entry.GetSHA(), mode: entry.GetMode()}
+	a.files[filePath] = file
+	return file, nil
+}
+
+func (a *patchApplier) content(ctx context.Context, file *patchedFile) (string, error) {
+	if file.content == nil {
+		content, err := a.blobFn(ctx, file.sha)
+		if err != nil {
+			return "", err
+		}
+		file.content = &content
+	}
+	return *file.content, nil
+}
+
+// apply applies the file diffs of a commit and returns the tree entries for the changed files,
+// or the reasons the commit could not be applied.
+func (a *patchApplier) apply(ctx context.Context, files []*fileDiff) ([]*github.TreeEntry, []string, error) {
+	var entries []*github.TreeEntry
+	var failures []string
+	for _, diff := range files {
+		fail := func(format string, args ...any) {
+			failures = append(failures, diff.Path+": "+fmt.Sprintf(format, args...))
+		}
+		if diff.Binary {
+			fail("binary files cannot be applied from a patch")
+			continue
+		}
+
+		sourcePath := diff.Path
+		if diff.Status == "renamed" {
+			sourcePath = diff.PreviousPath
+		}
+		source, err := a.file(ctx, sourcePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case diff.Status == "added" && source != nil:
+			fail("file already exists")
+			continue
+		case diff.Status != "added" && source == nil:
+			fail("file does not exist")
+			continue
+		case diff.Status == "renamed":
+			if target, err := a.file(ctx, diff.Path); err != nil {
+				return nil, nil, err
+			} else if target != nil {
+				fail("cannot rename %s because the file already exists", diff.PreviousPath)
+				continue
+			}
+		}
+
+		result := &patchedFile{mode: "100644"}
+		if source != nil {
+			*result = *source
+		}
+		if diff.Mode != "" {
+			result.mode = diff.Mode
+		}
+
+		if len(diff.Hunks) > 0 {
+			content := ""
+			if source != nil {
+				if content, err = a.content(ctx, source); err != nil {
+					return nil, nil, err
+				}
+			}
+			patched, hunkFailures := applyHunks(content, diff.Hunks)
+			for _, failure := range hunkFailures {
+				fail("%s", failure)
+			}
+			if len(hunkFailures) > 0 {
+				continue
+			}
+			result.sha = ""
+			result.content = &patched
+		}
+
+		if diff.Status == "removed" {
+			a.files[diff.Path] = &patchedFile{deleted: true}
+			entries = append(entries, &github.TreeEntry{Path: github.Ptr(diff.Path), Mode: github.Ptr("100644"), Type: github.Ptr("blob")})
+			continue
+		}
+		if diff.Status == "renamed" {
+			a.files[diff.PreviousPath] = &patchedFile{deleted: true}
+			entries = append(entries, &github.TreeEntry{Path: github.Ptr(diff.PreviousPath), Mode: github.Ptr("100644"), Type: github.Ptr("blob")})
+		}
+		a.files[diff.Path] = result
+
+		entry := &github.TreeEntry{
+			Path: github.Ptr(diff.Path),
+			Mode: github.Ptr(result.mode),
+			Type: github.Ptr("blob"),
+		}
+		if result.content != nil && result.sha == "" {
+			entry.Content = result.content
+		} else {
+			entry.SHA = github.Ptr(result.sha)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, failures, nil
+}
+
+// CreatePullRequestFromPatch creates a tool to open a pull request from a unified diff or a git
+// format-patch series, applying it to the base branch with the Git Data API.
+func CreatePullRequestFromPatch(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"base": {
+				Type:        "string",
+				Description: "Branch to apply the patch to and open the pull request against",
+			},
+			"head": {
+				Type:        "string",
+				Description: "Name of the new branch to create for the pull request",
+			},
+			"patch": {
+				Type:        "string",
+				Description: "A unified diff as produced by 'git diff', or a series of patches as produced by 'git format-patch', which keeps the message and author of each commit",
+			},
+			"title": {
+				Type:        "string",
+				Description: "Pull request title",
+			},
+			"body": {
+				Type:        "string",
+				Description: "Pull request description",
+			},
+			"commit_message": {
+				Type:        "string",
+				Description: "Commit message for a unified diff. Defaults to the pull request title. Ignored for format-patch series",
+			},
+			"draft": {
+				Type:        "boolean",
+				Description: "Create as draft pull request",
+			},
+		},
+		Required: []string{"owner", "repo", "base", "head", "patch", "title"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "create_pull_request_from_patch",
+			Description: t("TOOL_CREATE_PULL_REQUEST_FROM_PATCH_DESCRIPTION", "Open a pull request from a patch in one step: applies a unified diff or a git format-patch series to the base branch on GitHub, creates the head branch with one commit per patch and opens the pull request. Nothing is created if any hunk fails to apply; the failed hunks are reported instead."),
+			Icons:       octicons.Icons("git-pull-request"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_PULL_REQUEST_FROM_PATCH_USER_TITLE", "Create pull request from patch"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			base, err := RequiredParam[string](args, "base")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			head, err := RequiredParam[string](args, "head")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			patch, err := RequiredParam[string](args, "patch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			title, err := RequiredParam[string](args, "title")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			body, err := OptionalParam[string](args, "body")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commitMessage, err := OptionalParam[string](args, "commit_message")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			draft, err := OptionalParam[bool](args, "draft")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if commitMessage == "" {
+				commitMessage = title
+			}
+
+			commits, err := parsePatchSeries(patch, commitMessage)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to parse patch: %v", err)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get base branch", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get base commit", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Apply the whole series in memory first, so that nothing is created if any
+			// part of it does not apply.
+			applier := &patchApplier{
+				base:  newBaseTree(client, owner, repo, baseCommit.GetTree().GetSHA()),
+				files: map[string]*patchedFile{},
+				blobFn: func(ctx context.Context, sha string) (string, error) {
+					blob, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, sha)
+					if err != nil {
+						return "", fmt.Errorf("failed to get blob %s: %w", sha, err)
+					}
+					_ = resp.Body.Close()
+					return string(blob), nil
+				},
+			}
+			commitEntries := make([][]*github.TreeEntry, len(commits))
+			var failures []string
+			for i, commit := range commits {
+				entries, commitFailures, err := applier.apply(ctx, commit.Files)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to read files of base branch", err), nil, nil
+				}
+				subject, _, _ := strings.Cut(commit.Message, "\n")
+				for _, failure := range commitFailures {
+					if len(commits) > 1 {
+						failure = fmt.Sprintf("patch %d (%s): %s", i+1, subject, failure)
+					}
+					failures = append(failures, "- "+failure)
+				}
+				commitEntries[i] = entries
+			}
+			if len(failures) > 0 {
+				return utils.NewToolResultError(fmt.Sprintf("the patch does not apply to %s, so nothing was created:\n%s", base, strings.Join(failures, "\n"))), nil, nil
+			}
+
+			parent := baseCommit
+			createdCommits := make([]string, 0, len(commits))
+			for i, commit := range commits {
+				tree, resp, err := client.Git.CreateTree(ctx, owner, repo, parent.GetTree().GetSHA(), commitEntries[i])
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tree", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, github.Commit{
+					Message: github.Ptr(commit.Message),
+					Tree:    tree,
+					Parents: []*github.Commit{{SHA: parent.SHA}},
+					Author:  commit.Author,
+				}, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create commit", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				createdCommits = append(createdCommits, newCommit.GetSHA())
+				parent = newCommit
+			}
+
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, github.CreateRef{
+				Ref: "refs/heads/" + head,
+				SHA: parent.GetSHA(),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					ruleViolationMessage("failed to create head branch", err, head),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr(title),
+				Head:  github.Ptr(head),
+				Base:  github.Ptr(base),
+				Body:  github.Ptr(body),
+				Draft: github.Ptr(draft),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("created branch %s but failed to create pull request", head),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(map[string]any{
+				"number":  pr.GetNumber(),
+				"url":     pr.GetHTMLURL(),
+				"branch":  head,
+				"commits": createdCommits,
+			})
+			return result, nil, err
+		})
+}
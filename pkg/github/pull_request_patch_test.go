@@ -0,0 +1,382 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFormatPatch = `From 1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa Mon Sep 17 00:00:00 2001
+From: Mona Lisa <mona@example.com>
+Date: Tue, 2 Jan 2024 15:04:05 +0000
+Subject: [PATCH 1/2] Update the README greeting
+
+Say hello to everyone.
+---
+ README.md | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+@@ -3,2 +3,2 @@
+ # Hello
+-Hello world
++Hello everyone
+-- 
+2.43.0
+
+From 2222222bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb Mon Sep 17 00:00:00 2001
+From: =?UTF-8?q?Ren=C3=A9?= <rene@example.com>
+Date: Tue, 2 Jan 2024 16:04:05 +0000
+Subject: [PATCH 2/2] Add docs and
+ log on startup
+
+---
+ cmd/main.go  | 1 +
+ docs/new.md  | 1 +
+ 2 files changed, 2 insertions(+)
+
+diff --git a/cmd/main.go b/cmd/main.go
+index 5d6e7b2..8a4f5c3 100755
+--- a/cmd/main.go
++++ b/cmd/main.go
+@@ -1,2 +1,3 @@
+ package main
++// starts the server
+ func main() {}
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..8a4f5c3
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# Docs
+\ No newline at end of file
+-- 
+2.43.0
+`
+
+func Test_ParsePatchSeries(t *testing.T) {
+	t.Run("format-patch series", func(t *testing.T) {
+		commits, err := parsePatchSeries(testFormatPatch, "ignored")
+		require.NoError(t, err)
+		require.Len(t, commits, 2)
+
+		assert.Equal(t, "Update the README greeting\n\nSay hello to everyone.", commits[0].Message)
+		assert.Equal(t, "Mona Lisa", commits[0].Author.GetName())
+		assert.Equal(t, "mona@example.com", commits[0].Author.GetEmail())
+		assert.Equal(t, "2024-01-02T15:04:05Z", commits[0].Author.GetDate().UTC().Format("2006-01-02T15:04:05Z"))
+		require.Len(t, commits[0].Files, 1)
+		assert.Equal(t, "README.md", commits[0].Files[0].Path)
+		require.Len(t, commits[0].Files[0].Hunks, 1)
+		// The "-- " signature line must not be parsed as a removed line.
+		assert.Len(t, commits[0].Files[0].Hunks[0].Lines, 3)
+
+		assert.Equal(t, "Add docs and log on startup", commits[1].Message)
+		assert.Equal(t, "René", commits[1].Author.GetName())
+		require.Len(t, commits[1].Files, 2)
+		assert.Equal(t, "docs/new.md", commits[1].Files[1].Path)
+		assert.Equal(t, "added", commits[1].Files[1].Status)
+		assert.Equal(t, "100644", commits[1].Files[1].Mode)
+		assert.True(t, commits[1].Files[1].Hunks[0].newNoNewline)
+	})
+
+	t.Run("plain diff", func(t *testing.T) {
+		commits, err := parsePatchSeries("diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-a\n+b\n", "Change a")
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		assert.Equal(t, "Change a", commits[0].Message)
+		assert.Nil(t, commits[0].Author)
+		require.Len(t, commits[0].Files, 1)
+	})
+
+	t.Run("no file diffs", func(t *testing.T) {
+		_, err := parsePatchSeries("just some text", "message")
+		assert.EqualError(t, err, "patch does not contain any file diffs")
+	})
+}
+
+func Test_ApplyHunks(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		patch            string
+		expected         string
+		expectedFailures []string
+	}{
+		{
+			name:     "hunk at its header position",
+			content:  "a\nb\nc\n",
+			patch:    "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c",
+			expected: "a\nB\nc\n",
+		},
+		{
+			name:     "hunk with an offset",
+			content:  "x\ny\na\nb\nc\n",
+			patch:    "@@ -1,3 +1,4 @@\n a\n-b\n+B\n+B2\n c\n@@ -5 +6 @@\n-z\n+Z",
+			expected: "x\ny\na\nB\nB2\nc\n",
+			expectedFailures: []string{
+				"@@ -5 +6 @@: the lines to change do not match the file",
+			},
+		},
+		{
+			name:     "insertion after a line",
+			content:  "a\nb\n",
+			patch:    "@@ -1,0 +2 @@\n+inserted",
+			expected: "a\ninserted\nb\n",
+		},
+		{
+			name:     "new file without trailing newline",
+			content:  "",
+			patch:    "@@ -0,0 +1,2 @@\n+one\n+two\n\\ No newline at end of file",
+			expected: "one\ntwo",
+		},
+		{
+			name:     "adds trailing newline",
+			content:  "a\nb",
+			patch:    "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "keeps missing trailing newline away from the change",
+			content:  "a\nb\nc\nd",
+			patch:    "@@ -1,2 +1,2 @@\n-a\n+A\n b",
+			expected: "A\nb\nc\nd",
+		},
+		{
+			name:     "context does not match",
+			content:  "a\nb\nc\n",
+			patch:    "@@ -1,2 +1,2 @@\n a\n-x\n+y",
+			expected: "a\nb\nc\n",
+			expectedFailures: []string{
+				"@@ -1,2 +1,2 @@: the lines to change do not match the file",
+			},
+		},
+		{
+			name:     "blank context line with its whitespace stripped",
+			content:  "a\n\nb\nc\n",
+			patch:    "@@ -1,4 +1,4 @@\n a\n\n-b\n+B\n c\n",
+			expected: "a\n\nB\nc\n",
+		},
+		{
+			name:     "deletes all lines",
+			content:  "a\nb\n",
+			patch:    "@@ -1,2 +0,0 @@\n-a\n-b",
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hunks, err := parsePatch(tc.patch)
+			require.NoError(t, err)
+
+			result, failures := applyHunks(tc.content, hunks)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedFailures, failures)
+		})
+	}
+}
+
+func Test_CreatePullRequestFromPatch(t *testing.T) {
+	t.Parallel()
+
+	serverTool := CreatePullRequestFromPatch(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_pull_request_from_patch", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "base", "head", "patch", "title"})
+
+	trees := map[string]*github.Tree{
+		"tree-root": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("blob-readme")},
+			{Path: github.Ptr("cmd"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("tree-cmd")},
+		}},
+		"tree-cmd": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr("main.go"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("blob-main")},
+		}},
+	}
+	blobs := map[string]string{
+		"blob-readme": "Intro\n\n# Hello\nHello world\n",
+		"blob-main":   "package main\nfunc main() {}\n",
+	}
+
+	readHandlers := func(t *testing.T) map[string]http.HandlerFunc {
+		return map[string]http.HandlerFunc{
+			GetReposGitRefByOwnerByRepoByRef: expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+				mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+			),
+			GetReposGitCommitsByOwnerByRepoByCommitSHA: expectPath(t, "/repos/owner/repo/git/commits/base-sha").andThen(
+				mockResponse(t, http.StatusOK, &github.Commit{SHA: github.Ptr("base-sha"), Tree: &github.Tree{SHA: github.Ptr("tree-root")}}),
+			),
+			GetReposGitTreesByOwnerByRepoByTree: func(w http.ResponseWriter, r *http.Request) {
+				tree, ok := trees[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				mockResponse(t, http.StatusOK, tree)(w, r)
+			},
+			GetReposGitBlobsByOwnerByRepoByFileSHA: func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, blobs[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]])(w, r)
+			},
+		}
+	}
+
+	t.Run("applies a format-patch series and opens the pull request", func(t *testing.T) {
+		t.Parallel()
+
+		var createdTrees []map[string]any
+		var createdCommits []map[string]any
+		handlers := readHandlers(t)
+		handlers[PostReposGitTreesByOwnerByRepo] = func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			createdTrees = append(createdTrees, body)
+			mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("tree-" + string(rune('0'+len(createdTrees))))})(w, r)
+		}
+		handlers[PostReposGitCommitsByOwnerByRepo] = func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			createdCommits = append(createdCommits, body)
+			sha := "commit-" + string(rune('0'+len(createdCommits)))
+			mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr(sha), Tree: &github.Tree{SHA: github.Ptr("tree-" + string(rune('0'+len(createdCommits))))}})(w, r)
+		}
+		handlers[PostReposGitRefsByOwnerByRepo] = expectRequestBody(t, map[string]any{
+			"ref": "refs/heads/feature",
+			"sha": "commit-2",
+		}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/feature")}))
+		handlers[PostReposPullsByOwnerByRepo] = expectRequestBody(t, map[string]any{
+			"title": "Improve greeting",
+			"head":  "feature",
+			"base":  "main",
+			"body":  "",
+			"draft": true,
+		}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+			Number:  github.Ptr(7),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/7"),
+		}))
+
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"base":  "main",
+			"head":  "feature",
+			"patch": testFormatPatch,
+			"title": "Improve greeting",
+			"draft": true,
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, map[string]any{
+			"number":  float64(7),
+			"url":     "https://github.com/owner/repo/pull/7",
+			"branch":  "feature",
+			"commits": []any{"commit-1", "commit-2"},
+		}, response)
+
+		require.Len(t, createdTrees, 2)
+		assert.Equal(t, "tree-root", createdTrees[0]["base_tree"])
+		assert.Equal(t, []any{
+			map[string]any{"path": "README.md", "mode": "100644", "type": "blob", "content": "Intro\n\n# Hello\nHello everyone\n"},
+		}, createdTrees[0]["tree"])
+		assert.Equal(t, "tree-1", createdTrees[1]["base_tree"])
+		assert.Equal(t, []any{
+			map[string]any{"path": "cmd/main.go", "mode": "100755", "type": "blob", "content": "package main\n// starts the server\nfunc main() {}\n"},
+			map[string]any{"path": "docs/new.md", "mode": "100644", "type": "blob", "content": "# Docs"},
+		}, createdTrees[1]["tree"])
+
+		require.Len(t, createdCommits, 2)
+		assert.Equal(t, "Update the README greeting\n\nSay hello to everyone.", createdCommits[0]["message"])
+		assert.Equal(t, []any{"base-sha"}, createdCommits[0]["parents"])
+		assert.Equal(t, "Mona Lisa", createdCommits[0]["author"].(map[string]any)["name"])
+		assert.Equal(t, []any{"commit-1"}, createdCommits[1]["parents"])
+	})
+
+	t.Run("reports hunks that do not apply without creating anything", func(t *testing.T) {
+		t.Parallel()
+
+		patch := "diff --git a/README.md b/README.md\n" +
+			"--- a/README.md\n" +
+			"+++ b/README.md\n" +
+			"@@ -1,2 +1,2 @@\n" +
+			" # Hello\n" +
+			"-Goodbye world\n" +
+			"+Goodbye everyone\n" +
+			"diff --git a/missing.go b/missing.go\n" +
+			"--- a/missing.go\n" +
+			"+++ b/missing.go\n" +
+			"@@ -1 +1 @@\n" +
+			"-a\n" +
+			"+b\n" +
+			"diff --git a/cmd/main.go b/cmd/main.go\n" +
+			"new file mode 100644\n" +
+			"--- /dev/null\n" +
+			"+++ b/cmd/main.go\n" +
+			"@@ -0,0 +1 @@\n" +
+			"+package main\n"
+
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(readHandlers(t)))}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"base":  "main",
+			"head":  "feature",
+			"patch": patch,
+			"title": "Say goodbye",
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Equal(t, "the patch does not apply to main, so nothing was created:\n"+
+			"- README.md: @@ -1,2 +1,2 @@: the lines to change do not match the file\n"+
+			"- missing.go: file does not exist\n"+
+			"- cmd/main.go: file already exists", textContent.Text)
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		t.Parallel()
+
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"base":  "main",
+			"head":  "feature",
+			"patch": "not a patch",
+			"title": "Nothing",
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Equal(t, "failed to parse patch: patch does not contain any file diffs", textContent.Text)
+	})
+}
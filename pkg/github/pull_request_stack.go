@@ -0,0 +1,503 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxPullRequestStackPages limits stack discovery to the first 1000 open pull requests of a repository.
+const maxPullRequestStackPages = 10
+
+// Outcomes of updating the branch of a layer of a pull request stack.
+const (
+	stackUpdateUpdated    = "updated"
+	stackUpdateUpToDate   = "up_to_date"
+	stackUpdateInProgress = "in_progress"
+	stackUpdateConflict   = "conflict"
+	stackUpdateFailed     = "failed"
+	stackUpdateSkipped    = "skipped"
+)
+
+// pullRequestStackQuery fetches the open pull requests of a repository with what is needed to
+// arrange them into stacks and to summarize the status of each layer.
+type pullRequestStackQuery struct {
+	Repository struct {
+		PullRequests struct {
+			Nodes    []pullRequestStackNode
+			PageInfo struct {
+				HasNextPage githubv4.Boolean
+				EndCursor   githubv4.String
+			}
+		} `graphql:"pullRequests(states: OPEN, first: 100, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+type pullRequestStackNode struct {
+	Number            githubv4.Int
+	Title             githubv4.String
+	URL               githubv4.URI
+	IsDraft           githubv4.Boolean
+	IsCrossRepository githubv4.Boolean
+	BaseRefName       githubv4.String
+	HeadRefName       githubv4.String
+	HeadRefOid        githubv4.String
+	Mergeable         githubv4.String
+	MergeStateStatus  githubv4.String
+	ReviewDecision    *githubv4.String
+	Commits           struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State githubv4.String
+				}
+			}
+		}
+	} `graphql:"commits(last: 1)"`
+}
+
+// PullRequestStackLayer is a pull request in a stack.
+type PullRequestStackLayer struct {
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	URL              string `json:"url"`
+	Base             string `json:"base"`
+	Head             string `json:"head"`
+	HeadSHA          string `json:"head_sha"`
+	Parent           int    `json:"parent,omitempty"`
+	Depth            int    `json:"depth"`
+	IsDraft          bool   `json:"is_draft"`
+	Mergeable        string `json:"mergeable"`
+	MergeStateStatus string `json:"merge_state_status"`
+	ReviewDecision   string `json:"review_decision,omitempty"`
+	Checks           string `json:"checks,omitempty"`
+}
+
+// PullRequestStack is a set of pull requests where each one targets the head branch of the one
+// below it. Layers are ordered from the bottom of the stack to the top, parents before children.
+type PullRequestStack struct {
+	Base   string                  `json:"base"`
+	Layers []PullRequestStackLayer `json:"layers"`
+}
+
+// PullRequestStackUpdate is the outcome of updating the branch of one layer of a stack.
+type PullRequestStackUpdate struct {
+	Number  int    `json:"number"`
+	Status  string `json:"status"`
+	HeadSHA string `json:"head_sha,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// newPullRequestStackLayer converts a pull request of a stack query to a stack layer.
+func newPullRequestStackLayer(node pullRequestStackNode, parent, depth int) PullRequestStackLayer {
+	layer := PullRequestStackLayer{
+		Number:           int(node.Number),
+		Title:            string(node.Title),
+		URL:              node.URL.String(),
+		Base:             string(node.BaseRefName),
+		Head:             string(node.HeadRefName),
+		HeadSHA:          string(node.HeadRefOid),
+		Parent:           parent,
+		Depth:            depth,
+		IsDraft:          bool(node.IsDraft),
+		Mergeable:        string(node.Mergeable),
+		MergeStateStatus: string(node.MergeStateStatus),
+	}
+	if node.ReviewDecision != nil {
+		layer.ReviewDecision = string(*node.ReviewDecision)
+	}
+	if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		layer.Checks = string(node.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+	}
+	return layer
+}
+
+// buildPullRequestStack finds the stack containing a pull request among the open pull requests
+// of a repository. A pull request is stacked on another one when its base branch is the head
+// branch of the other one. Pull requests from forks can be stacked on others, but nothing can be
+// stacked on them.
+func buildPullRequestStack(nodes []pullRequestStackNode, pullNumber int) (*PullRequestStack, error) {
+	byHead := make(map[string]pullRequestStackNode)
+	byBase := make(map[string][]pullRequestStackNode)
+	var target *pullRequestStackNode
+	for i, node := range nodes {
+		if int(node.Number) == pullNumber {
+			target = &nodes[i]
+		}
+		if _, exists := byHead[string(node.HeadRefName)]; !exists && !bool(node.IsCrossRepository) {
+			byHead[string(node.HeadRefName)] = node
+		}
+		byBase[string(node.BaseRefName)] = append(byBase[string(node.BaseRefName)], node)
+	}
+	if target == nil {
+		return nil, fmt.Errorf("pull request #%d is not open", pullNumber)
+	}
+
+	root := *target
+	seen := map[int]bool{int(root.Number): true}
+	for {
+		parent, ok := byHead[string(root.BaseRefName)]
+		if !ok || seen[int(parent.Number)] {
+			break
+		}
+		seen[int(parent.Number)] = true
+		root = parent
+	}
+
+	stack := &PullRequestStack{Base: string(root.BaseRefName)}
+	visited := make(map[int]bool)
+	var visit func(node pullRequestStackNode, parent, depth int)
+	visit = func(node pullRequestStackNode, parent, depth int) {
+		visited[int(node.Number)] = true
+		stack.Layers = append(stack.Layers, newPullRequestStackLayer(node, parent, depth))
+		if bool(node.IsCrossRepository) {
+			return
+		}
+		children := slices.Clone(byBase[string(node.HeadRefName)])
+		slices.SortFunc(children, func(a, b pullRequestStackNode) int { return int(a.Number) - int(b.Number) })
+		for _, child := range children {
+			if !visited[int(child.Number)] {
+				visit(child, int(node.Number), depth+1)
+			}
+		}
+	}
+	visit(root, 0, 0)
+	return stack, nil
+}
+
+// findPullRequestStack fetches the open pull requests of a repository and finds the stack
+// containing a pull request.
+func findPullRequestStack(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) (*PullRequestStack, error) {
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"after": (*githubv4.String)(nil),
+	}
+
+	var nodes []pullRequestStackNode
+	for range maxPullRequestStackPages {
+		var query pullRequestStackQuery
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, query.Repository.PullRequests.Nodes...)
+		if !query.Repository.PullRequests.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = query.Repository.PullRequests.PageInfo.EndCursor
+	}
+	return buildPullRequestStack(nodes, pullNumber)
+}
+
+// GetPullRequestStack creates a tool to discover the stack a pull request belongs to.
+func GetPullRequestStack(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "get_pull_request_stack",
+			Description: t("TOOL_GET_PULL_REQUEST_STACK_DESCRIPTION", "Get the stack of pull requests a pull request belongs to. Pull requests are stacked when one targets the head branch of another. Returns every open pull request of the stack from the bottom to the top, parents before their children, with the parent, depth, mergeability, review decision and check status of each layer. A 'merge_state_status' of 'BEHIND' means the layer needs to be updated with its base."),
+			Icons:       octicons.Icons("git-pull-request"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_PULL_REQUEST_STACK_USER_TITLE", "Get pull request stack"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Number of any open pull request of the stack",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+			stack, err := findPullRequestStack(ctx, gqlClient, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request stack", err), nil, nil
+			}
+
+			result, err := utils.NewToolResultJSON(stack)
+			return result, nil, err
+		},
+	)
+}
+
+// UpdatePullRequestStack creates a tool to keep a stack of pull requests up to date.
+func UpdatePullRequestStack(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "update_pull_request_stack",
+			Description: t("TOOL_UPDATE_PULL_REQUEST_STACK_DESCRIPTION", "Maintain a stack of pull requests, where each pull request targets the head branch of the one below it. Use 'get_pull_request_stack' to see the stack first."),
+			Icons:       octicons.Icons("git-pull-request"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_PULL_REQUEST_STACK_USER_TITLE", "Update pull request stack"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The action to perform:
+- 'retarget_children': after 'pullNumber' was merged, change the base of the open pull requests stacked on it to the base branch it was merged into.
+- 'update_branches': update the branch of every pull request of the stack containing 'pullNumber' with its base, from the bottom of the stack to the top, like 'update_pull_request_branch'. Stops at the first pull request that cannot be updated, for example because of a merge conflict, and reports the outcome for every pull request.`,
+						Enum: []any{"retarget_children", "update_branches"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "For 'retarget_children', the merged pull request. For 'update_branches', any open pull request of the stack.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "retarget_children":
+				result, err := RetargetStackedPullRequests(ctx, client, owner, repo, pullNumber)
+				return result, nil, err
+			case "update_branches":
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+				}
+				result, err := UpdatePullRequestStackBranches(ctx, client, gqlClient, owner, repo, pullNumber)
+				return result, nil, err
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: retarget_children, update_branches", method)), nil, nil
+			}
+		},
+	)
+}
+
+// RetargetStackedPullRequests changes the base of the open pull requests stacked on a merged
+// pull request to the branch it was merged into.
+func RetargetStackedPullRequests(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	parent, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+	}
+	_ = resp.Body.Close()
+	if !parent.GetMerged() {
+		return utils.NewToolResultError(fmt.Sprintf("pull request #%d is not merged; only pull requests stacked on a merged pull request can be retargeted", pullNumber)), nil
+	}
+	newBase := parent.GetBase().GetRef()
+
+	var children []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        parent.GetHead().GetRef(),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list stacked pull requests", resp, err), nil
+		}
+		_ = resp.Body.Close()
+		children = append(children, prs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	retargeted := make([]map[string]any, 0, len(children))
+	for _, child := range children {
+		updated, resp, err := client.PullRequests.Edit(ctx, owner, repo, child.GetNumber(), &github.PullRequest{
+			Base: &github.PullRequestBranch{Ref: github.Ptr(newBase)},
+		})
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to retarget pull request #%d after retargeting %d of %d pull requests", child.GetNumber(), len(retargeted), len(children)),
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+		retargeted = append(retargeted, map[string]any{
+			"number": updated.GetNumber(),
+			"url":    updated.GetHTMLURL(),
+		})
+	}
+
+	return utils.NewToolResultJSON(map[string]any{
+		"merged":     pullNumber,
+		"base":       newBase,
+		"retargeted": retargeted,
+	})
+}
+
+// UpdatePullRequestStackBranches updates the branch of every pull request of a stack with its
+// base, parents before children so that each layer picks up the update of the one below it.
+// It stops at the first layer that cannot be updated.
+func UpdatePullRequestStackBranches(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	stack, err := findPullRequestStack(ctx, gqlClient, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request stack", err), nil
+	}
+
+	updates := make([]PullRequestStackUpdate, 0, len(stack.Layers))
+	updated := make(map[int]bool)
+	var stoppedAt int
+	for _, layer := range stack.Layers {
+		if stoppedAt != 0 {
+			updates = append(updates, PullRequestStackUpdate{Number: layer.Number, Status: stackUpdateSkipped})
+			continue
+		}
+		// Mergeability of children is computed against the old head of their parent, so a layer
+		// whose parent was just updated needs an update even if it does not look behind.
+		if layer.MergeStateStatus != "BEHIND" && !updated[layer.Parent] {
+			updates = append(updates, PullRequestStackUpdate{Number: layer.Number, Status: stackUpdateUpToDate, HeadSHA: layer.HeadSHA})
+			continue
+		}
+
+		update, errResult := updatePullRequestStackLayer(ctx, client, owner, repo, layer)
+		if errResult != nil {
+			return errResult, nil
+		}
+		updates = append(updates, update)
+		switch update.Status {
+		case stackUpdateUpdated:
+			updated[layer.Number] = true
+		case stackUpdateUpToDate:
+		default:
+			stoppedAt = layer.Number
+		}
+	}
+
+	result := map[string]any{
+		"base":   stack.Base,
+		"layers": updates,
+	}
+	if stoppedAt != 0 {
+		result["stopped_at"] = stoppedAt
+		result["message"] = fmt.Sprintf("Stopped at pull request #%d. Once it is resolved, run 'update_branches' again to update the rest of the stack.", stoppedAt)
+	}
+	return utils.NewToolResultJSON(result)
+}
+
+// updatePullRequestStackLayer updates the branch of one layer of a stack and waits for the update
+// to land, so that the layers stacked on it are updated with the new head.
+func updatePullRequestStackLayer(ctx context.Context, client *github.Client, owner, repo string, layer PullRequestStackLayer) (PullRequestStackUpdate, *mcp.CallToolResult) {
+	update := PullRequestStackUpdate{Number: layer.Number}
+	_, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, layer.Number, &github.PullRequestBranchUpdateOptions{
+		ExpectedHeadSHA: github.Ptr(layer.HeadSHA),
+	})
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusAccepted || !isAcceptedError(err)) {
+		var errResp *github.ErrorResponse
+		if resp == nil || resp.StatusCode != http.StatusUnprocessableEntity || !errors.As(err, &errResp) {
+			return update, ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to update branch of pull request #%d", layer.Number),
+				resp,
+				err,
+			)
+		}
+		message := strings.ToLower(errResp.Message)
+		switch {
+		case strings.Contains(message, "no new commits"):
+			update.Status = stackUpdateUpToDate
+			update.HeadSHA = layer.HeadSHA
+		case strings.Contains(message, "conflict"):
+			update.Status = stackUpdateConflict
+			update.Error = errResp.Message
+		default:
+			update.Status = stackUpdateFailed
+			update.Error = errResp.Message
+		}
+		return update, nil
+	}
+
+	pollConfig := getPollConfig(ctx)
+	for attempt := range pollConfig.MaxAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return update, utils.NewToolResultErrorFromErr(fmt.Sprintf("stopped waiting for the branch of pull request #%d to update", layer.Number), ctx.Err())
+			case <-time.After(pollConfig.Delay):
+			}
+		}
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, layer.Number)
+		if err != nil {
+			// Polling errors are non-fatal, continue to next attempt
+			continue
+		}
+		_ = resp.Body.Close()
+		if sha := pr.GetHead().GetSHA(); sha != layer.HeadSHA {
+			update.Status = stackUpdateUpdated
+			update.HeadSHA = sha
+			return update, nil
+		}
+	}
+
+	update.Status = stackUpdateInProgress
+	update.Error = "the update is still in progress, so the pull requests stacked on it cannot be updated yet"
+	return update, nil
+}
@@ -0,0 +1,386 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stackPullRequestNode(number int, base, head, mergeStateStatus string, crossRepository bool) map[string]any {
+	return map[string]any{
+		"number":            number,
+		"title":             "Layer " + head,
+		"url":               fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+		"isDraft":           false,
+		"isCrossRepository": crossRepository,
+		"baseRefName":       base,
+		"headRefName":       head,
+		"headRefOid":        "sha-" + head,
+		"mergeable":         "MERGEABLE",
+		"mergeStateStatus":  mergeStateStatus,
+		"reviewDecision":    "APPROVED",
+		"commits": map[string]any{
+			"nodes": []any{
+				map[string]any{"commit": map[string]any{"statusCheckRollup": map[string]any{"state": "SUCCESS"}}},
+			},
+		},
+	}
+}
+
+func stackQueryMatcher(nodes ...any) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		pullRequestStackQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"after": (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequests": map[string]any{
+					"nodes":    nodes,
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				},
+			},
+		}),
+	)
+}
+
+func Test_BuildPullRequestStack(t *testing.T) {
+	node := func(number int, base, head string, crossRepository bool) pullRequestStackNode {
+		return pullRequestStackNode{
+			Number:            githubv4.Int(number),
+			BaseRefName:       githubv4.String(base),
+			HeadRefName:       githubv4.String(head),
+			IsCrossRepository: githubv4.Boolean(crossRepository),
+			URL:               githubv4.URI{URL: &url.URL{Scheme: "https", Host: "github.com", Path: fmt.Sprintf("/owner/repo/pull/%d", number)}},
+		}
+	}
+	nodes := []pullRequestStackNode{
+		node(3, "feature-b", "feature-c", false),
+		node(4, "feature-a", "feature-d", false),
+		node(2, "feature-a", "feature-b", false),
+		node(1, "main", "feature-a", false),
+		node(5, "main", "other", false),
+		// A pull request from a fork whose branch has the same name as a branch of the stack.
+		node(6, "feature-b", "feature-a", true),
+		node(7, "feature-a", "feature-e", true),
+	}
+
+	stack, err := buildPullRequestStack(nodes, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "main", stack.Base)
+
+	type position struct{ number, parent, depth int }
+	var positions []position
+	for _, layer := range stack.Layers {
+		positions = append(positions, position{layer.Number, layer.Parent, layer.Depth})
+	}
+	assert.Equal(t, []position{
+		{1, 0, 0},
+		{2, 1, 1},
+		{3, 2, 2},
+		{6, 2, 2},
+		{4, 1, 1},
+		{7, 1, 1},
+	}, positions)
+
+	stack, err = buildPullRequestStack(nodes, 5)
+	require.NoError(t, err)
+	require.Len(t, stack.Layers, 1)
+	assert.Equal(t, 5, stack.Layers[0].Number)
+
+	cyclic := []pullRequestStackNode{node(1, "b", "a", false), node(2, "a", "b", false)}
+	stack, err = buildPullRequestStack(cyclic, 1)
+	require.NoError(t, err)
+	assert.Len(t, stack.Layers, 2)
+
+	_, err = buildPullRequestStack(nodes, 42)
+	assert.EqualError(t, err, "pull request #42 is not open")
+}
+
+func Test_GetPullRequestStack(t *testing.T) {
+	serverTool := GetPullRequestStack(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_stack", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	t.Run("returns the stack in order", func(t *testing.T) {
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(stackQueryMatcher(
+			stackPullRequestNode(2, "feature-a", "feature-b", "BEHIND", false),
+			stackPullRequestNode(1, "main", "feature-a", "CLEAN", false),
+			stackPullRequestNode(5, "main", "other", "CLEAN", false),
+		)))
+		deps := BaseDeps{GQLClient: gqlClient}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(2),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var stack PullRequestStack
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &stack))
+		assert.Equal(t, PullRequestStack{
+			Base: "main",
+			Layers: []PullRequestStackLayer{
+				{
+					Number: 1, Title: "Layer feature-a", URL: "https://github.com/owner/repo/pull/1",
+					Base: "main", Head: "feature-a", HeadSHA: "sha-feature-a", Depth: 0,
+					Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN", ReviewDecision: "APPROVED", Checks: "SUCCESS",
+				},
+				{
+					Number: 2, Title: "Layer feature-b", URL: "https://github.com/owner/repo/pull/2",
+					Base: "feature-a", Head: "feature-b", HeadSHA: "sha-feature-b", Parent: 1, Depth: 1,
+					Mergeable: "MERGEABLE", MergeStateStatus: "BEHIND", ReviewDecision: "APPROVED", Checks: "SUCCESS",
+				},
+			},
+		}, stack)
+	})
+
+	t.Run("pull request is not open", func(t *testing.T) {
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(stackQueryMatcher(
+			stackPullRequestNode(1, "main", "feature-a", "CLEAN", false),
+		)))
+		deps := BaseDeps{GQLClient: gqlClient}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(9),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Contains(t, textContent.Text, "pull request #9 is not open")
+	})
+}
+
+func Test_UpdatePullRequestStack(t *testing.T) {
+	serverTool := UpdatePullRequestStack(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_pull_request_stack", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "pullNumber"})
+	assert.ElementsMatch(t, schema.Properties["method"].Enum, []any{"retarget_children", "update_branches"})
+
+	pullNumberFromPath := func(path string) string {
+		path = strings.TrimSuffix(path, "/update-branch")
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+
+	t.Run("retarget_children", func(t *testing.T) {
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber: expectPath(t, "/repos/owner/repo/pulls/1").andThen(
+				mockResponse(t, http.StatusOK, &github.PullRequest{
+					Number: github.Ptr(1),
+					Merged: github.Ptr(true),
+					Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+					Head:   &github.PullRequestBranch{Ref: github.Ptr("feature-a")},
+				}),
+			),
+			GetReposPullsByOwnerByRepo: expectQueryParams(t, map[string]string{
+				"state":    "open",
+				"base":     "feature-a",
+				"per_page": "100",
+			}).andThen(mockResponse(t, http.StatusOK, []*github.PullRequest{
+				{Number: github.Ptr(2)},
+				{Number: github.Ptr(4)},
+			})),
+			PatchReposPullsByOwnerByRepoByPullNumber: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]any{"base": "main"}, body)
+				number := pullNumberFromPath(r.URL.Path)
+				mockResponse(t, http.StatusOK, map[string]any{
+					"number":   json.Number(number),
+					"html_url": "https://github.com/owner/repo/pull/" + number,
+				})(w, r)
+			},
+		})
+
+		deps := BaseDeps{Client: github.NewClient(mockedClient)}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":     "retarget_children",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(1),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, map[string]any{
+			"merged": float64(1),
+			"base":   "main",
+			"retargeted": []any{
+				map[string]any{"number": float64(2), "url": "https://github.com/owner/repo/pull/2"},
+				map[string]any{"number": float64(4), "url": "https://github.com/owner/repo/pull/4"},
+			},
+		}, response)
+	})
+
+	t.Run("retarget_children of an unmerged pull request", func(t *testing.T) {
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, &github.PullRequest{
+				Number: github.Ptr(1),
+				Merged: github.Ptr(false),
+			}),
+		})
+
+		deps := BaseDeps{Client: github.NewClient(mockedClient)}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":     "retarget_children",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(1),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Equal(t, "pull request #1 is not merged; only pull requests stacked on a merged pull request can be retargeted", textContent.Text)
+	})
+
+	t.Run("update_branches stops at the first conflict", func(t *testing.T) {
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(stackQueryMatcher(
+			stackPullRequestNode(1, "main", "feature-a", "BEHIND", false),
+			stackPullRequestNode(2, "feature-a", "feature-b", "CLEAN", false),
+			stackPullRequestNode(3, "feature-b", "feature-c", "CLEAN", false),
+			stackPullRequestNode(4, "feature-a", "feature-d", "CLEAN", false),
+			stackPullRequestNode(5, "feature-c", "feature-e", "CLEAN", false),
+			stackPullRequestNode(6, "feature-d", "feature-f", "BEHIND", false),
+		)))
+
+		var updatedPullRequests []string
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			PutReposPullsUpdateBranchByOwnerByRepoByPullNumber: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				number := pullNumberFromPath(r.URL.Path)
+				updatedPullRequests = append(updatedPullRequests, number)
+				switch number {
+				case "1":
+					assert.Equal(t, map[string]any{"expected_head_sha": "sha-feature-a"}, body)
+					mockResponse(t, http.StatusAccepted, map[string]any{"message": "Updating pull request branch."})(w, r)
+				case "2":
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]any{"message": "There are no new commits on the base branch."})(w, r)
+				case "4":
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]any{"message": "merge conflict between base and head"})(w, r)
+				default:
+					t.Errorf("unexpected update of pull request #%s", number)
+				}
+			},
+			GetReposPullsByOwnerByRepoByPullNumber: expectPath(t, "/repos/owner/repo/pulls/1").andThen(
+				mockResponse(t, http.StatusOK, &github.PullRequest{
+					Number: github.Ptr(1),
+					Head:   &github.PullRequestBranch{SHA: github.Ptr("new-sha-feature-a")},
+				}),
+			),
+		})
+
+		deps := BaseDeps{Client: github.NewClient(mockedClient), GQLClient: gqlClient}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":     "update_branches",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(3),
+		})
+		ctx := ContextWithPollConfig(ContextWithDeps(context.Background(), deps), PollConfig{MaxAttempts: 2})
+		result, err := handler(ctx, &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, map[string]any{
+			"base": "main",
+			"layers": []any{
+				map[string]any{"number": float64(1), "status": "updated", "head_sha": "new-sha-feature-a"},
+				map[string]any{"number": float64(2), "status": "up_to_date", "head_sha": "sha-feature-b"},
+				map[string]any{"number": float64(3), "status": "up_to_date", "head_sha": "sha-feature-c"},
+				map[string]any{"number": float64(5), "status": "up_to_date", "head_sha": "sha-feature-e"},
+				map[string]any{"number": float64(4), "status": "conflict", "error": "merge conflict between base and head"},
+				map[string]any{"number": float64(6), "status": "skipped"},
+			},
+			"stopped_at": float64(4),
+			"message":    "Stopped at pull request #4. Once it is resolved, run 'update_branches' again to update the rest of the stack.",
+		}, response)
+		assert.Equal(t, []string{"1", "2", "4"}, updatedPullRequests)
+	})
+
+	t.Run("update_branches stops waiting when the request is cancelled", func(t *testing.T) {
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(stackQueryMatcher(
+			stackPullRequestNode(1, "main", "feature-a", "BEHIND", false),
+			stackPullRequestNode(2, "feature-a", "feature-b", "CLEAN", false),
+		)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			PutReposPullsUpdateBranchByOwnerByRepoByPullNumber: mockResponse(t, http.StatusAccepted, map[string]any{"message": "Updating pull request branch."}),
+			GetReposPullsByOwnerByRepoByPullNumber: func(w http.ResponseWriter, r *http.Request) {
+				// The update has not landed yet when the client goes away.
+				cancel()
+				mockResponse(t, http.StatusOK, &github.PullRequest{
+					Number: github.Ptr(1),
+					Head:   &github.PullRequestBranch{SHA: github.Ptr("sha-feature-a")},
+				})(w, r)
+			},
+		})
+
+		deps := BaseDeps{Client: github.NewClient(mockedClient), GQLClient: gqlClient}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":     "update_branches",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(2),
+		})
+		ctx = ContextWithPollConfig(ContextWithDeps(ctx, deps), PollConfig{MaxAttempts: 5, Delay: time.Hour})
+		result, err := handler(ctx, &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.True(t, result.IsError)
+		assert.Contains(t, textContent.Text, "stopped waiting for the branch of pull request #1 to update")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v79/github"
@@ -31,14 +32,19 @@ func PullRequestRead(t translations.TranslationHelperFunc) inventory.ServerTool
 				Description: `Action to specify what pull request data needs to be retrieved from GitHub. 
 Possible options: 
  1. get - Get details of a specific pull request.
- 2. get_diff - Get the diff of a pull request.
+ 2. get_diff - Get the diff of a pull request. Use 'base_sha' (and optionally 'head_sha') to only get the changes made by a range of its commits.
  3. get_status - Get status of a head commit in a pull request. This reflects status of builds and checks.
- 4. get_files - Get the list of files changed in a pull request. Use with pagination parameters to control the number of results returned.
+ 4. get_files - Get the list of files changed in a pull request. Use with pagination parameters to control the number of results returned. Use 'base_sha' (and optionally 'head_sha') to only get the files changed by a range of its commits.
  5. get_review_comments - Get review threads on a pull request. Each thread contains logically grouped review comments made on the same code location during pull request reviews. Returns threads with metadata (isResolved, isOutdated, isCollapsed) and their associated comments. Use cursor-based pagination (perPage, after) to control results.
  6. get_reviews - Get the reviews on a pull request. When asked for review comments, use get_review_comments method.
  7. get_comments - Get comments on a pull request. Use this if user doesn't specifically want review comments. Use with pagination parameters to control the number of results returned.
+ 8. get_diff_hunks - Get the diff of a pull request as hunks with explicit old (LEFT) and new (RIGHT) line numbers, and the line ranges of each file that review comments can be placed on. Use before adding inline review comments. Paginated by hunk across files; use 'path' to restrict to a single file.
+ 9. get_merge_readiness - Check whether a pull request can be merged. Combines mergeability, required status checks (missing, failing or pending), required and code owner reviews, unresolved conversations, whether the branch is behind its base, merge queue requirement and allowed merge methods from branch protection and rulesets, with a list of reasons the merge is blocked.
+ 10. get_commits - Get the commits of a pull request, oldest first. Use with pagination parameters to control the number of results returned.
+ 11. get_requested_reviewers - Get the users and teams whose review is requested and who have not reviewed yet.
+ 12. get_code_owners - Get the code owners of the files changed in a pull request, resolved from the CODEOWNERS file of the base branch with GitHub's matching rules. Returns the rule deciding the owners of each file, files without owners, syntax errors in the CODEOWNERS file, and the 'reviewers' and 'team_reviewers' to pass to 'pull_request_reviewers' to request all code owners.
 `,
-				Enum: []any{"get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments"},
+				Enum: []any{"get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments", "get_diff_hunks", "get_merge_readiness", "get_commits", "get_requested_reviewers", "get_code_owners"},
 			},
 			"owner": {
 				Type:        "string",
@@ -52,6 +58,18 @@ Possible options:
 				Type:        "number",
 				Description: "Pull request number",
 			},
+			"path": {
+				Type:        "string",
+				Description: "For get_diff_hunks, only return the hunks of the file with this path",
+			},
+			"base_sha": {
+				Type:        "string",
+				Description: "For get_diff and get_files, only include changes made after this commit of the pull request, e.g. the commit_id of your last review from get_reviews to see the changes since that review",
+			},
+			"head_sha": {
+				Type:        "string",
+				Description: "For get_diff and get_files with base_sha, the last commit of the range. Defaults to the head of the pull request",
+			},
 		},
 		Required: []string{"method", "owner", "repo", "pullNumber"},
 	}
@@ -87,6 +105,17 @@ Possible options:
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			baseSHA, err := OptionalParam[string](args, "base_sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			headSHA, err := OptionalParam[string](args, "head_sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if headSHA != "" && baseSHA == "" {
+				return utils.NewToolResultError("head_sha can only be used together with base_sha"), nil, nil
+			}
 
 			client, err := deps.GetClient(ctx)
 			if err != nil {
@@ -98,14 +127,25 @@ Possible options:
 				result, err := GetPullRequest(ctx, client, deps, owner, repo, pullNumber)
 				return result, nil, err
 			case "get_diff":
+				if baseSHA != "" {
+					result, err := GetPullRequestCommitRangeDiff(ctx, client, owner, repo, pullNumber, baseSHA, headSHA)
+					return result, nil, err
+				}
 				result, err := GetPullRequestDiff(ctx, client, owner, repo, pullNumber)
 				return result, nil, err
 			case "get_status":
 				result, err := GetPullRequestStatus(ctx, client, owner, repo, pullNumber)
 				return result, nil, err
 			case "get_files":
+				if baseSHA != "" {
+					result, err := GetPullRequestCommitRangeFiles(ctx, client, owner, repo, pullNumber, baseSHA, headSHA, pagination)
+					return result, nil, err
+				}
 				result, err := GetPullRequestFiles(ctx, client, owner, repo, pullNumber, pagination)
 				return result, nil, err
+			case "get_commits":
+				result, err := GetPullRequestCommits(ctx, client, owner, repo, pullNumber, pagination)
+				return result, nil, err
 			case "get_review_comments":
 				gqlClient, err := deps.GetGQLClient(ctx)
 				if err != nil {
@@ -123,6 +163,26 @@ Possible options:
 			case "get_comments":
 				result, err := GetIssueComments(ctx, client, deps, owner, repo, pullNumber, pagination)
 				return result, nil, err
+			case "get_diff_hunks":
+				path, err := OptionalParam[string](args, "path")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				result, err := GetPullRequestDiffHunks(ctx, client, owner, repo, pullNumber, path, pagination)
+				return result, nil, err
+			case "get_merge_readiness":
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+				}
+				result, err := GetPullRequestMergeReadiness(ctx, client, gqlClient, owner, repo, pullNumber)
+				return result, nil, err
+			case "get_requested_reviewers":
+				result, err := GetPullRequestRequestedReviewers(ctx, client, owner, repo, pullNumber)
+				return result, nil, err
+			case "get_code_owners":
+				result, err := GetPullRequestCodeOwners(ctx, client, owner, repo, pullNumber)
+				return result, nil, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -214,6 +274,42 @@ func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo
 	return utils.NewToolResultText(string(raw)), nil
 }
 
+func GetPullRequestDiffHunks(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, path string, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	files, resp, err := getPullRequestFileDiffs(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request diff",
+			resp,
+			err,
+		), nil
+	}
+
+	if path != "" {
+		file, ok := diffFilesByPath(files)[path]
+		if !ok {
+			return utils.NewToolResultError(fmt.Sprintf("%s is not changed in pull request #%d", path, pullNumber)), nil
+		}
+		files = []*fileDiff{file}
+	}
+
+	totalHunks := 0
+	for _, file := range files {
+		totalHunks += len(file.Hunks)
+	}
+
+	page, hasNextPage := paginateDiffHunks(files, pagination.Page, pagination.PerPage)
+	response := map[string]any{
+		"files":       page,
+		"total_files": len(files),
+		"total_hunks": totalHunks,
+	}
+	if hasNextPage {
+		response["next_page"] = pagination.Page + 1
+	}
+
+	return utils.NewToolResultJSON(response)
+}
+
 func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
 	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 	if err != nil {
@@ -281,6 +377,157 @@ func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo
 	return utils.NewToolResultJSON(files)
 }
 
+func GetPullRequestCommits(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	opts := &github.ListOptions{
+		PerPage: pagination.PerPage,
+		Page:    pagination.Page,
+	}
+	commits, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullNumber, opts)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request commits",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request commits", resp, body), nil
+	}
+
+	minimalCommits := make([]MinimalCommit, len(commits))
+	for i, commit := range commits {
+		minimalCommits[i] = convertToMinimalCommit(commit, false)
+	}
+
+	return utils.NewToolResultJSON(minimalCommits)
+}
+
+// listAllPullRequestCommits lists the commits of a pull request, oldest first. The API returns
+// at most 250 commits.
+func listAllPullRequestCommits(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) ([]*github.RepositoryCommit, *github.Response, error) {
+	var all []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		commits, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		all = append(all, commits...)
+		if resp.NextPage == 0 {
+			return all, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// resolvePullRequestCommitRange checks that baseSHA and headSHA, which may be abbreviated, are
+// commits of the pull request with baseSHA before headSHA, and returns their full SHAs. headSHA
+// defaults to the last commit of the pull request.
+func resolvePullRequestCommitRange(commits []*github.RepositoryCommit, pullNumber int, baseSHA, headSHA string) (string, string, error) {
+	if len(commits) == 0 {
+		return "", "", fmt.Errorf("pull request #%d has no commits", pullNumber)
+	}
+	find := func(param, sha string) (int, error) {
+		if len(sha) < 7 {
+			return 0, fmt.Errorf("%s must be a commit SHA of at least 7 characters", param)
+		}
+		for i, commit := range commits {
+			if strings.HasPrefix(commit.GetSHA(), sha) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%s %s is not a commit of pull request #%d; it may have been removed by a force push. Use get_commits to list the current commits", param, sha, pullNumber)
+	}
+
+	base, err := find("base_sha", baseSHA)
+	if err != nil {
+		return "", "", err
+	}
+	head := len(commits) - 1
+	if headSHA != "" {
+		if head, err = find("head_sha", headSHA); err != nil {
+			return "", "", err
+		}
+	}
+	if base >= head {
+		return "", "", fmt.Errorf("base_sha %s must be an earlier commit of pull request #%d than head_sha %s", baseSHA, pullNumber, commits[head].GetSHA())
+	}
+	return commits[base].GetSHA(), commits[head].GetSHA(), nil
+}
+
+// getPullRequestCommitRange resolves a commit range of a pull request. It returns a tool result
+// if the range cannot be resolved.
+func getPullRequestCommitRange(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, baseSHA, headSHA string) (string, string, *mcp.CallToolResult) {
+	commits, resp, err := listAllPullRequestCommits(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return "", "", ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request commits",
+			resp,
+			err,
+		)
+	}
+	base, head, err := resolvePullRequestCommitRange(commits, pullNumber, baseSHA, headSHA)
+	if err != nil {
+		return "", "", utils.NewToolResultError(err.Error())
+	}
+	return base, head, nil
+}
+
+// GetPullRequestCommitRangeDiff returns the diff of the changes made by the commits of a pull
+// request after baseSHA up to and including headSHA.
+func GetPullRequestCommitRangeDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, baseSHA, headSHA string) (*mcp.CallToolResult, error) {
+	base, head, errResult := getPullRequestCommitRange(ctx, client, owner, repo, pullNumber, baseSHA, headSHA)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	raw, resp, err := client.Repositories.CompareCommitsRaw(ctx, owner, repo, base, head, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get diff of commit range",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return utils.NewToolResultText(raw), nil
+}
+
+// GetPullRequestCommitRangeFiles lists the files changed by the commits of a pull request after
+// baseSHA up to and including headSHA.
+func GetPullRequestCommitRangeFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, baseSHA, headSHA string, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	base, head, errResult := getPullRequestCommitRange(ctx, client, owner, repo, pullNumber, baseSHA, headSHA)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to compare commit range",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// The compare API returns all changed files at once, so page through them here to match
+	// the pull request files API.
+	files := comparison.Files
+	start := min((pagination.Page-1)*pagination.PerPage, len(files))
+	end := min(start+pagination.PerPage, len(files))
+
+	return utils.NewToolResultJSON(files[start:end])
+}
+
 // GraphQL types for review threads query
 type reviewThreadsQuery struct {
 	Repository struct {
@@ -407,6 +654,53 @@ func GetPullRequestReviewComments(ctx context.Context, gqlClient *githubv4.Clien
 	return utils.NewToolResultJSON(response)
 }
 
+// GetPullRequestRequestedReviewers returns the users and teams whose review of a pull request is
+// still pending.
+func GetPullRequestRequestedReviewers(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	reviewers := &github.Reviewers{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.PullRequests.ListReviewers(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get requested reviewers",
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+
+		reviewers.Users = append(reviewers.Users, page.Users...)
+		reviewers.Teams = append(reviewers.Teams, page.Teams...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return utils.NewToolResultJSON(convertToRequestedReviewers(reviewers))
+}
+
+// RequestedReviewers are the users and teams whose review of a pull request is requested.
+type RequestedReviewers struct {
+	Users []MinimalUser `json:"users"`
+	Teams []MinimalTeam `json:"teams"`
+}
+
+func convertToRequestedReviewers(reviewers *github.Reviewers) RequestedReviewers {
+	result := RequestedReviewers{
+		Users: make([]MinimalUser, 0, len(reviewers.Users)),
+		Teams: make([]MinimalTeam, 0, len(reviewers.Teams)),
+	}
+	for _, user := range reviewers.Users {
+		result.Users = append(result.Users, *convertToMinimalUser(user))
+	}
+	for _, team := range reviewers.Teams {
+		result.Teams = append(result.Teams, convertToMinimalTeam(team))
+	}
+	return result
+}
+
 func GetPullRequestReviews(ctx context.Context, client *github.Client, deps ToolDependencies, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
 	cache, err := deps.GetRepoAccessCache(ctx)
 	if err != nil {
@@ -756,14 +1050,7 @@ func UpdatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 					return utils.NewToolResultErrorFromErr("failed to get GitHub GraphQL client", err), nil, nil
 				}
 
-				var prQuery struct {
-					Repository struct {
-						PullRequest struct {
-							ID      githubv4.ID
-							IsDraft githubv4.Boolean
-						} `graphql:"pullRequest(number: $prNum)"`
-					} `graphql:"repository(owner: $owner, name: $repo)"`
-				}
+				var prQuery pullRequestIDQuery
 
 				err = gqlClient.Query(ctx, &prQuery, map[string]interface{}{
 					"owner": githubv4.String(owner),
@@ -779,15 +1066,7 @@ func UpdatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				if currentIsDraft != draftValue {
 					if draftValue {
 						// Convert to draft
-						var mutation struct {
-							ConvertPullRequestToDraft struct {
-								PullRequest struct {
-									ID      githubv4.ID
-									IsDraft githubv4.Boolean
-								}
-							} `graphql:"convertPullRequestToDraft(input: $input)"`
-						}
-
+						var mutation convertPullRequestToDraftMutation
 						err = gqlClient.Mutate(ctx, &mutation, githubv4.ConvertPullRequestToDraftInput{
 							PullRequestID: prQuery.Repository.PullRequest.ID,
 						}, nil)
@@ -796,15 +1075,7 @@ func UpdatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 						}
 					} else {
 						// Mark as ready for review
-						var mutation struct {
-							MarkPullRequestReadyForReview struct {
-								PullRequest struct {
-									ID      githubv4.ID
-									IsDraft githubv4.Boolean
-								}
-							} `graphql:"markPullRequestReadyForReview(input: $input)"`
-						}
-
+						var mutation markPullRequestReadyForReviewMutation
 						err = gqlClient.Mutate(ctx, &mutation, githubv4.MarkPullRequestReadyForReviewInput{
 							PullRequestID: prQuery.Repository.PullRequest.ID,
 						}, nil)
@@ -965,6 +1236,259 @@ func AddReplyToPullRequestComment(t translations.TranslationHelperFunc) inventor
 		})
 }
 
+// reviewThreadState is the selection returned by the resolve and unresolve mutations.
+type reviewThreadState struct {
+	ID         githubv4.ID
+	IsResolved githubv4.Boolean
+}
+
+type resolveReviewThreadMutation struct {
+	ResolveReviewThread struct {
+		Thread reviewThreadState
+	} `graphql:"resolveReviewThread(input: $input)"`
+}
+
+type unresolveReviewThreadMutation struct {
+	UnresolveReviewThread struct {
+		Thread reviewThreadState
+	} `graphql:"unresolveReviewThread(input: $input)"`
+}
+
+type addReviewThreadReplyMutation struct {
+	AddPullRequestReviewThreadReply struct {
+		Comment struct {
+			ID  githubv4.ID
+			URL githubv4.URI
+		}
+	} `graphql:"addPullRequestReviewThreadReply(input: $input)"`
+}
+
+// viewerReviewThreadsQuery fetches the review threads of a pull request together with the
+// author of the first comment of each thread, which is the author of the thread.
+type viewerReviewThreadsQuery struct {
+	Viewer struct {
+		Login githubv4.String
+	}
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+					IsOutdated githubv4.Boolean
+					Comments   struct {
+						Nodes []struct {
+							Author struct {
+								Login githubv4.String
+							}
+						}
+					} `graphql:"comments(first: 1)"`
+				}
+				PageInfo struct {
+					HasNextPage githubv4.Boolean
+					EndCursor   githubv4.String
+				}
+			} `graphql:"reviewThreads(first: 100, after: $after)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// PullRequestReviewThreads creates a tool to resolve, unresolve and reply to pull request review threads.
+func PullRequestReviewThreads(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"method": {
+				Type: "string",
+				Description: `The action to perform on review threads:
+- 'resolve': mark the thread 'threadId' as resolved.
+- 'unresolve': mark the thread 'threadId' as unresolved.
+- 'reply': reply to the thread 'threadId' with 'body'.
+- 'resolve_outdated': resolve every unresolved, outdated thread started by the current user on the pull request identified by 'owner', 'repo' and 'pullNumber'.`,
+				Enum: []any{"resolve", "unresolve", "reply", "resolve_outdated"},
+			},
+			"threadId": {
+				Type:        "string",
+				Description: "The node ID of the review thread, as returned by the get_review_comments method of pull_request_read. Required for 'resolve', 'unresolve' and 'reply'.",
+			},
+			"body": {
+				Type:        "string",
+				Description: "The text of the reply. Required for 'reply'.",
+			},
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner. Required for 'resolve_outdated'.",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name. Required for 'resolve_outdated'.",
+			},
+			"pullNumber": {
+				Type:        "number",
+				Description: "Pull request number. Required for 'resolve_outdated'.",
+			},
+		},
+		Required: []string{"method"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "pull_request_review_threads",
+			Description: t("TOOL_PULL_REQUEST_REVIEW_THREADS_DESCRIPTION", "Resolve, unresolve or reply to pull request review threads by their node ID, or resolve all outdated review threads started by the current user on a pull request, for example after pushing fixes."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_PULL_REQUEST_REVIEW_THREADS_USER_TITLE", "Resolve, unresolve and reply to review threads"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+
+			switch method {
+			case "resolve", "unresolve", "reply":
+				threadID, err := RequiredParam[string](args, "threadId")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				switch method {
+				case "resolve":
+					result, err := SetReviewThreadResolved(ctx, client, threadID, true)
+					return result, nil, err
+				case "unresolve":
+					result, err := SetReviewThreadResolved(ctx, client, threadID, false)
+					return result, nil, err
+				default:
+					body, err := RequiredParam[string](args, "body")
+					if err != nil {
+						return utils.NewToolResultError(err.Error()), nil, nil
+					}
+					result, err := ReplyToReviewThread(ctx, client, threadID, body)
+					return result, nil, err
+				}
+			case "resolve_outdated":
+				owner, repo, err := RequiredOwnerRepo(args)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				pullNumber, err := RequiredInt(args, "pullNumber")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				result, err := ResolveOutdatedReviewThreads(ctx, client, owner, repo, pullNumber)
+				return result, nil, err
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: resolve, unresolve, reply, resolve_outdated", method)), nil, nil
+			}
+		})
+}
+
+// SetReviewThreadResolved resolves or unresolves a review thread and reports its new state.
+func SetReviewThreadResolved(ctx context.Context, client *githubv4.Client, threadID string, resolved bool) (*mcp.CallToolResult, error) {
+	var thread reviewThreadState
+	if resolved {
+		var mutation resolveReviewThreadMutation
+		if err := client.Mutate(ctx, &mutation, githubv4.ResolveReviewThreadInput{
+			ThreadID: githubv4.ID(threadID),
+		}, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to resolve review thread", err), nil
+		}
+		thread = mutation.ResolveReviewThread.Thread
+	} else {
+		var mutation unresolveReviewThreadMutation
+		if err := client.Mutate(ctx, &mutation, githubv4.UnresolveReviewThreadInput{
+			ThreadID: githubv4.ID(threadID),
+		}, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unresolve review thread", err), nil
+		}
+		thread = mutation.UnresolveReviewThread.Thread
+	}
+
+	return utils.NewToolResultJSON(map[string]any{
+		"id":         thread.ID,
+		"isResolved": bool(thread.IsResolved),
+	})
+}
+
+// ReplyToReviewThread adds a reply comment to a review thread.
+func ReplyToReviewThread(ctx context.Context, client *githubv4.Client, threadID, body string) (*mcp.CallToolResult, error) {
+	var mutation addReviewThreadReplyMutation
+	if err := client.Mutate(ctx, &mutation, githubv4.AddPullRequestReviewThreadReplyInput{
+		PullRequestReviewThreadID: githubv4.ID(threadID),
+		Body:                      githubv4.String(body),
+	}, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to reply to review thread", err), nil
+	}
+
+	comment := mutation.AddPullRequestReviewThreadReply.Comment
+	return utils.NewToolResultJSON(MinimalResponse{
+		ID:  fmt.Sprintf("%v", comment.ID),
+		URL: comment.URL.String(),
+	})
+}
+
+// ResolveOutdatedReviewThreads resolves all unresolved, outdated review threads started by the
+// current user on a pull request.
+func ResolveOutdatedReviewThreads(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(pullNumber)), //nolint:gosec // pullNumber is controlled by user input validation
+		"after": (*githubv4.String)(nil),
+	}
+
+	var threadIDs []githubv4.ID
+	for {
+		var query viewerReviewThreadsQuery
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request review threads", err), nil
+		}
+
+		threads := query.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			if thread.IsResolved || !thread.IsOutdated || len(thread.Comments.Nodes) == 0 {
+				continue
+			}
+			if thread.Comments.Nodes[0].Author.Login == query.Viewer.Login {
+				threadIDs = append(threadIDs, thread.ID)
+			}
+		}
+
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = threads.PageInfo.EndCursor
+	}
+
+	resolved := make([]githubv4.ID, 0, len(threadIDs))
+	for _, threadID := range threadIDs {
+		var mutation resolveReviewThreadMutation
+		if err := client.Mutate(ctx, &mutation, githubv4.ResolveReviewThreadInput{
+			ThreadID: threadID,
+		}, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				fmt.Sprintf("failed to resolve review thread %v after resolving %d of %d outdated threads", threadID, len(resolved), len(threadIDs)),
+				err,
+			), nil
+		}
+		resolved = append(resolved, threadID)
+	}
+
+	return utils.NewToolResultJSON(map[string]any{
+		"resolvedThreadIds": resolved,
+		"resolvedCount":     len(resolved),
+	})
+}
+
 // ListPullRequests creates a tool to list and filter repository pull requests.
 func ListPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool {
 	schema := &jsonschema.Schema{
@@ -1184,7 +1708,7 @@ func MergePullRequest(t translations.TranslationHelperFunc) inventory.ServerTool
 			result, resp, err := client.PullRequests.Merge(ctx, owner, repo, pullNumber, commitMessage, options)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to merge pull request",
+					ruleViolationMessage("failed to merge pull request", err, ""),
 					resp,
 					err,
 				), nil, nil
@@ -1209,12 +1733,420 @@ func MergePullRequest(t translations.TranslationHelperFunc) inventory.ServerTool
 		})
 }
 
-// SearchPullRequests creates a tool to search for pull requests.
-func SearchPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool {
-	schema := &jsonschema.Schema{
-		Type: "object",
-		Properties: map[string]*jsonschema.Schema{
-			"query": {
+// pullRequestIDQuery looks up the node ID and draft state of a pull request by its number.
+type pullRequestIDQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ID      githubv4.ID
+			IsDraft githubv4.Boolean
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+type convertPullRequestToDraftMutation struct {
+	ConvertPullRequestToDraft struct {
+		PullRequest struct {
+			ID      githubv4.ID
+			IsDraft githubv4.Boolean
+		}
+	} `graphql:"convertPullRequestToDraft(input: $input)"`
+}
+
+type markPullRequestReadyForReviewMutation struct {
+	MarkPullRequestReadyForReview struct {
+		PullRequest struct {
+			ID      githubv4.ID
+			IsDraft githubv4.Boolean
+		}
+	} `graphql:"markPullRequestReadyForReview(input: $input)"`
+}
+
+// autoMergePullRequestFragment is the auto-merge state of a pull request returned by the
+// auto-merge mutations.
+type autoMergePullRequestFragment struct {
+	Number           githubv4.Int
+	URL              githubv4.URI
+	AutoMergeRequest *struct {
+		EnabledAt      githubv4.DateTime
+		MergeMethod    githubv4.String
+		CommitHeadline *githubv4.String
+		CommitBody     *githubv4.String
+		EnabledBy      *struct {
+			Login githubv4.String
+		}
+	}
+}
+
+type enablePullRequestAutoMergeMutation struct {
+	EnablePullRequestAutoMerge struct {
+		PullRequest autoMergePullRequestFragment
+	} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+}
+
+type disablePullRequestAutoMergeMutation struct {
+	DisablePullRequestAutoMerge struct {
+		PullRequest autoMergePullRequestFragment
+	} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+}
+
+// mergeQueueEntryFragment is the entry of a pull request in the merge queue of its base branch.
+type mergeQueueEntryFragment struct {
+	Position             githubv4.Int
+	State                githubv4.String
+	EnqueuedAt           githubv4.DateTime
+	EstimatedTimeToMerge *githubv4.Int
+	BaseCommit           *struct {
+		Oid githubv4.String
+	}
+}
+
+type enqueuePullRequestMutation struct {
+	EnqueuePullRequest struct {
+		MergeQueueEntry mergeQueueEntryFragment
+	} `graphql:"enqueuePullRequest(input: $input)"`
+}
+
+type dequeuePullRequestMutation struct {
+	DequeuePullRequest struct {
+		MergeQueueEntry struct {
+			State githubv4.String
+		}
+	} `graphql:"dequeuePullRequest(input: $input)"`
+}
+
+// PullRequestMergeAutomation creates a tool to hand a pull request off to GitHub for merging,
+// either by enabling auto-merge or by adding it to the merge queue.
+func PullRequestMergeAutomation(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"method": {
+				Type: "string",
+				Description: `The action to perform:
+- 'enable_auto_merge': merge the pull request automatically once all requirements are met, using 'merge_method', 'commit_headline' and 'commit_body'.
+- 'disable_auto_merge': cancel auto-merge.
+- 'enqueue': add the pull request to the merge queue of its base branch. Returns its position and state in the queue.
+- 'dequeue': remove the pull request from the merge queue.`,
+				Enum: []any{"enable_auto_merge", "disable_auto_merge", "enqueue", "dequeue"},
+			},
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"pullNumber": {
+				Type:        "number",
+				Description: "Pull request number",
+			},
+			"merge_method": {
+				Type:        "string",
+				Description: "Merge method for 'enable_auto_merge'. Ignored when the base branch uses a merge queue.",
+				Enum:        []any{"merge", "squash", "rebase"},
+			},
+			"commit_headline": {
+				Type:        "string",
+				Description: "Commit headline for 'enable_auto_merge'. Ignored when the base branch uses a merge queue.",
+			},
+			"commit_body": {
+				Type:        "string",
+				Description: "Commit body for 'enable_auto_merge'. Ignored when the base branch uses a merge queue.",
+			},
+			"expected_head_sha": {
+				Type:        "string",
+				Description: "For 'enable_auto_merge' and 'enqueue', the SHA the head of the pull request must match. Fails if new commits were pushed since.",
+			},
+			"jump": {
+				Type:        "boolean",
+				Description: "For 'enqueue', add the pull request to the front of the queue",
+			},
+		},
+		Required: []string{"method", "owner", "repo", "pullNumber"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "pull_request_merge_automation",
+			Description: t("TOOL_PULL_REQUEST_MERGE_AUTOMATION_DESCRIPTION", "Enable or disable auto-merge on a pull request, or add it to or remove it from the merge queue, so that GitHub merges it once all requirements are met instead of waiting for checks to pass."),
+			Icons:       octicons.Icons("git-merge"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_PULL_REQUEST_MERGE_AUTOMATION_USER_TITLE", "Manage auto-merge and merge queue for a pull request"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](args, "expected_head_sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			expectedHeadOid := newGQLStringlike[githubv4.GitObjectID](expectedHeadSHA)
+
+			var input any
+			switch method {
+			case "enable_auto_merge":
+				mergeMethod, err := OptionalParam[string](args, "merge_method")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				commitHeadline, err := OptionalParam[string](args, "commit_headline")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				commitBody, err := OptionalParam[string](args, "commit_body")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				input = githubv4.EnablePullRequestAutoMergeInput{
+					MergeMethod:     newGQLStringlike[githubv4.PullRequestMergeMethod](strings.ToUpper(mergeMethod)),
+					CommitHeadline:  newGQLStringlike[githubv4.String](commitHeadline),
+					CommitBody:      newGQLStringlike[githubv4.String](commitBody),
+					ExpectedHeadOid: expectedHeadOid,
+				}
+			case "enqueue":
+				jump, err := OptionalParam[bool](args, "jump")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				enqueueInput := githubv4.EnqueuePullRequestInput{
+					ExpectedHeadOid: expectedHeadOid,
+				}
+				if jump {
+					enqueueInput.Jump = githubv4.NewBoolean(true)
+				}
+				input = enqueueInput
+			case "disable_auto_merge", "dequeue":
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: enable_auto_merge, disable_auto_merge, enqueue, dequeue", method)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+
+			var prQuery pullRequestIDQuery
+			if err := client.Query(ctx, &prQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(int32(pullNumber)), //nolint:gosec // pullNumber is controlled by user input validation
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find pull request", err), nil, nil
+			}
+			prID := prQuery.Repository.PullRequest.ID
+
+			switch method {
+			case "enable_auto_merge":
+				enableInput := input.(githubv4.EnablePullRequestAutoMergeInput)
+				enableInput.PullRequestID = prID
+				var mutation enablePullRequestAutoMergeMutation
+				if err := client.Mutate(ctx, &mutation, enableInput, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to enable auto-merge", err), nil, nil
+				}
+				result, err := utils.NewToolResultJSON(autoMergeState(mutation.EnablePullRequestAutoMerge.PullRequest))
+				return result, nil, err
+			case "disable_auto_merge":
+				var mutation disablePullRequestAutoMergeMutation
+				if err := client.Mutate(ctx, &mutation, githubv4.DisablePullRequestAutoMergeInput{
+					PullRequestID: prID,
+				}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to disable auto-merge", err), nil, nil
+				}
+				result, err := utils.NewToolResultJSON(autoMergeState(mutation.DisablePullRequestAutoMerge.PullRequest))
+				return result, nil, err
+			case "enqueue":
+				enqueueInput := input.(githubv4.EnqueuePullRequestInput)
+				enqueueInput.PullRequestID = prID
+				var mutation enqueuePullRequestMutation
+				if err := client.Mutate(ctx, &mutation, enqueueInput, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add pull request to the merge queue", err), nil, nil
+				}
+				entry := mutation.EnqueuePullRequest.MergeQueueEntry
+				result, err := utils.NewToolResultJSON(map[string]any{
+					"number":          pullNumber,
+					"mergeQueueEntry": newMergeQueueEntry(entry),
+				})
+				return result, nil, err
+			default:
+				var mutation dequeuePullRequestMutation
+				if err := client.Mutate(ctx, &mutation, githubv4.DequeuePullRequestInput{
+					ID: prID,
+				}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to remove pull request from the merge queue", err), nil, nil
+				}
+				result, err := utils.NewToolResultJSON(map[string]any{
+					"number": pullNumber,
+					"state":  string(mutation.DequeuePullRequest.MergeQueueEntry.State),
+				})
+				return result, nil, err
+			}
+		})
+}
+
+// autoMergeState converts the auto-merge state of a pull request into the tool output.
+func autoMergeState(pr autoMergePullRequestFragment) map[string]any {
+	state := map[string]any{
+		"number":    int(pr.Number),
+		"url":       pr.URL.String(),
+		"autoMerge": nil,
+	}
+	if request := pr.AutoMergeRequest; request != nil {
+		autoMerge := map[string]any{
+			"enabledAt":   request.EnabledAt.Time,
+			"mergeMethod": string(request.MergeMethod),
+		}
+		if request.CommitHeadline != nil {
+			autoMerge["commitHeadline"] = string(*request.CommitHeadline)
+		}
+		if request.CommitBody != nil {
+			autoMerge["commitBody"] = string(*request.CommitBody)
+		}
+		if request.EnabledBy != nil {
+			autoMerge["enabledBy"] = string(request.EnabledBy.Login)
+		}
+		state["autoMerge"] = autoMerge
+	}
+	return state
+}
+
+// newMergeQueueEntry converts a merge queue entry into the tool output.
+func newMergeQueueEntry(entry mergeQueueEntryFragment) map[string]any {
+	result := map[string]any{
+		"position":   int(entry.Position),
+		"state":      string(entry.State),
+		"enqueuedAt": entry.EnqueuedAt.Time,
+	}
+	if entry.EstimatedTimeToMerge != nil {
+		result["estimatedTimeToMergeSeconds"] = int(*entry.EstimatedTimeToMerge)
+	}
+	if entry.BaseCommit != nil {
+		result["baseCommitSha"] = string(entry.BaseCommit.Oid)
+	}
+	return result
+}
+
+// SetPullRequestDraft creates a tool to mark a pull request as ready for review or convert it to a draft.
+func SetPullRequestDraft(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"pullNumber": {
+				Type:        "number",
+				Description: "Pull request number",
+			},
+			"draft": {
+				Type:        "boolean",
+				Description: "true to convert the pull request to a draft, false to mark it as ready for review",
+			},
+		},
+		Required: []string{"owner", "repo", "pullNumber", "draft"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "set_pull_request_draft",
+			Description: t("TOOL_SET_PULL_REQUEST_DRAFT_DESCRIPTION", "Mark a draft pull request as ready for review, or convert a pull request back to a draft."),
+			Icons:       octicons.Icons("git-pull-request"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_SET_PULL_REQUEST_DRAFT_USER_TITLE", "Mark pull request ready for review or as draft"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			draft, ok, err := OptionalParamOK[bool](args, "draft")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if !ok {
+				return utils.NewToolResultError("missing required parameter: draft"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+			}
+
+			var prQuery pullRequestIDQuery
+			if err := client.Query(ctx, &prQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(int32(pullNumber)), //nolint:gosec // pullNumber is controlled by user input validation
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find pull request", err), nil, nil
+			}
+			pr := prQuery.Repository.PullRequest
+
+			isDraft := bool(pr.IsDraft)
+			if isDraft != draft {
+				if draft {
+					var mutation convertPullRequestToDraftMutation
+					if err := client.Mutate(ctx, &mutation, githubv4.ConvertPullRequestToDraftInput{
+						PullRequestID: pr.ID,
+					}, nil); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to convert pull request to draft", err), nil, nil
+					}
+					isDraft = bool(mutation.ConvertPullRequestToDraft.PullRequest.IsDraft)
+				} else {
+					var mutation markPullRequestReadyForReviewMutation
+					if err := client.Mutate(ctx, &mutation, githubv4.MarkPullRequestReadyForReviewInput{
+						PullRequestID: pr.ID,
+					}, nil); err != nil {
+						return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to mark pull request ready for review", err), nil, nil
+					}
+					isDraft = bool(mutation.MarkPullRequestReadyForReview.PullRequest.IsDraft)
+				}
+			}
+
+			result, err := utils.NewToolResultJSON(map[string]any{
+				"number":  pullNumber,
+				"isDraft": isDraft,
+			})
+			return result, nil, err
+		})
+}
+
+// SearchPullRequests creates a tool to search for pull requests.
+func SearchPullRequests(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"query": {
 				Type:        "string",
 				Description: "Search query using GitHub pull request search syntax",
 			},
@@ -1371,6 +2303,18 @@ type PullRequestReviewWriteParams struct {
 	Body       string
 	Event      string
 	CommitID   *string
+	Comments   []PullRequestReviewCommentParams
+}
+
+// PullRequestReviewCommentParams is an inline comment created together with a review.
+type PullRequestReviewCommentParams struct {
+	Path       string
+	Body       string
+	Line       int32
+	Side       *string
+	StartLine  *int32
+	StartSide  *string
+	Suggestion *string
 }
 
 func PullRequestReviewWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
@@ -1410,6 +2354,46 @@ func PullRequestReviewWrite(t translations.TranslationHelperFunc) inventory.Serv
 				Type:        "string",
 				Description: "SHA of commit to review",
 			},
+			"comments": {
+				Type:        "array",
+				Description: "Inline comments to add with the review, only used by the 'create' method. Every comment is validated against the pull request diff before anything is created.",
+				Items: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"path": {
+							Type:        "string",
+							Description: "The relative path to the file to comment on",
+						},
+						"body": {
+							Type:        "string",
+							Description: "The text of the comment. Required unless 'suggestion' is given",
+						},
+						"line": {
+							Type:        "number",
+							Description: "The line of the file in the pull request diff that the comment applies to. For multi-line comments, the last line of the range",
+						},
+						"side": {
+							Type:        "string",
+							Description: "The side of the diff to comment on. LEFT indicates the previous state, RIGHT indicates the new state. Defaults to RIGHT",
+							Enum:        []any{"LEFT", "RIGHT"},
+						},
+						"startLine": {
+							Type:        "number",
+							Description: "For multi-line comments, the first line of the range. Must be in the same diff hunk as 'line'",
+						},
+						"startSide": {
+							Type:        "string",
+							Description: "For multi-line comments, the side of 'startLine'. Defaults to 'side'",
+							Enum:        []any{"LEFT", "RIGHT"},
+						},
+						"suggestion": {
+							Type:        "string",
+							Description: "Replacement code for the commented lines, appended to the body as a suggestion block. Only valid on the RIGHT side",
+						},
+					},
+					Required: []string{"path", "line"},
+				},
+			},
 		},
 		Required: []string{"method", "owner", "repo", "pullNumber"},
 	}
@@ -1421,7 +2405,7 @@ func PullRequestReviewWrite(t translations.TranslationHelperFunc) inventory.Serv
 			Description: t("TOOL_PULL_REQUEST_REVIEW_WRITE_DESCRIPTION", `Create and/or submit, delete review of a pull request.
 
 Available methods:
-- create: Create a new review of a pull request. If "event" parameter is provided, the review is submitted. If "event" is omitted, a pending review is created.
+- create: Create a new review of a pull request. If "event" parameter is provided, the review is submitted. If "event" is omitted, a pending review is created. Inline "comments" are validated against the pull request diff and created together with the review in a single call, so a complete review needs no further tool calls and invalid comments create nothing.
 - submit_pending: Submit an existing pending review of a pull request. This requires that a pending review exists for the current user on the specified pull request. The "body" and "event" parameters are used when submitting the review.
 - delete_pending: Delete an existing pending review of a pull request. This requires that a pending review exists for the current user on the specified pull request.
 `),
@@ -1446,6 +2430,17 @@ Available methods:
 
 			switch params.Method {
 			case "create":
+				if len(params.Comments) > 0 {
+					restClient, err := deps.GetClient(ctx)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+					}
+					diffs := getReviewCommentDiffs(ctx, restClient, params.Owner, params.Repo, int(params.PullNumber))
+					if problems := validateReviewComments(diffs, params.Comments); len(problems) > 0 {
+						return utils.NewToolResultError(fmt.Sprintf("%d of %d review comments cannot be placed on the pull request diff, so no review was created:\n- %s",
+							len(problems), len(params.Comments), strings.Join(problems, "\n- "))), nil, nil
+					}
+				}
 				result, err := CreatePullRequestReview(ctx, client, params)
 				return result, nil, err
 			case "submit_pending":
@@ -1500,6 +2495,15 @@ func CreatePullRequestReview(ctx context.Context, client *githubv4.Client, param
 		addPullRequestReviewInput.Body = githubv4.NewString(githubv4.String(params.Body))
 	}
 
+	// Inline comments are created atomically with the review
+	if len(params.Comments) > 0 {
+		threads := make([]*githubv4.DraftPullRequestReviewThread, 0, len(params.Comments))
+		for _, comment := range params.Comments {
+			threads = append(threads, newDraftReviewThread(comment))
+		}
+		addPullRequestReviewInput.Threads = &threads
+	}
+
 	if err := client.Mutate(
 		ctx,
 		&addPullRequestReviewMutation,
@@ -1512,12 +2516,88 @@ func CreatePullRequestReview(ctx context.Context, client *githubv4.Client, param
 	// Return nothing interesting, just indicate success for the time being.
 	// In future, we may want to return the review ID, but for the moment, we're not leaking
 	// API implementation details to the LLM.
+	if len(params.Comments) > 0 {
+		if params.Event == "" {
+			return utils.NewToolResultText(fmt.Sprintf("pending pull request review created with %d comments", len(params.Comments))), nil
+		}
+		return utils.NewToolResultText(fmt.Sprintf("pull request review submitted successfully with %d comments", len(params.Comments))), nil
+	}
 	if params.Event == "" {
 		return utils.NewToolResultText("pending pull request created"), nil
 	}
 	return utils.NewToolResultText("pull request review submitted successfully"), nil
 }
 
+// validateReviewComments checks every inline review comment against the pull request diff
+// and returns a description of each comment that cannot be placed. Positions are not checked
+// when diffs is nil.
+func validateReviewComments(diffs map[string]*fileDiff, comments []PullRequestReviewCommentParams) []string {
+	var problems []string
+	for i, comment := range comments {
+		side, startSide := reviewCommentSides(comment)
+		var err error
+		switch {
+		case comment.Path == "":
+			err = fmt.Errorf("path is required")
+		case comment.Body == "" && comment.Suggestion == nil:
+			err = fmt.Errorf("body is required")
+		case comment.Suggestion != nil && (side != diffSideRight || startSide != diffSideRight):
+			err = fmt.Errorf("suggestions can only be made on the RIGHT side of the diff")
+		case diffs != nil:
+			var startLine int
+			if comment.StartLine != nil {
+				startLine = int(*comment.StartLine)
+			}
+			err = validateDiffPosition(diffs, comment.Path, int(comment.Line), side, startLine, startSide)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("comments[%d] (%s:%d): %v", i, comment.Path, comment.Line, err))
+		}
+	}
+	return problems
+}
+
+// reviewCommentSides returns the sides of the end and start lines of a review comment,
+// defaulting to the RIGHT side and to the end line's side for the start line.
+func reviewCommentSides(comment PullRequestReviewCommentParams) (string, string) {
+	side := diffSideRight
+	if comment.Side != nil && *comment.Side != "" {
+		side = *comment.Side
+	}
+	startSide := side
+	if comment.StartSide != nil && *comment.StartSide != "" {
+		startSide = *comment.StartSide
+	}
+	return side, startSide
+}
+
+// newDraftReviewThread converts an inline review comment into a GraphQL review thread,
+// appending any suggested change as a suggestion block.
+func newDraftReviewThread(comment PullRequestReviewCommentParams) *githubv4.DraftPullRequestReviewThread {
+	body := comment.Body
+	if comment.Suggestion != nil {
+		suggestion := "```suggestion\n" + strings.TrimSuffix(*comment.Suggestion, "\n") + "\n```"
+		if body == "" {
+			body = suggestion
+		} else {
+			body += "\n\n" + suggestion
+		}
+	}
+
+	side, startSide := reviewCommentSides(comment)
+	thread := &githubv4.DraftPullRequestReviewThread{
+		Path: githubv4.String(comment.Path),
+		Line: githubv4.Int(comment.Line),
+		Body: githubv4.String(body),
+		Side: newGQLStringlike[githubv4.DiffSide](side),
+	}
+	if comment.StartLine != nil {
+		thread.StartLine = newGQLIntPtr(comment.StartLine)
+		thread.StartSide = newGQLStringlike[githubv4.DiffSide](startSide)
+	}
+	return thread
+}
+
 func SubmitPendingPullRequestReview(ctx context.Context, client *githubv4.Client, params PullRequestReviewWriteParams) (*mcp.CallToolResult, error) {
 	// First we'll get the current user
 	var getViewerQuery struct {
@@ -1773,6 +2853,25 @@ func AddCommentToPendingReview(t translations.TranslationHelperFunc) inventory.S
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			// Reject positions outside the diff up front, since GitHub does not explain why
+			// a review thread could not be created.
+			if params.SubjectType == "LINE" && params.Line != nil {
+				restClient, err := deps.GetClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+				}
+				if diffs := getReviewCommentDiffs(ctx, restClient, params.Owner, params.Repo, int(params.PullNumber)); diffs != nil {
+					side, startSide := reviewCommentSides(PullRequestReviewCommentParams{Side: params.Side, StartSide: params.StartSide})
+					var startLine int
+					if params.StartLine != nil {
+						startLine = int(*params.StartLine)
+					}
+					if err := validateDiffPosition(diffs, params.Path, int(*params.Line), side, startLine, startSide); err != nil {
+						return utils.NewToolResultError(fmt.Sprintf("cannot add review comment: %v. Use pull_request_read method get_diff_hunks to find commentable lines", err)), nil, nil
+					}
+				}
+			}
+
 			client, err := deps.GetGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
@@ -1873,6 +2972,131 @@ func AddCommentToPendingReview(t translations.TranslationHelperFunc) inventory.S
 		})
 }
 
+// PullRequestReviewers creates a tool to request reviews from users and teams, or remove review requests.
+func PullRequestReviewers(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"method": {
+				Type: "string",
+				Description: `The action to perform:
+- 'request': request reviews from 'reviewers' and 'team_reviewers'.
+- 'remove': remove the review requests of 'reviewers' and 'team_reviewers'.`,
+				Enum: []any{"request", "remove"},
+			},
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"pullNumber": {
+				Type:        "number",
+				Description: "Pull request number",
+			},
+			"reviewers": {
+				Type:        "array",
+				Description: "Usernames of the users",
+				Items: &jsonschema.Schema{
+					Type: "string",
+				},
+			},
+			"team_reviewers": {
+				Type:        "array",
+				Description: "Slugs of the teams, which must belong to the organization owning the repository",
+				Items: &jsonschema.Schema{
+					Type: "string",
+				},
+			},
+		},
+		Required: []string{"method", "owner", "repo", "pullNumber"},
+	}
+
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "pull_request_reviewers",
+			Description: t("TOOL_PULL_REQUEST_REVIEWERS_DESCRIPTION", "Request reviews of a pull request from users and teams, or remove review requests. Returns the reviewers whose review is requested afterwards. Use 'pull_request_read' method 'get_code_owners' to find the code owners whose review is required."),
+			Icons:       octicons.Icons("people"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_PULL_REQUEST_REVIEWERS_USER_TITLE", "Manage pull request reviewers"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			reviewers, err := OptionalStringArrayParam(args, "reviewers")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			teamReviewers, err := OptionalStringArrayParam(args, "team_reviewers")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(reviewers) == 0 && len(teamReviewers) == 0 {
+				return utils.NewToolResultError("at least one of reviewers or team_reviewers is required"), nil, nil
+			}
+			request := github.ReviewersRequest{
+				Reviewers:     reviewers,
+				TeamReviewers: teamReviewers,
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "request":
+				pr, resp, err := client.PullRequests.RequestReviewers(ctx, owner, repo, pullNumber, request)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to request reviewers",
+						resp,
+						err,
+					), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToRequestedReviewers(&github.Reviewers{
+					Users: pr.RequestedReviewers,
+					Teams: pr.RequestedTeams,
+				}))
+				return result, nil, err
+			case "remove":
+				resp, err := client.PullRequests.RemoveReviewers(ctx, owner, repo, pullNumber, request)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to remove reviewers",
+						resp,
+						err,
+					), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				result, err := GetPullRequestRequestedReviewers(ctx, client, owner, repo, pullNumber)
+				return result, nil, err
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: request, remove", method)), nil, nil
+			}
+		})
+}
+
 // RequestCopilotReview creates a tool to request a Copilot review for a pull request.
 // Note that this tool will not work on GHES where this feature is unsupported. In future, we should not expose this
 // tool if the configured host does not support it.
@@ -1900,7 +3124,7 @@ func RequestCopilotReview(t translations.TranslationHelperFunc) inventory.Server
 		ToolsetMetadataPullRequests,
 		mcp.Tool{
 			Name:        "request_copilot_review",
-			Description: t("TOOL_REQUEST_COPILOT_REVIEW_DESCRIPTION", "Request a GitHub Copilot code review for a pull request. Use this for automated feedback on pull requests, usually before requesting a human reviewer."),
+			Description: t("TOOL_REQUEST_COPILOT_REVIEW_DESCRIPTION", "Request a GitHub Copilot code review for a pull request. Use this for automated feedback on pull requests, usually before requesting a human reviewer. Use 'get_copilot_review' to wait for the review and get its comments."),
 			Icons:       octicons.Icons("copilot"),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_REQUEST_COPILOT_REVIEW_USER_TITLE", "Request Copilot review"),
@@ -1931,8 +3155,7 @@ func RequestCopilotReview(t translations.TranslationHelperFunc) inventory.Server
 				repo,
 				pullNumber,
 				github.ReviewersRequest{
-					// The login name of the copilot reviewer bot
-					Reviewers: []string{"copilot-pull-request-reviewer[bot]"},
+					Reviewers: []string{copilotReviewerLogin},
 				},
 			)
 			if err != nil {
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -755,6 +756,22 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to merge pull request",
 		},
+		{
+			name: "merge blocked by repository rules",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PutReposPullsMergeByOwnerByRepoByPullNumber: func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					_, _ = w.Write([]byte(`{"message": "Repository rule violations found\n\nRequired status check \"ci\" is expected.\n\n"}`))
+				},
+			}),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to merge pull request: blocked by repository rules on the target branch (Required status check \"ci\" is expected.)",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2703,6 +2720,12 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 	assert.Contains(t, schema.Properties, "startSide")
 	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber", "path", "body", "subjectType"})
 
+	stubbedDiff := "diff --git a/file.go b/file.go\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- a/file.go\n" +
+		"+++ b/file.go\n" +
+		"@@ -1,18 +1,20 @@ package main\n"
+
 	tests := []struct {
 		name               string
 		mockedClient       *http.Client
@@ -2778,7 +2801,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 				"path":        "file.go",
 				"body":        "Comment on non-existent line",
 				"subjectType": "LINE",
-				"line":        float64(999),
+				"line":        float64(15),
 				"side":        "RIGHT",
 			},
 			mockedClient: githubv4mock.NewMockedHTTPClient(
@@ -2809,7 +2832,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 						Path:                githubv4.String("file.go"),
 						Body:                githubv4.String("Comment on non-existent line"),
 						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
-						Line:                githubv4.NewInt(999),
+						Line:                githubv4.NewInt(15),
 						Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
 						StartLine:           nil,
 						StartSide:           nil,
@@ -2828,6 +2851,37 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 			expectToolError:    true,
 			expectedToolErrMsg: "Failed to add comment to pending review",
 		},
+		{
+			name: "line outside the diff is rejected before creating a thread",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"path":        "file.go",
+				"body":        "Comment on non-existent line",
+				"subjectType": "LINE",
+				"line":        float64(999),
+				"side":        "RIGHT",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "cannot add review comment: line 999 is not part of the diff of file.go on the RIGHT side; commentable lines are 1-20. Use pull_request_read method get_diff_hunks to find commentable lines",
+		},
+		{
+			name: "file outside the diff is rejected before creating a thread",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"path":        "other.go",
+				"body":        "Comment on unchanged file",
+				"subjectType": "LINE",
+				"line":        float64(1),
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "cannot add review comment: other.go is not changed in the pull request",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2838,6 +2892,11 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 			client := githubv4.NewClient(tc.mockedClient)
 			serverTool := AddCommentToPendingReview(translations.NullTranslationHelper)
 			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+					GetReposPullsByOwnerByRepoByPullNumber: expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+						mockResponse(t, http.StatusOK, stubbedDiff),
+					),
+				})),
 				GQLClient: client,
 			}
 			handler := serverTool.Handler(deps)
@@ -3391,3 +3450,2049 @@ func TestAddReplyToPullRequestComment(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestReviewThreads(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	serverTool := PullRequestReviewThreads(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "pull_request_review_threads", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "method")
+	assert.Contains(t, schema.Properties, "threadId")
+	assert.Contains(t, schema.Properties, "body")
+	assert.Contains(t, schema.Properties, "pullNumber")
+	assert.ElementsMatch(t, schema.Required, []string{"method"})
+
+	threadsPage := func(after any, nodes []map[string]any, hasNextPage bool, endCursor string) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			viewerReviewThreadsQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+				"after": after,
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"viewer": map[string]any{"login": "octocat"},
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes": nodes,
+							"pageInfo": map[string]any{
+								"hasNextPage": hasNextPage,
+								"endCursor":   endCursor,
+							},
+						},
+					},
+				},
+			}),
+		)
+	}
+	thread := func(id string, isResolved, isOutdated bool, author string) map[string]any {
+		return map[string]any{
+			"id":         id,
+			"isResolved": isResolved,
+			"isOutdated": isOutdated,
+			"comments": map[string]any{
+				"nodes": []map[string]any{
+					{"author": map[string]any{"login": author}},
+				},
+			},
+		}
+	}
+	resolveThread := func(id string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			resolveReviewThreadMutation{},
+			githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID(id)},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"resolveReviewThread": map[string]any{
+					"thread": map[string]any{"id": id, "isResolved": true},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     map[string]any
+	}{
+		{
+			name: "resolve thread",
+			requestArgs: map[string]any{
+				"method":   "resolve",
+				"threadId": "PRRT_1",
+			},
+			mockedClient:   githubv4mock.NewMockedHTTPClient(resolveThread("PRRT_1")),
+			expectedResult: map[string]any{"id": "PRRT_1", "isResolved": true},
+		},
+		{
+			name: "unresolve thread",
+			requestArgs: map[string]any{
+				"method":   "unresolve",
+				"threadId": "PRRT_1",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					unresolveReviewThreadMutation{},
+					githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unresolveReviewThread": map[string]any{
+							"thread": map[string]any{"id": "PRRT_1", "isResolved": false},
+						},
+					}),
+				),
+			),
+			expectedResult: map[string]any{"id": "PRRT_1", "isResolved": false},
+		},
+		{
+			name: "reply to thread",
+			requestArgs: map[string]any{
+				"method":   "reply",
+				"threadId": "PRRT_1",
+				"body":     "Fixed in the latest commit",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					addReviewThreadReplyMutation{},
+					githubv4.AddPullRequestReviewThreadReplyInput{
+						PullRequestReviewThreadID: githubv4.ID("PRRT_1"),
+						Body:                      githubv4.String("Fixed in the latest commit"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addPullRequestReviewThreadReply": map[string]any{
+							"comment": map[string]any{
+								"id":  "PRRC_2",
+								"url": "https://github.com/owner/repo/pull/42#discussion_r2",
+							},
+						},
+					}),
+				),
+			),
+			expectedResult: map[string]any{"id": "PRRC_2", "url": "https://github.com/owner/repo/pull/42#discussion_r2"},
+		},
+		{
+			name: "resolve outdated threads authored by viewer",
+			requestArgs: map[string]any{
+				"method":     "resolve_outdated",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				threadsPage((*githubv4.String)(nil), []map[string]any{
+					thread("PRRT_1", false, true, "octocat"),
+					thread("PRRT_2", false, false, "octocat"),
+					thread("PRRT_3", false, true, "hubot"),
+					thread("PRRT_4", true, true, "octocat"),
+				}, false, "cursor1"),
+				resolveThread("PRRT_1"),
+			),
+			expectedResult: map[string]any{
+				"resolvedThreadIds": []any{"PRRT_1"},
+				"resolvedCount":     float64(1),
+			},
+		},
+		{
+			name: "resolve outdated with nothing to resolve",
+			requestArgs: map[string]any{
+				"method":     "resolve_outdated",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				threadsPage((*githubv4.String)(nil), []map[string]any{
+					thread("PRRT_3", false, true, "hubot"),
+				}, false, "cursor1"),
+			),
+			expectedResult: map[string]any{
+				"resolvedThreadIds": []any{},
+				"resolvedCount":     float64(0),
+			},
+		},
+		{
+			name: "resolve requires threadId",
+			requestArgs: map[string]any{
+				"method": "resolve",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: threadId",
+		},
+		{
+			name: "reply requires body",
+			requestArgs: map[string]any{
+				"method":   "reply",
+				"threadId": "PRRT_1",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: body",
+		},
+		{
+			name: "resolve_outdated requires pullNumber",
+			requestArgs: map[string]any{
+				"method": "resolve_outdated",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: pullNumber",
+		},
+		{
+			name: "resolve fails",
+			requestArgs: map[string]any{
+				"method":   "resolve",
+				"threadId": "PRRT_1",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					resolveReviewThreadMutation{},
+					githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+					nil,
+					githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'PRRT_1'"),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to resolve review thread",
+		},
+		{
+			name: "unknown method",
+			requestArgs: map[string]any{
+				"method": "delete",
+			},
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "unknown method: delete",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				GQLClient: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+	t.Run("resolve outdated threads across pages", func(t *testing.T) {
+		t.Parallel()
+
+		// githubv4mock matches requests by query text, so the repeated page queries and
+		// resolve mutations are served in order by a single handler instead.
+		responses := []map[string]any{
+			{
+				"viewer": map[string]any{"login": "octocat"},
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes":    []map[string]any{thread("PRRT_1", false, true, "octocat")},
+							"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "cursor1"},
+						},
+					},
+				},
+			},
+			{
+				"viewer": map[string]any{"login": "octocat"},
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes":    []map[string]any{thread("PRRT_5", false, true, "octocat")},
+							"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor2"},
+						},
+					},
+				},
+			},
+			{"resolveReviewThread": map[string]any{"thread": map[string]any{"id": "PRRT_1", "isResolved": true}}},
+			{"resolveReviewThread": map[string]any{"thread": map[string]any{"id": "PRRT_5", "isResolved": true}}},
+		}
+		var requests []map[string]any
+		httpClient, transport := NewMockHTTPClient()
+		transport.OnRequest(http.MethodPost, "/graphql", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Variables map[string]any `json:"variables"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			requests = append(requests, body.Variables)
+			require.LessOrEqual(t, len(requests), len(responses))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": responses[len(requests)-1]})
+		})
+
+		deps := BaseDeps{
+			GQLClient: githubv4.NewClient(httpClient),
+		}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":     "resolve_outdated",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		require.Len(t, requests, 4)
+		assert.Nil(t, requests[0]["after"])
+		assert.Equal(t, "cursor1", requests[1]["after"])
+		assert.Equal(t, map[string]any{"threadId": "PRRT_1"}, requests[2]["input"])
+		assert.Equal(t, map[string]any{"threadId": "PRRT_5"}, requests[3]["input"])
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, map[string]any{
+			"resolvedThreadIds": []any{"PRRT_1", "PRRT_5"},
+			"resolvedCount":     float64(2),
+		}, response)
+	})
+}
+
+func TestCreatePullRequestReviewWithComments(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	serverTool := PullRequestReviewWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	require.Contains(t, schema.Properties, "comments")
+	assert.ElementsMatch(t, schema.Properties["comments"].Items.Required, []string{"path", "line"})
+
+	stubbedDiff := "diff --git a/main.go b/main.go\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -10,4 +10,5 @@ func main() {\n" +
+		" \tfoo()\n" +
+		"-\tbar()\n" +
+		"+\tbaz()\n" +
+		"+\tqux()\n" +
+		" \treturn\n" +
+		" }\n" +
+		"diff --git a/logo.png b/logo.png\n" +
+		"new file mode 100644\n" +
+		"index 0000000..8a4f5c3\n" +
+		"Binary files /dev/null and b/logo.png differ\n"
+
+	prIDQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"id": "PR_kwDODKw3uc6WYN1T",
+				},
+			},
+		}),
+	)
+	addReviewMutation := struct {
+		AddPullRequestReview struct {
+			PullRequestReview struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addPullRequestReview(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		mockedGQLClient    *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedText       string
+	}{
+		{
+			name: "submits review with single line, multi-line and suggestion comments",
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				prIDQuery,
+				githubv4mock.NewMutationMatcher(
+					addReviewMutation,
+					githubv4.AddPullRequestReviewInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						Body:          githubv4.NewString("Looks good overall"),
+						Event:         githubv4mock.Ptr(githubv4.PullRequestReviewEventRequestChanges),
+						Threads: &[]*githubv4.DraftPullRequestReviewThread{
+							{
+								Path: githubv4.String("main.go"),
+								Line: githubv4.Int(11),
+								Body: githubv4.String("Why was bar removed?"),
+								Side: githubv4mock.Ptr(githubv4.DiffSideLeft),
+							},
+							{
+								Path:      githubv4.String("main.go"),
+								Line:      githubv4.Int(12),
+								Body:      githubv4.String("Combine these calls\n\n```suggestion\n\tbazQux()\n```"),
+								Side:      githubv4mock.Ptr(githubv4.DiffSideRight),
+								StartLine: githubv4.NewInt(11),
+								StartSide: githubv4mock.Ptr(githubv4.DiffSideRight),
+							},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "create",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"body":       "Looks good overall",
+				"event":      "REQUEST_CHANGES",
+				"comments": []any{
+					map[string]any{
+						"path": "main.go",
+						"line": float64(11),
+						"side": "LEFT",
+						"body": "Why was bar removed?",
+					},
+					map[string]any{
+						"path":       "main.go",
+						"startLine":  float64(11),
+						"line":       float64(12),
+						"body":       "Combine these calls",
+						"suggestion": "\tbazQux()\n",
+					},
+				},
+			},
+			expectedText: "pull request review submitted successfully with 2 comments",
+		},
+		{
+			name:            "reports every invalid comment and creates nothing",
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":     "create",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"event":      "COMMENT",
+				"comments": []any{
+					map[string]any{"path": "main.go", "line": float64(11), "body": "valid"},
+					map[string]any{"path": "main.go", "line": float64(40), "body": "outside the diff"},
+					map[string]any{"path": "other.go", "line": float64(1), "body": "not changed"},
+					map[string]any{"path": "logo.png", "line": float64(1), "body": "binary"},
+					map[string]any{"path": "main.go", "line": float64(11), "side": "LEFT", "suggestion": "x"},
+				},
+			},
+			expectToolError: true,
+			expectedToolErrMsg: "4 of 5 review comments cannot be placed on the pull request diff, so no review was created:\n" +
+				"- comments[1] (main.go:40): line 40 is not part of the diff of main.go on the RIGHT side; commentable lines are 10-14\n" +
+				"- comments[2] (other.go:1): other.go is not changed in the pull request\n" +
+				"- comments[3] (logo.png:1): logo.png is a binary file, so line comments cannot be added to it\n" +
+				"- comments[4] (main.go:11): suggestions can only be made on the RIGHT side of the diff",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+					GetReposPullsByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, stubbedDiff),
+				})),
+				GQLClient: githubv4.NewClient(tc.mockedGQLClient),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedToolErrMsg, textContent.Text)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func TestReviewCommentsWithoutPullRequestDiff(t *testing.T) {
+	t.Parallel()
+
+	// GitHub answers 406 when the diff of a pull request is too large to render.
+	diffTooLarge := mockResponse(t, http.StatusNotAcceptable, `{"message": "Sorry, the diff exceeded the maximum number of lines (20000)"}`)
+
+	t.Run("create validates comments against the patches of the changed files", func(t *testing.T) {
+		t.Parallel()
+
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber: diffTooLarge,
+				GetReposPullsFilesByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, []*github.CommitFile{
+					{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -10,2 +10,3 @@\n \tfoo()\n+\tbar()\n \treturn")},
+					{Filename: github.Ptr("generated.go"), Status: github.Ptr("modified")},
+				}),
+			})),
+			GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient()),
+		}
+		serverTool := PullRequestReviewWrite(translations.NullTranslationHelper)
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":     "create",
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"event":      "COMMENT",
+			"comments": []any{
+				map[string]any{"path": "main.go", "line": float64(40), "body": "outside the patch"},
+				map[string]any{"path": "generated.go", "line": float64(5000), "body": "patch too large to check"},
+			},
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "1 of 2 review comments cannot be placed on the pull request diff, so no review was created:\n"+
+			"- comments[0] (main.go:40): line 40 is not part of the diff of main.go on the RIGHT side; commentable lines are 10-12",
+			getTextResult(t, result).Text)
+	})
+
+	t.Run("comment is added without validation when no diff is available", func(t *testing.T) {
+		t.Parallel()
+
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber:      diffTooLarge,
+				GetReposPullsFilesByOwnerByRepoByPullNumber: mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`),
+			})),
+			GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+					author: "williammartin",
+					owner:  "owner",
+					repo:   "repo",
+					prNum:  42,
+
+					reviews: []getLatestPendingReviewQueryReview{
+						{
+							id:    "PR_kwDODKw3uc6WYN1T",
+							state: "PENDING",
+							url:   "https://github.com/owner/repo/pull/42",
+						},
+					},
+				}),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddPullRequestReviewThread struct {
+							Thread struct {
+								ID githubv4.String
+							}
+						} `graphql:"addPullRequestReviewThread(input: $input)"`
+					}{},
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("main.go"),
+						Body:                githubv4.String("Comment on a large pull request"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+						Line:                githubv4.NewInt(10),
+						Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
+						PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addPullRequestReviewThread": map[string]any{
+							"thread": map[string]any{"id": "PRRT_1"},
+						},
+					}),
+				),
+			)),
+		}
+		serverTool := AddCommentToPendingReview(translations.NullTranslationHelper)
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pullNumber":  float64(42),
+			"path":        "main.go",
+			"body":        "Comment on a large pull request",
+			"subjectType": "LINE",
+			"line":        float64(10),
+			"side":        "RIGHT",
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Equal(t, "pull request review comment successfully added to pending review", textContent.Text)
+	})
+}
+
+func TestGetPullRequestDiffHunks(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_diff_hunks")
+	assert.Contains(t, schema.Properties, "path")
+
+	stubbedDiff := "diff --git a/README.md b/README.md\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- a/README.md\n" +
+		"+++ b/README.md\n" +
+		"@@ -1,2 +1,3 @@\n" +
+		" # Hello-World\n" +
+		"-Hello\n" +
+		"+Hello World\n" +
+		"+More\n" +
+		"@@ -10 +11 @@ ## Usage\n" +
+		"-old\n" +
+		"+new\n" +
+		"diff --git a/main.go b/main.go\n" +
+		"new file mode 100644\n" +
+		"index 0000000..8a4f5c3\n" +
+		"--- /dev/null\n" +
+		"+++ b/main.go\n" +
+		"@@ -0,0 +1 @@\n" +
+		"+package main\n"
+
+	readmeHunks := []map[string]any{
+		{
+			"index":     float64(0),
+			"header":    "@@ -1,2 +1,3 @@",
+			"old_start": float64(1),
+			"old_lines": float64(2),
+			"new_start": float64(1),
+			"new_lines": float64(3),
+			"lines": []any{
+				map[string]any{"type": "context", "old_line": float64(1), "new_line": float64(1), "content": "# Hello-World"},
+				map[string]any{"type": "delete", "old_line": float64(2), "content": "Hello"},
+				map[string]any{"type": "add", "new_line": float64(2), "content": "Hello World"},
+				map[string]any{"type": "add", "new_line": float64(3), "content": "More"},
+			},
+		},
+		{
+			"index":     float64(1),
+			"header":    "@@ -10 +11 @@ ## Usage",
+			"old_start": float64(10),
+			"old_lines": float64(1),
+			"new_start": float64(11),
+			"new_lines": float64(1),
+			"lines": []any{
+				map[string]any{"type": "delete", "old_line": float64(10), "content": "old"},
+				map[string]any{"type": "add", "new_line": float64(11), "content": "new"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		diffUnavailable    bool
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResponse   map[string]any
+	}{
+		{
+			name: "first page of hunks",
+			requestArgs: map[string]any{
+				"method":     "get_diff_hunks",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"perPage":    float64(2),
+			},
+			expectedResponse: map[string]any{
+				"files": []any{
+					map[string]any{
+						"path":              "README.md",
+						"status":            "modified",
+						"commentable_lines": map[string]any{"LEFT": []any{"1-2", "10"}, "RIGHT": []any{"1-3", "11"}},
+						"hunks":             []any{readmeHunks[0], readmeHunks[1]},
+					},
+				},
+				"total_files": float64(2),
+				"total_hunks": float64(3),
+				"next_page":   float64(2),
+			},
+		},
+		{
+			name: "hunks of a single file",
+			requestArgs: map[string]any{
+				"method":     "get_diff_hunks",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "main.go",
+			},
+			expectedResponse: map[string]any{
+				"files": []any{
+					map[string]any{
+						"path":              "main.go",
+						"status":            "added",
+						"commentable_lines": map[string]any{"LEFT": []any{}, "RIGHT": []any{"1"}},
+						"hunks": []any{
+							map[string]any{
+								"index":     float64(0),
+								"header":    "@@ -0,0 +1 @@",
+								"old_start": float64(0),
+								"old_lines": float64(0),
+								"new_start": float64(1),
+								"new_lines": float64(1),
+								"lines": []any{
+									map[string]any{"type": "add", "new_line": float64(1), "content": "package main"},
+								},
+							},
+						},
+					},
+				},
+				"total_files": float64(1),
+				"total_hunks": float64(1),
+			},
+		},
+		{
+			name: "falls back to the patches of the changed files",
+			requestArgs: map[string]any{
+				"method":     "get_diff_hunks",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			diffUnavailable: true,
+			expectedResponse: map[string]any{
+				"files": []any{
+					map[string]any{
+						"path":              "README.md",
+						"status":            "modified",
+						"commentable_lines": map[string]any{"LEFT": []any{"1-2", "10"}, "RIGHT": []any{"1-3", "11"}},
+						"hunks":             []any{readmeHunks[0], readmeHunks[1]},
+					},
+					map[string]any{
+						"path":              "data.json",
+						"status":            "added",
+						"patch_unavailable": true,
+						"commentable_lines": map[string]any{"LEFT": []any{}, "RIGHT": []any{}},
+						"hunks":             []any{},
+					},
+				},
+				"total_files": float64(2),
+				"total_hunks": float64(2),
+			},
+		},
+		{
+			name: "unknown path",
+			requestArgs: map[string]any{
+				"method":     "get_diff_hunks",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "other.go",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "other.go is not changed in pull request #42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handlers := map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber: expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+					mockResponse(t, http.StatusOK, stubbedDiff),
+				),
+			}
+			if tc.diffUnavailable {
+				// GitHub answers 406 when the diff of a pull request is too large to render.
+				handlers[GetReposPullsByOwnerByRepoByPullNumber] = mockResponse(t, http.StatusNotAcceptable, `{"message": "Sorry, the diff exceeded the maximum number of lines (20000)"}`)
+				handlers[GetReposPullsFilesByOwnerByRepoByPullNumber] = mockResponse(t, http.StatusOK, []*github.CommitFile{
+					{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,2 +1,3 @@\n # Hello-World\n-Hello\n+Hello World\n+More\n@@ -10 +11 @@ ## Usage\n-old\n+new")},
+					{Filename: github.Ptr("data.json"), Status: github.Ptr("added")},
+				})
+			}
+			client := github.NewClient(MockHTTPClientWithHandlers(handlers))
+			deps := BaseDeps{
+				Client:          client,
+				RepoAccessCache: stubRepoAccessCache(githubv4.NewClient(nil), 5*time.Minute),
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func TestGetPullRequestMergeReadiness(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_merge_readiness")
+
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+	pullRequest := func(overrides map[string]any) map[string]any {
+		pr := map[string]any{
+			"state":               "OPEN",
+			"isDraft":             false,
+			"mergeable":           "MERGEABLE",
+			"mergeStateStatus":    "CLEAN",
+			"reviewDecision":      "APPROVED",
+			"isInMergeQueue":      false,
+			"isMergeQueueEnabled": false,
+			"baseRefName":         "main",
+			"headRefOid":          "abc123",
+			"baseRef": map[string]any{
+				"branchProtectionRule": map[string]any{
+					"requiredApprovingReviewCount":   1,
+					"requiresApprovingReviews":       true,
+					"requiresCodeOwnerReviews":       false,
+					"requiresStatusChecks":           true,
+					"requiresStrictStatusChecks":     false,
+					"requiredStatusCheckContexts":    []any{"build"},
+					"requiresConversationResolution": true,
+				},
+			},
+			"latestOpinionatedReviews": map[string]any{
+				"nodes": []any{
+					map[string]any{"state": "APPROVED", "author": map[string]any{"login": "reviewer"}},
+				},
+			},
+			"reviewRequests": map[string]any{"nodes": []any{}},
+			"reviewThreads": map[string]any{
+				"nodes": []any{map[string]any{"isResolved": true}},
+			},
+			"commits": map[string]any{
+				"nodes": []any{
+					map[string]any{
+						"commit": map[string]any{
+							"statusCheckRollup": map[string]any{
+								"state": "SUCCESS",
+								"contexts": map[string]any{
+									"nodes": []any{
+										map[string]any{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS", "isRequired": true},
+										map[string]any{"__typename": "StatusContext", "context": "ci/lint", "state": "SUCCESS", "isRequired": false},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		for k, v := range overrides {
+			pr[k] = v
+		}
+		return pr
+	}
+	repository := func(pr map[string]any) map[string]any {
+		return map[string]any{
+			"repository": map[string]any{
+				"mergeCommitAllowed": true,
+				"squashMergeAllowed": true,
+				"rebaseMergeAllowed": false,
+				"pullRequest":        pr,
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		gqlResponse        githubv4mock.GQLResponse
+		threadPages        []githubv4mock.Matcher
+		rules              []any
+		rulesPath          string
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedReady      bool
+		expectedBlocked    []string
+		verify             func(t *testing.T, readiness MergeReadiness)
+	}{
+		{
+			name:          "ready to merge",
+			gqlResponse:   githubv4mock.DataResponse(repository(pullRequest(nil))),
+			rules:         []any{},
+			expectedReady: true,
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.Equal(t, []string{"build"}, readiness.StatusChecks.Required)
+				assert.Equal(t, []string{"reviewer"}, readiness.Reviews.ApprovedBy)
+				assert.Equal(t, []string{"merge", "squash"}, readiness.AllowedMergeMethods)
+				assert.True(t, readiness.Conversations.ResolutionRequired)
+				assert.Equal(t, 0, readiness.Conversations.Unresolved)
+			},
+		},
+		{
+			name: "blocked by checks, reviews, conversations and branch state",
+			gqlResponse: githubv4mock.DataResponse(repository(pullRequest(map[string]any{
+				"mergeStateStatus": "BEHIND",
+				"reviewDecision":   "REVIEW_REQUIRED",
+				"isInMergeQueue":   true,
+				"mergeQueueEntry":  map[string]any{"position": 3, "state": "AWAITING_CHECKS"},
+				"latestOpinionatedReviews": map[string]any{
+					"nodes": []any{
+						map[string]any{"state": "CHANGES_REQUESTED", "author": map[string]any{"login": "maintainer"}},
+					},
+				},
+				"reviewRequests": map[string]any{
+					"nodes": []any{
+						map[string]any{"asCodeOwner": true, "requestedReviewer": map[string]any{"combinedSlug": "owner/core"}},
+						map[string]any{"asCodeOwner": false, "requestedReviewer": map[string]any{"login": "someone"}},
+					},
+				},
+				"reviewThreads": map[string]any{
+					"nodes": []any{map[string]any{"isResolved": false}, map[string]any{"isResolved": true}},
+				},
+				"commits": map[string]any{
+					"nodes": []any{
+						map[string]any{
+							"commit": map[string]any{
+								"statusCheckRollup": map[string]any{
+									"state": "FAILURE",
+									"contexts": map[string]any{
+										"nodes": []any{
+											map[string]any{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "FAILURE", "isRequired": true},
+											map[string]any{"__typename": "CheckRun", "name": "e2e", "status": "IN_PROGRESS", "conclusion": nil, "isRequired": true},
+											map[string]any{"__typename": "StatusContext", "context": "ci/lint", "state": "ERROR", "isRequired": false},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}))),
+			rules: []any{
+				map[string]any{
+					"type": "required_status_checks",
+					"parameters": map[string]any{
+						"strict_required_status_checks_policy": true,
+						"required_status_checks":               []any{map[string]any{"context": "security"}},
+					},
+				},
+				map[string]any{
+					"type": "pull_request",
+					"parameters": map[string]any{
+						"required_approving_review_count":   2,
+						"require_code_owner_review":         true,
+						"dismiss_stale_reviews_on_push":     false,
+						"require_last_push_approval":        false,
+						"required_review_thread_resolution": false,
+						"allowed_merge_methods":             []any{"squash", "rebase"},
+					},
+				},
+				map[string]any{
+					"type": "merge_queue",
+					"parameters": map[string]any{
+						"merge_method": "SQUASH",
+					},
+				},
+			},
+			expectedBlocked: []string{
+				"head branch is behind main and the branch must be up to date before merging",
+				"required status checks have not run: security",
+				"required status checks are failing: build",
+				"required status checks are still running: e2e",
+				"changes requested by maintainer",
+				"2 approving review(s) required, 0 given",
+				"code owner review required from owner/core",
+				"1 unresolved review conversation(s) must be resolved",
+			},
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.True(t, readiness.BehindBase)
+				assert.True(t, readiness.StatusChecks.UpToDateRequired)
+				assert.Equal(t, []string{"build", "e2e", "security"}, readiness.StatusChecks.Required)
+				assert.Equal(t, []string{"ci/lint"}, readiness.StatusChecks.FailingNotRequired)
+				assert.True(t, readiness.Reviews.CodeOwnerReviewRequired)
+				assert.Equal(t, MergeReadinessMergeQueue{Required: true, InQueue: true, Position: 3, State: "AWAITING_CHECKS"}, readiness.MergeQueue)
+				assert.Equal(t, []string{"squash"}, readiness.AllowedMergeMethods)
+			},
+		},
+		{
+			name: "counts unresolved conversations on every page of review threads",
+			gqlResponse: githubv4mock.DataResponse(repository(pullRequest(map[string]any{
+				"reviewThreads": map[string]any{
+					"nodes":    []any{map[string]any{"isResolved": true}},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "cursor1"},
+				},
+			}))),
+			threadPages: []githubv4mock.Matcher{
+				githubv4mock.NewQueryMatcher(
+					mergeReadinessThreadsQuery{},
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"repo":  githubv4.String("repo"),
+						"prNum": githubv4.Int(42),
+						"after": githubv4.String("cursor1"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"pullRequest": map[string]any{
+								"reviewThreads": map[string]any{
+									"nodes":    []any{map[string]any{"isResolved": false}, map[string]any{"isResolved": false}},
+									"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor2"},
+								},
+							},
+						},
+					}),
+				),
+			},
+			rules: []any{},
+			expectedBlocked: []string{
+				"2 unresolved review conversation(s) must be resolved",
+			},
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.Equal(t, 2, readiness.Conversations.Unresolved)
+			},
+		},
+		{
+			name: "escapes the base branch name",
+			gqlResponse: githubv4mock.DataResponse(repository(pullRequest(map[string]any{
+				"baseRefName": "fix#1?v%",
+			}))),
+			rules:         []any{},
+			rulesPath:     "/repos/owner/repo/rules/branches/fix%231%3Fv%25",
+			expectedReady: true,
+		},
+		{
+			name: "draft with conflicts",
+			gqlResponse: githubv4mock.DataResponse(repository(pullRequest(map[string]any{
+				"isDraft":          true,
+				"mergeable":        "CONFLICTING",
+				"mergeStateStatus": "DIRTY",
+			}))),
+			rules: []any{},
+			expectedBlocked: []string{
+				"pull request is a draft",
+				"head branch has merge conflicts with main",
+			},
+		},
+		{
+			name: "blocked for a reason not otherwise reported",
+			gqlResponse: githubv4mock.DataResponse(repository(pullRequest(map[string]any{
+				"mergeStateStatus": "BLOCKED",
+			}))),
+			rules: []any{},
+			expectedBlocked: []string{
+				"merging is blocked by branch protection or rulesets, check get_branch_rules for the rules of main",
+			},
+		},
+		{
+			name:               "pull request not found",
+			gqlResponse:        githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42."),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get pull request merge readiness",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rulesPath := tc.rulesPath
+			if rulesPath == "" {
+				rulesPath = "/repos/owner/repo/rules/branches/main"
+			}
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposRulesBranchesByOwnerByRepoByBranch: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, rulesPath, r.URL.EscapedPath())
+					mockResponse(t, http.StatusOK, tc.rules)(w, r)
+				},
+			}))
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				append([]githubv4mock.Matcher{githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, tc.gqlResponse)}, tc.threadPages...)...,
+			))
+			deps := BaseDeps{
+				Client:    client,
+				GQLClient: gqlClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":     "get_merge_readiness",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var readiness MergeReadiness
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &readiness))
+			assert.Equal(t, tc.expectedReady, readiness.Ready)
+			if tc.expectedBlocked == nil {
+				tc.expectedBlocked = []string{}
+			}
+			assert.Equal(t, tc.expectedBlocked, readiness.BlockedBecause)
+			if tc.verify != nil {
+				tc.verify(t, readiness)
+			}
+		})
+	}
+}
+
+func Test_PullRequestMergeAutomation(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestMergeAutomation(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "pull_request_merge_automation", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "pullNumber"})
+
+	prQueryMatcher := githubv4mock.NewQueryMatcher(
+		pullRequestIDQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO", "isDraft": false},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name               string
+		matchers           []githubv4mock.Matcher
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResponse   map[string]any
+	}{
+		{
+			name: "enable auto-merge",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					enablePullRequestAutoMergeMutation{},
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:   "PR_kwDOA0xdyM50BPaO",
+						MergeMethod:     newGQLStringlike[githubv4.PullRequestMergeMethod]("SQUASH"),
+						CommitHeadline:  githubv4.NewString("Release v1.2.0 (#42)"),
+						CommitBody:      githubv4.NewString("Bumps version"),
+						ExpectedHeadOid: newGQLStringlike[githubv4.GitObjectID]("abc123"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"number": 42,
+								"url":    "https://github.com/owner/repo/pull/42",
+								"autoMergeRequest": map[string]any{
+									"enabledAt":      "2026-01-02T03:04:05Z",
+									"mergeMethod":    "SQUASH",
+									"commitHeadline": "Release v1.2.0 (#42)",
+									"commitBody":     "Bumps version",
+									"enabledBy":      map[string]any{"login": "release-bot"},
+								},
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":            "enable_auto_merge",
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"merge_method":      "squash",
+				"commit_headline":   "Release v1.2.0 (#42)",
+				"commit_body":       "Bumps version",
+				"expected_head_sha": "abc123",
+			},
+			expectedResponse: map[string]any{
+				"number": float64(42),
+				"url":    "https://github.com/owner/repo/pull/42",
+				"autoMerge": map[string]any{
+					"enabledAt":      "2026-01-02T03:04:05Z",
+					"mergeMethod":    "SQUASH",
+					"commitHeadline": "Release v1.2.0 (#42)",
+					"commitBody":     "Bumps version",
+					"enabledBy":      "release-bot",
+				},
+			},
+		},
+		{
+			name: "disable auto-merge",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					disablePullRequestAutoMergeMutation{},
+					githubv4.DisablePullRequestAutoMergeInput{PullRequestID: "PR_kwDOA0xdyM50BPaO"},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"disablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"number":           42,
+								"url":              "https://github.com/owner/repo/pull/42",
+								"autoMergeRequest": nil,
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":     "disable_auto_merge",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedResponse: map[string]any{
+				"number":    float64(42),
+				"url":       "https://github.com/owner/repo/pull/42",
+				"autoMerge": nil,
+			},
+		},
+		{
+			name: "enqueue at the front of the merge queue",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					enqueuePullRequestMutation{},
+					githubv4.EnqueuePullRequestInput{
+						PullRequestID: "PR_kwDOA0xdyM50BPaO",
+						Jump:          githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enqueuePullRequest": map[string]any{
+							"mergeQueueEntry": map[string]any{
+								"position":             1,
+								"state":                "QUEUED",
+								"enqueuedAt":           "2026-01-02T03:04:05Z",
+								"estimatedTimeToMerge": 600,
+								"baseCommit":           map[string]any{"oid": "def456"},
+							},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":     "enqueue",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"jump":       true,
+			},
+			expectedResponse: map[string]any{
+				"number": float64(42),
+				"mergeQueueEntry": map[string]any{
+					"position":                    float64(1),
+					"state":                       "QUEUED",
+					"enqueuedAt":                  "2026-01-02T03:04:05Z",
+					"estimatedTimeToMergeSeconds": float64(600),
+					"baseCommitSha":               "def456",
+				},
+			},
+		},
+		{
+			name: "dequeue",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					dequeuePullRequestMutation{},
+					githubv4.DequeuePullRequestInput{ID: "PR_kwDOA0xdyM50BPaO"},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"dequeuePullRequest": map[string]any{
+							"mergeQueueEntry": map[string]any{"state": "UNMERGEABLE"},
+						},
+					}),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":     "dequeue",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedResponse: map[string]any{
+				"number": float64(42),
+				"state":  "UNMERGEABLE",
+			},
+		},
+		{
+			name: "merge queue not enabled",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher,
+				githubv4mock.NewMutationMatcher(
+					enqueuePullRequestMutation{},
+					githubv4.EnqueuePullRequestInput{PullRequestID: "PR_kwDOA0xdyM50BPaO"},
+					nil,
+					githubv4mock.ErrorResponse("Merge queue is not enabled for this branch"),
+				),
+			},
+			requestArgs: map[string]any{
+				"method":     "enqueue",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to add pull request to the merge queue",
+		},
+		{
+			name: "unknown method",
+			requestArgs: map[string]any{
+				"method":     "merge_now",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "unknown method: merge_now",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			deps := BaseDeps{GQLClient: gqlClient}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_SetPullRequestDraft(t *testing.T) {
+	t.Parallel()
+
+	serverTool := SetPullRequestDraft(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_pull_request_draft", tool.Name)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber", "draft"})
+
+	prQueryMatcher := func(isDraft bool) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			pullRequestIDQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO", "isDraft": isDraft},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		matchers        []githubv4mock.Matcher
+		draft           bool
+		expectedIsDraft bool
+	}{
+		{
+			name: "mark ready for review",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher(true),
+				githubv4mock.NewMutationMatcher(
+					markPullRequestReadyForReviewMutation{},
+					githubv4.MarkPullRequestReadyForReviewInput{PullRequestID: "PR_kwDOA0xdyM50BPaO"},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"markPullRequestReadyForReview": map[string]any{
+							"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO", "isDraft": false},
+						},
+					}),
+				),
+			},
+			draft:           false,
+			expectedIsDraft: false,
+		},
+		{
+			name: "convert to draft",
+			matchers: []githubv4mock.Matcher{
+				prQueryMatcher(false),
+				githubv4mock.NewMutationMatcher(
+					convertPullRequestToDraftMutation{},
+					githubv4.ConvertPullRequestToDraftInput{PullRequestID: "PR_kwDOA0xdyM50BPaO"},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"convertPullRequestToDraft": map[string]any{
+							"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO", "isDraft": true},
+						},
+					}),
+				),
+			},
+			draft:           true,
+			expectedIsDraft: true,
+		},
+		{
+			name:            "already a draft",
+			matchers:        []githubv4mock.Matcher{prQueryMatcher(true)},
+			draft:           true,
+			expectedIsDraft: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			deps := BaseDeps{GQLClient: gqlClient}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"draft":      tc.draft,
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, map[string]any{"number": float64(42), "isDraft": tc.expectedIsDraft}, response)
+		})
+	}
+}
+
+func Test_GetPullRequestCommits(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_commits")
+
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA:     github.Ptr("1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/1111111"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Add feature"),
+				Author:  &github.CommitAuthor{Name: github.Ptr("Octocat"), Email: github.Ptr("octocat@github.com")},
+			},
+			Author: &github.User{Login: github.Ptr("octocat")},
+		},
+		{
+			SHA:     github.Ptr("2222222bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/2222222"),
+			Commit: &github.Commit{
+				Message: github.Ptr("fixup! Add feature"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		handler            http.HandlerFunc
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "successful commits fetch",
+			handler: expectQueryParams(t, map[string]string{
+				"page":     "2",
+				"per_page": "2",
+			}).andThen(mockResponse(t, http.StatusOK, mockCommits)),
+		},
+		{
+			name:               "commits fetch fails",
+			handler:            mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get pull request commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposPullsCommitsByOwnerByRepoByPullNumber: tc.handler,
+			}))
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":     "get_commits",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"page":       float64(2),
+				"perPage":    float64(2),
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var commits []MinimalCommit
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &commits))
+			require.Len(t, commits, 2)
+			assert.Equal(t, "1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", commits[0].SHA)
+			assert.Equal(t, "Add feature", commits[0].Commit.Message)
+			assert.Equal(t, "octocat", commits[0].Author.Login)
+			assert.Equal(t, "fixup! Add feature", commits[1].Commit.Message)
+		})
+	}
+}
+
+func Test_GetPullRequestCommitRange(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "base_sha")
+	assert.Contains(t, schema.Properties, "head_sha")
+
+	const (
+		firstSHA  = "1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		secondSHA = "2222222bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+		thirdSHA  = "3333333ccccccccccccccccccccccccccccccccc"
+	)
+	mockCommits := []*github.RepositoryCommit{
+		{SHA: github.Ptr(firstSHA)},
+		{SHA: github.Ptr(secondSHA)},
+		{SHA: github.Ptr(thirdSHA)},
+	}
+	mockComparison := &github.CommitsComparison{
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("a.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1)},
+			{Filename: github.Ptr("b.go"), Status: github.Ptr("added"), Additions: github.Ptr(2)},
+			{Filename: github.Ptr("c.go"), Status: github.Ptr("removed"), Deletions: github.Ptr(3)},
+		},
+	}
+	stubbedDiff := "diff --git a/a.go b/a.go\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- a/a.go\n" +
+		"+++ b/a.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-old\n" +
+		"+new\n"
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		compareHandler     http.HandlerFunc
+		expectToolError    bool
+		expectedToolErrMsg string
+		verify             func(t *testing.T, text string)
+	}{
+		{
+			name: "files changed since a commit",
+			requestArgs: map[string]any{
+				"method":   "get_files",
+				"base_sha": "1111111",
+				"page":     float64(1),
+				"perPage":  float64(2),
+			},
+			compareHandler: expectPath(t, "/repos/owner/repo/compare/"+firstSHA+"..."+thirdSHA).andThen(
+				mockResponse(t, http.StatusOK, mockComparison),
+			),
+			verify: func(t *testing.T, text string) {
+				var files []*github.CommitFile
+				require.NoError(t, json.Unmarshal([]byte(text), &files))
+				require.Len(t, files, 2)
+				assert.Equal(t, "a.go", files[0].GetFilename())
+				assert.Equal(t, "b.go", files[1].GetFilename())
+			},
+		},
+		{
+			name: "last page of files changed since a commit",
+			requestArgs: map[string]any{
+				"method":   "get_files",
+				"base_sha": "1111111",
+				"page":     float64(2),
+				"perPage":  float64(2),
+			},
+			compareHandler: mockResponse(t, http.StatusOK, mockComparison),
+			verify: func(t *testing.T, text string) {
+				var files []*github.CommitFile
+				require.NoError(t, json.Unmarshal([]byte(text), &files))
+				require.Len(t, files, 1)
+				assert.Equal(t, "c.go", files[0].GetFilename())
+			},
+		},
+		{
+			name: "diff of a commit range",
+			requestArgs: map[string]any{
+				"method":   "get_diff",
+				"base_sha": firstSHA,
+				"head_sha": "2222222",
+			},
+			compareHandler: expectPath(t, "/repos/owner/repo/compare/"+firstSHA+"..."+secondSHA).andThen(
+				mockResponse(t, http.StatusOK, stubbedDiff),
+			),
+			verify: func(t *testing.T, text string) {
+				assert.Equal(t, stubbedDiff, text)
+			},
+		},
+		{
+			name: "commit removed by a force push",
+			requestArgs: map[string]any{
+				"method":   "get_diff",
+				"base_sha": "9999999",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "base_sha 9999999 is not a commit of pull request #42; it may have been removed by a force push",
+		},
+		{
+			name: "base after head",
+			requestArgs: map[string]any{
+				"method":   "get_files",
+				"base_sha": thirdSHA,
+				"head_sha": secondSHA,
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "base_sha " + thirdSHA + " must be an earlier commit of pull request #42 than head_sha " + secondSHA,
+		},
+		{
+			name: "abbreviated SHA too short",
+			requestArgs: map[string]any{
+				"method":   "get_files",
+				"base_sha": "111",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "base_sha must be a commit SHA of at least 7 characters",
+		},
+		{
+			name: "head_sha without base_sha",
+			requestArgs: map[string]any{
+				"method":   "get_diff",
+				"head_sha": secondSHA,
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "head_sha can only be used together with base_sha",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handlers := map[string]http.HandlerFunc{
+				GetReposPullsCommitsByOwnerByRepoByPullNumber: expectQueryParams(t, map[string]string{
+					"per_page": "100",
+				}).andThen(mockResponse(t, http.StatusOK, mockCommits)),
+			}
+			if tc.compareHandler != nil {
+				handlers[GetReposCompareByOwnerByRepoByBasehead] = tc.compareHandler
+			}
+			client := github.NewClient(MockHTTPClientWithHandlers(handlers))
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			args := map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			tc.verify(t, textContent.Text)
+		})
+	}
+}
+
+func Test_GetPullRequestRequestedReviewers(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_requested_reviewers")
+
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber: expectPath(t, "/repos/owner/repo/pulls/42/requested_reviewers").andThen(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("page") == "2" {
+					mockResponse(t, http.StatusOK, &github.Reviewers{
+						Users: []*github.User{{Login: github.Ptr("hubot"), ID: github.Ptr(int64(3))}},
+					})(w, r)
+					return
+				}
+				w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls/42/requested_reviewers?page=2>; rel="next"`)
+				mockResponse(t, http.StatusOK, &github.Reviewers{
+					Users: []*github.User{{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))}},
+					Teams: []*github.Team{{Slug: github.Ptr("core"), Name: github.Ptr("Core"), ID: github.Ptr(int64(2))}},
+				})(w, r)
+			},
+		),
+	}))
+	deps := BaseDeps{Client: client}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"method":     "get_requested_reviewers",
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var reviewers RequestedReviewers
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &reviewers))
+	assert.Equal(t, RequestedReviewers{
+		Users: []MinimalUser{{Login: "octocat", ID: 1}, {Login: "hubot", ID: 3}},
+		Teams: []MinimalTeam{{ID: 2, Slug: "core", Name: "Core"}},
+	}, reviewers)
+}
+
+func Test_GetPullRequestCodeOwners(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties["method"].Enum, "get_code_owners")
+
+	codeowners := "* @octo-org/core\n" +
+		"*.go @gopher @author\n" +
+		"/docs/ @writer docs@example.com @other-org/docs\n" +
+		"/docs/generated/\n" +
+		"[invalid @nobody\n"
+
+	pullRequestHandler := expectPath(t, "/repos/octo-org/repo/pulls/42").andThen(
+		mockResponse(t, http.StatusOK, &github.PullRequest{
+			Number: github.Ptr(42),
+			User:   &github.User{Login: github.Ptr("author")},
+			Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+		}),
+	)
+
+	t.Run("resolves the owners of every changed file", func(t *testing.T) {
+		t.Parallel()
+
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber: pullRequestHandler,
+			"GET /repos/octo-org/repo/contents/CODEOWNERS": expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(codeowners))),
+				}),
+			),
+			GetReposCodeownersErrorsByOwnerByRepo: expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+				mockResponse(t, http.StatusOK, &github.CodeownersErrors{Errors: []*github.CodeownersError{{
+					Line:    5,
+					Column:  1,
+					Kind:    "Invalid pattern",
+					Source:  "[invalid @nobody",
+					Message: "Invalid pattern on line 5",
+					Path:    "CODEOWNERS",
+				}}}),
+			),
+			GetReposPullsFilesByOwnerByRepoByPullNumber: expectQueryParams(t, map[string]string{"per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.CommitFile{
+					{Filename: github.Ptr("main.go")},
+					{Filename: github.Ptr("README.md")},
+					{Filename: github.Ptr("docs/guide.md")},
+					{Filename: github.Ptr("docs/generated/api.md")},
+					{Filename: github.Ptr("pkg/util.go")},
+				}),
+			),
+		}))
+		deps := BaseDeps{Client: client}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":     "get_code_owners",
+			"owner":      "octo-org",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var owners PullRequestCodeOwners
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &owners))
+		assert.Equal(t, "CODEOWNERS", owners.Path)
+		assert.Equal(t, "main", owners.Ref)
+		assert.Equal(t, []CodeOwnersRule{
+			{Pattern: "*.go", Line: 2, Owners: []string{"@gopher", "@author"}, Files: []string{"main.go", "pkg/util.go"}},
+			{Pattern: "*", Line: 1, Owners: []string{"@octo-org/core"}, Files: []string{"README.md"}},
+			{Pattern: "/docs/", Line: 3, Owners: []string{"@writer", "docs@example.com", "@other-org/docs"}, Files: []string{"docs/guide.md"}},
+		}, owners.Rules)
+		assert.Equal(t, []string{"docs/generated/api.md"}, owners.UnownedFiles)
+		assert.Equal(t, []string{"@gopher", "@author", "@octo-org/core", "@writer", "docs@example.com", "@other-org/docs"}, owners.Owners)
+		assert.Equal(t, []string{"gopher", "writer"}, owners.Reviewers)
+		assert.Equal(t, []string{"core"}, owners.TeamReviewers)
+		require.Len(t, owners.Errors, 1)
+		assert.Equal(t, "Invalid pattern on line 5", owners.Errors[0].Message)
+	})
+
+	t.Run("no CODEOWNERS file", func(t *testing.T) {
+		t.Parallel()
+
+		client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber: pullRequestHandler,
+		}))
+		deps := BaseDeps{Client: client}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]any{
+			"method":     "get_code_owners",
+			"owner":      "octo-org",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var owners PullRequestCodeOwners
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &owners))
+		assert.Empty(t, owners.Path)
+		assert.Equal(t, "No CODEOWNERS file found in .github/, the repository root or docs/ on main.", owners.Message)
+	})
+}
+
+func Test_PullRequestReviewers(t *testing.T) {
+	t.Parallel()
+
+	serverTool := PullRequestReviewers(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "pull_request_reviewers", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "pullNumber"})
+	assert.ElementsMatch(t, schema.Properties["method"].Enum, []any{"request", "remove"})
+
+	requestedReviewers := &github.Reviewers{
+		Users: []*github.User{{Login: github.Ptr("octocat")}},
+		Teams: []*github.Team{{Slug: github.Ptr("core")}},
+	}
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		handlers           map[string]http.HandlerFunc
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedReviewers  RequestedReviewers
+	}{
+		{
+			name: "request reviewers",
+			requestArgs: map[string]any{
+				"method":         "request",
+				"reviewers":      []any{"octocat"},
+				"team_reviewers": []any{"core"},
+			},
+			handlers: map[string]http.HandlerFunc{
+				PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber: expectRequestBody(t, map[string]any{
+					"reviewers":      []any{"octocat"},
+					"team_reviewers": []any{"core"},
+				}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+					Number:             github.Ptr(42),
+					RequestedReviewers: requestedReviewers.Users,
+					RequestedTeams:     requestedReviewers.Teams,
+				})),
+			},
+			expectedReviewers: RequestedReviewers{
+				Users: []MinimalUser{{Login: "octocat"}},
+				Teams: []MinimalTeam{{Slug: "core"}},
+			},
+		},
+		{
+			name: "remove reviewers",
+			requestArgs: map[string]any{
+				"method":    "remove",
+				"reviewers": []any{"hubot"},
+			},
+			handlers: map[string]http.HandlerFunc{
+				DeleteReposPullsRequestedReviewersByOwnerByRepoByPullNumber: expectRequestBody(t, map[string]any{
+					"reviewers": []any{"hubot"},
+				}).andThen(mockResponse(t, http.StatusOK, &github.PullRequest{Number: github.Ptr(42)})),
+				GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, requestedReviewers),
+			},
+			expectedReviewers: RequestedReviewers{
+				Users: []MinimalUser{{Login: "octocat"}},
+				Teams: []MinimalTeam{{Slug: "core"}},
+			},
+		},
+		{
+			name: "no reviewers",
+			requestArgs: map[string]any{
+				"method": "request",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "at least one of reviewers or team_reviewers is required",
+		},
+		{
+			name: "request fails",
+			requestArgs: map[string]any{
+				"method":    "request",
+				"reviewers": []any{"ghost"},
+			},
+			handlers: map[string]http.HandlerFunc{
+				PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber: mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reviews may only be requested from collaborators."}`),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to request reviewers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(MockHTTPClientWithHandlers(tc.handlers))
+			deps := BaseDeps{Client: client}
+			handler := serverTool.Handler(deps)
+
+			args := map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var reviewers RequestedReviewers
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &reviewers))
+			assert.Equal(t, tc.expectedReviewers, reviewers)
+		})
+	}
+}
@@ -1421,7 +1421,7 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 			})
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to update reference",
+					ruleViolationMessage("failed to update reference", err, branch),
 					resp,
 					err,
 				), nil, nil
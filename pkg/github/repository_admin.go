@@ -0,0 +1,630 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateRepositorySettings creates a tool to update general repository settings such as
+// the description, homepage, topics, default branch and merge button configuration.
+func UpdateRepositorySettings(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "update_repository_settings",
+			Description: t("TOOL_UPDATE_REPOSITORY_SETTINGS_DESCRIPTION", "Update settings of a GitHub repository: description, homepage, topics, default branch and merge button options. Only the provided fields are changed."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_REPOSITORY_SETTINGS_USER_TITLE", "Update repository settings"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"description": {
+						Type:        "string",
+						Description: "New repository description",
+					},
+					"homepage": {
+						Type:        "string",
+						Description: "New homepage URL",
+					},
+					"topics": {
+						Type:        "array",
+						Description: "Topics to set on the repository. Replaces all existing topics; pass an empty array to clear them.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"default_branch": {
+						Type:        "string",
+						Description: "Name of the branch to use as the default branch",
+					},
+					"allow_merge_commit": {
+						Type:        "boolean",
+						Description: "Allow merging pull requests with a merge commit",
+					},
+					"allow_squash_merge": {
+						Type:        "boolean",
+						Description: "Allow squash-merging pull requests",
+					},
+					"allow_rebase_merge": {
+						Type:        "boolean",
+						Description: "Allow rebase-merging pull requests",
+					},
+					"allow_update_branch": {
+						Type:        "boolean",
+						Description: "Always suggest updating pull request branches",
+					},
+					"delete_branch_on_merge": {
+						Type:        "boolean",
+						Description: "Automatically delete head branches after pull requests are merged",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			update := &github.Repository{}
+			editNeeded := false
+
+			for param, field := range map[string]**string{
+				"description":    &update.Description,
+				"homepage":       &update.Homepage,
+				"default_branch": &update.DefaultBranch,
+			} {
+				value, ok, err := OptionalParamOK[string](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					editNeeded = true
+				}
+			}
+
+			for param, field := range map[string]**bool{
+				"allow_merge_commit":     &update.AllowMergeCommit,
+				"allow_squash_merge":     &update.AllowSquashMerge,
+				"allow_rebase_merge":     &update.AllowRebaseMerge,
+				"allow_update_branch":    &update.AllowUpdateBranch,
+				"delete_branch_on_merge": &update.DeleteBranchOnMerge,
+			} {
+				value, ok, err := OptionalParamOK[bool](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					editNeeded = true
+				}
+			}
+
+			_, topicsProvided := args["topics"]
+			topics, err := OptionalStringArrayParam(args, "topics")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			if !editNeeded && !topicsProvided {
+				return utils.NewToolResultError("No update parameters provided."), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			if topicsProvided {
+				_, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to replace repository topics", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if !editNeeded {
+				return getRepositorySettings(ctx, client, owner, repo)
+			}
+			return editRepository(ctx, client, owner, repo, update, "failed to update repository settings")
+		},
+	)
+}
+
+// SetRepositoryFeatures creates a tool to enable or disable optional repository features.
+func SetRepositoryFeatures(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "set_repository_features",
+			Description: t("TOOL_SET_REPOSITORY_FEATURES_DESCRIPTION", "Enable or disable repository features such as issues, wiki, projects, discussions and auto-merge. Only the provided features are changed."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_SET_REPOSITORY_FEATURES_USER_TITLE", "Enable or disable repository features"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"has_issues": {
+						Type:        "boolean",
+						Description: "Enable issues",
+					},
+					"has_wiki": {
+						Type:        "boolean",
+						Description: "Enable the wiki",
+					},
+					"has_projects": {
+						Type:        "boolean",
+						Description: "Enable projects",
+					},
+					"has_discussions": {
+						Type:        "boolean",
+						Description: "Enable discussions",
+					},
+					"allow_auto_merge": {
+						Type:        "boolean",
+						Description: "Allow auto-merge to be enabled on pull requests",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			update := &github.Repository{}
+			editNeeded := false
+
+			for param, field := range map[string]**bool{
+				"has_issues":       &update.HasIssues,
+				"has_wiki":         &update.HasWiki,
+				"has_projects":     &update.HasProjects,
+				"has_discussions":  &update.HasDiscussions,
+				"allow_auto_merge": &update.AllowAutoMerge,
+			} {
+				value, ok, err := OptionalParamOK[bool](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					editNeeded = true
+				}
+			}
+
+			if !editNeeded {
+				return utils.NewToolResultError("No features provided."), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			return editRepository(ctx, client, owner, repo, update, "failed to update repository features")
+		},
+	)
+}
+
+// SetRepositoryVisibility creates a tool to change the visibility of a repository.
+func SetRepositoryVisibility(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "set_repository_visibility",
+			Description: t("TOOL_SET_REPOSITORY_VISIBILITY_DESCRIPTION", "Change the visibility of a GitHub repository. Making a private repository public exposes all of its code and history."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_SET_REPOSITORY_VISIBILITY_USER_TITLE", "Change repository visibility"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"visibility": {
+						Type:        "string",
+						Description: "New visibility. 'internal' is only available for organizations on GitHub Enterprise.",
+						Enum:        []any{"public", "private", "internal"},
+					},
+				},
+				Required: []string{"owner", "repo", "visibility"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			visibility, err := RequiredParam[string](args, "visibility")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			switch visibility {
+			case "public", "private", "internal":
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid visibility: %s. Supported values are: public, private, internal", visibility)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			return editRepository(ctx, client, owner, repo, &github.Repository{Visibility: github.Ptr(visibility)}, "failed to change repository visibility")
+		},
+	)
+}
+
+// ArchiveRepository creates a tool to archive or unarchive a repository.
+func ArchiveRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "archive_repository",
+			Description: t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive or unarchive a GitHub repository. Archived repositories are read-only."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive or unarchive repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"archived": {
+						Type:        "boolean",
+						Description: "true to archive the repository, false to unarchive it",
+					},
+				},
+				Required: []string{"owner", "repo", "archived"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			archived, ok, err := OptionalParamOK[bool](args, "archived")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if !ok {
+				return utils.NewToolResultError("missing required parameter: archived"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			message := "failed to archive repository"
+			if !archived {
+				message = "failed to unarchive repository"
+			}
+			return editRepository(ctx, client, owner, repo, &github.Repository{Archived: github.Ptr(archived)}, message)
+		},
+	)
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "transfer_repository",
+			Description: t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a GitHub repository to another user or organization. The current owner loses access unless granted again by the new owner."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Current repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"new_owner": {
+						Type:        "string",
+						Description: "Username or organization name the repository will be transferred to",
+					},
+					"new_name": {
+						Type:        "string",
+						Description: "New name for the repository after the transfer (optional)",
+					},
+					"team_ids": {
+						Type:        "array",
+						Description: "IDs of teams in the target organization to grant access to the repository (e.g. [\"12345\"])",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"owner", "repo", "new_owner"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo, scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			newOwner, err := RequiredParam[string](args, "new_owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			newName, err := OptionalParam[string](args, "new_name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			teamIDs, err := OptionalBigIntArrayParam(args, "team_ids")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			transfer := github.TransferRequest{
+				NewOwner: newOwner,
+				NewName:  ToStringPtr(newName),
+			}
+			if len(teamIDs) > 0 {
+				transfer.TeamID = teamIDs
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			transferred, resp, err := client.Repositories.Transfer(ctx, owner, repo, transfer)
+			if err != nil {
+				// The transfer is scheduled as a background job and acknowledged with 202 Accepted.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return utils.NewToolResultText(fmt.Sprintf("Transfer of %s/%s to %s is in progress", owner, repo, newOwner)), nil, nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to transfer repository", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to transfer repository", resp, body), nil, nil
+			}
+
+			minimalResponse := MinimalResponse{
+				ID:  fmt.Sprintf("%d", transferred.GetID()),
+				URL: transferred.GetHTMLURL(),
+			}
+
+			result, err := utils.NewToolResultJSON(minimalResponse)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// CreateRepositoryFromTemplate creates a tool to generate a new repository from a template repository.
+func CreateRepositoryFromTemplate(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "create_repository_from_template",
+			Description: t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"template_owner": {
+						Type:        "string",
+						Description: "Owner of the template repository",
+					},
+					"template_repo": {
+						Type:        "string",
+						Description: "Name of the template repository",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the new repository",
+					},
+					"owner": {
+						Type:        "string",
+						Description: "User or organization to create the repository in (omit to create in your personal account)",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Description of the new repository",
+					},
+					"private": {
+						Type:        "boolean",
+						Description: "Whether the new repository should be private",
+					},
+					"include_all_branches": {
+						Type:        "boolean",
+						Description: "Copy all branches from the template instead of only the default branch",
+					},
+				},
+				Required: []string{"template_owner", "template_repo", "name"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			templateOwner, err := RequiredParam[string](args, "template_owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			templateRepo, err := RequiredParam[string](args, "template_repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			name, err := RequiredParam[string](args, "name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := OptionalParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			description, err := OptionalParam[string](args, "description")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			private, err := OptionalParam[bool](args, "private")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			includeAllBranches, err := OptionalParam[bool](args, "include_all_branches")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			req := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Owner:              ToStringPtr(owner),
+				Description:        ToStringPtr(description),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, req)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create repository from template", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+				}
+				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create repository from template", resp, body), nil, nil
+			}
+
+			minimalResponse := MinimalResponse{
+				ID:  fmt.Sprintf("%d", createdRepo.GetID()),
+				URL: createdRepo.GetHTMLURL(),
+			}
+
+			result, err := utils.NewToolResultJSON(minimalResponse)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// editRepository applies a partial repository update and returns the resulting settings.
+func editRepository(ctx context.Context, client *github.Client, owner, repo string, update *github.Repository, errMessage string) (*mcp.CallToolResult, any, error) {
+	updated, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, errMessage, resp, err), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+		}
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, errMessage, resp, body), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(convertToMinimalRepositorySettings(updated))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// getRepositorySettings fetches a repository and returns its settings.
+func getRepositorySettings(ctx context.Context, client *github.Client, owner, repo string) (*mcp.CallToolResult, any, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository", resp, err), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+		}
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get repository", resp, body), nil, nil
+	}
+
+	result, err := utils.NewToolResultJSON(convertToMinimalRepositorySettings(repository))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
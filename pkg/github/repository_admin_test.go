@@ -0,0 +1,612 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateRepositorySettings(t *testing.T) {
+	// Verify tool definition once
+	serverTool := UpdateRepositorySettings(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "update_repository_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, schema.Properties, "description")
+	assert.Contains(t, schema.Properties, "topics")
+	assert.Contains(t, schema.Properties, "default_branch")
+	assert.Contains(t, schema.Properties, "allow_squash_merge")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+	assert.Equal(t, []string{"repo"}, serverTool.RequiredScopes)
+
+	mockRepo := &github.Repository{
+		FullName:         github.Ptr("owner/repo"),
+		HTMLURL:          github.Ptr("https://github.com/owner/repo"),
+		Description:      github.Ptr("New description"),
+		DefaultBranch:    github.Ptr("main"),
+		Topics:           []string{"go", "mcp"},
+		AllowSquashMerge: github.Ptr(true),
+		AllowMergeCommit: github.Ptr(false),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings MinimalRepositorySettings
+	}{
+		{
+			name: "updates description and merge settings",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"description":        "New description",
+						"allow_merge_commit": false,
+						"allow_squash_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"description":        "New description",
+				"allow_merge_commit": false,
+				"allow_squash_merge": true,
+			},
+			expectedSettings: convertToMinimalRepositorySettings(mockRepo),
+		},
+		{
+			name: "replaces topics only",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"go", "mcp"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{"go", "mcp"}}),
+					),
+				),
+				WithRequestMatch(GetReposByOwnerByRepo, mockRepo),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"go", "mcp"},
+			},
+			expectedSettings: convertToMinimalRepositorySettings(mockRepo),
+		},
+		{
+			name:         "no update parameters",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "update fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"homepage": "https://example.com",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update repository settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings MinimalRepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
+
+func Test_SetRepositoryFeatures(t *testing.T) {
+	// Verify tool definition once
+	serverTool := SetRepositoryFeatures(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "set_repository_features", tool.Name)
+	assert.Contains(t, schema.Properties, "has_issues")
+	assert.Contains(t, schema.Properties, "has_wiki")
+	assert.Contains(t, schema.Properties, "has_discussions")
+	assert.Contains(t, schema.Properties, "allow_auto_merge")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		FullName:       github.Ptr("owner/repo"),
+		HasWiki:        github.Ptr(false),
+		HasDiscussions: github.Ptr(true),
+		AllowAutoMerge: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "toggles features",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"has_wiki":         false,
+						"has_discussions":  true,
+						"allow_auto_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"has_wiki":         false,
+				"has_discussions":  true,
+				"allow_auto_merge": true,
+			},
+		},
+		{
+			name:         "no features provided",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "No features provided.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings MinimalRepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.False(t, settings.HasWiki)
+			assert.True(t, settings.HasDiscussions)
+			assert.True(t, settings.AllowAutoMerge)
+		})
+	}
+}
+
+func Test_SetRepositoryVisibility(t *testing.T) {
+	// Verify tool definition once
+	serverTool := SetRepositoryVisibility(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repository_visibility", tool.Name)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "makes repository private",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"visibility": "private",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							FullName:   github.Ptr("owner/repo"),
+							Visibility: github.Ptr("private"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+			},
+		},
+		{
+			name:         "invalid visibility",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "secret",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid visibility: secret",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings MinimalRepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, "private", settings.Visibility)
+		})
+	}
+}
+
+func Test_ArchiveRepository(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ArchiveRepository(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "archive_repository", tool.Name)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectArchived bool
+	}{
+		{
+			name: "archives repository",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"archived": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{Archived: github.Ptr(true)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"archived": true,
+			},
+			expectArchived: true,
+		},
+		{
+			name: "unarchive fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"archived": false,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to unarchive repository",
+		},
+		{
+			name:         "missing archived",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: archived",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var settings MinimalRepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, tc.expectArchived, settings.Archived)
+		})
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	// Verify tool definition once
+	serverTool := TransferRepository(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "transfer_repository", tool.Name)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "new_owner"})
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, []string{"repo", "admin:org"}, serverTool.RequiredScopes)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "transfer accepted",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"new_owner": "new-org",
+						"new_name":  "renamed",
+						"team_ids":  []interface{}{float64(42)},
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.Repository{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+				"new_name":  "renamed",
+				"team_ids":  []interface{}{"42"},
+			},
+			expectedText: "Transfer of owner/repo to new-org is in progress",
+		},
+		{
+			name: "transfer fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposTransferByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "new-org already has a repository with this name"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	serverTool := CreateRepositoryFromTemplate(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.ElementsMatch(t, schema.Required, []string{"template_owner", "template_repo", "name"})
+
+	mockRepo := &github.Repository{
+		ID:      github.Ptr(int64(123)),
+		HTMLURL: github.Ptr("https://github.com/my-org/new-repo"),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse MinimalResponse
+	}{
+		{
+			name: "creates repository from template",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":                 "new-repo",
+						"owner":                "my-org",
+						"private":              true,
+						"include_all_branches": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "octo",
+				"template_repo":  "template",
+				"name":           "new-repo",
+				"owner":          "my-org",
+				"private":        true,
+			},
+			expectedResponse: MinimalResponse{ID: "123", URL: "https://github.com/my-org/new-repo"},
+		},
+		{
+			name: "template not found",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposGenerateByTemplateOwnerByTemplateRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "octo",
+				"template_repo":  "missing",
+				"name":           "new-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+)
+
+// Diff sides as used by the GraphQL review APIs. LEFT is the base version of a file and
+// RIGHT is the head version.
+const (
+	diffSideLeft  = "LEFT"
+	diffSideRight = "RIGHT"
+)
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// diffLine is a single line of a diff hunk. OldLine is set for context and deleted lines,
+// NewLine for context and added lines.
+type diffLine struct {
+	Type    string `json:"type"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Content string `json:"content"`
+}
+
+// Types of diff lines.
+const (
+	diffLineContext = "context"
+	diffLineAdd     = "add"
+	diffLineDelete  = "delete"
+)
+
+// diffHunk is a parsed hunk of a unified diff.
+type diffHunk struct {
+	Header   string     `json:"header"`
+	OldStart int        `json:"old_start"`
+	OldLines int        `json:"old_lines"`
+	NewStart int        `json:"new_start"`
+	NewLines int        `json:"new_lines"`
+	Lines    []diffLine `json:"lines"`
+
+	// oldNoNewline and newNoNewline record a "\ No newline at end of file" marker for the old
+	// and new version of the last line of the hunk.
+	oldNoNewline bool
+	newNoNewline bool
+}
+
+// hasLine reports whether the line of the given side is part of the hunk and so can be
+// commented on.
+func (h diffHunk) hasLine(side string, line int) bool {
+	if side == diffSideLeft {
+		return h.OldLines > 0 && line >= h.OldStart && line < h.OldStart+h.OldLines
+	}
+	return h.NewLines > 0 && line >= h.NewStart && line < h.NewStart+h.NewLines
+}
+
+// lineRange describes the lines of the given side covered by the hunk, e.g. "10-15".
+func (h diffHunk) lineRange(side string) string {
+	start, count := h.NewStart, h.NewLines
+	if side == diffSideLeft {
+		start, count = h.OldStart, h.OldLines
+	}
+	switch count {
+	case 0:
+		return ""
+	case 1:
+		return strconv.Itoa(start)
+	default:
+		return fmt.Sprintf("%d-%d", start, start+count-1)
+	}
+}
+
+// fileDiff is the parsed diff of a single file of a pull request. Mode is only set when the
+// diff creates the file or changes its mode. Unknown is set when the file is changed but
+// GitHub did not return its patch, so its hunks are not known.
+type fileDiff struct {
+	Path         string
+	PreviousPath string
+	Status       string
+	Mode         string
+	Binary       bool
+	Unknown      bool
+	Hunks        []diffHunk
+}
+
+// commentableLines lists the line ranges of the given side that review comments can be placed on.
+func (f *fileDiff) commentableLines(side string) []string {
+	ranges := []string{}
+	for _, hunk := range f.Hunks {
+		if r := hunk.lineRange(side); r != "" {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// parsePatch parses the hunks of a unified diff patch.
+func parsePatch(patch string) ([]diffHunk, error) {
+	var hunks []diffHunk
+	var oldLine, newLine int
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			match := hunkHeaderRegexp.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("invalid hunk header: %s", line)
+			}
+			hunk := diffHunk{
+				Header:   line,
+				OldStart: atoiOrDefault(match[1], 0),
+				OldLines: atoiOrDefault(match[2], 1),
+				NewStart: atoiOrDefault(match[3], 0),
+				NewLines: atoiOrDefault(match[4], 1),
+				Lines:    []diffLine{},
+			}
+			hunks = append(hunks, hunk)
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			continue
+		}
+		if len(hunks) == 0 {
+			continue
+		}
+
+		hunk := &hunks[len(hunks)-1]
+		if line == "" {
+			// Editors and mail clients strip the trailing space of blank context lines. Count
+			// them as context while the hunk still expects lines on both sides, so that the
+			// empty string after the final newline is not mistaken for one.
+			if oldLine < hunk.OldStart+hunk.OldLines && newLine < hunk.NewStart+hunk.NewLines {
+				hunk.Lines = append(hunk.Lines, diffLine{Type: diffLineContext, OldLine: oldLine, NewLine: newLine})
+				oldLine++
+				newLine++
+			}
+			continue
+		}
+		switch line[0] {
+		case '+':
+			hunk.Lines = append(hunk.Lines, diffLine{Type: diffLineAdd, NewLine: newLine, Content: line[1:]})
+			newLine++
+		case '-':
+			hunk.Lines = append(hunk.Lines, diffLine{Type: diffLineDelete, OldLine: oldLine, Content: line[1:]})
+			oldLine++
+		case ' ':
+			hunk.Lines = append(hunk.Lines, diffLine{Type: diffLineContext, OldLine: oldLine, NewLine: newLine, Content: line[1:]})
+			oldLine++
+			newLine++
+		case '\\':
+			// "\ No newline at end of file" markers do not count as lines.
+			if len(hunk.Lines) > 0 {
+				switch hunk.Lines[len(hunk.Lines)-1].Type {
+				case diffLineDelete:
+					hunk.oldNoNewline = true
+				case diffLineAdd:
+					hunk.newNoNewline = true
+				default:
+					hunk.oldNoNewline = true
+					hunk.newNoNewline = true
+				}
+			}
+		}
+	}
+	return hunks, nil
+}
+
+func atoiOrDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// parsePullRequestDiff parses a multi-file git diff, as returned by the pull request diff
+// media type, into the diff of each file in diff order.
+func parsePullRequestDiff(raw string) ([]*fileDiff, error) {
+	var files []*fileDiff
+	var file *fileDiff
+	var patch []string
+
+	flush := func() error {
+		if file == nil {
+			return nil
+		}
+		hunks, err := parsePatch(strings.Join(patch, "\n"))
+		if err != nil {
+			return fmt.Errorf("failed to parse diff of %s: %w", file.Path, err)
+		}
+		file.Hunks = hunks
+		files = append(files, file)
+		return nil
+	}
+
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			file = &fileDiff{Status: "modified"}
+			file.PreviousPath, file.Path = splitDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+			patch = nil
+			continue
+		}
+		if file == nil {
+			continue
+		}
+		if len(patch) > 0 || strings.HasPrefix(line, "@@") {
+			patch = append(patch, line)
+			continue
+		}
+
+		// Extended header lines before the first hunk
+		switch {
+		case strings.HasPrefix(line, "new file mode "):
+			file.Status = "added"
+			file.Mode = strings.TrimPrefix(line, "new file mode ")
+		case strings.HasPrefix(line, "new mode "):
+			file.Mode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = "removed"
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = "renamed"
+			file.PreviousPath = unquoteDiffPath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			file.Path = unquoteDiffPath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "--- "):
+			if path, ok := strings.CutPrefix(unquoteDiffPath(strings.TrimPrefix(line, "--- ")), "a/"); ok {
+				file.PreviousPath = path
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path, ok := strings.CutPrefix(unquoteDiffPath(strings.TrimPrefix(line, "+++ ")), "b/"); ok {
+				file.Path = path
+			}
+		case strings.HasPrefix(line, "Binary files "), strings.HasPrefix(line, "GIT binary patch"):
+			file.Binary = true
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.Status != "renamed" {
+			file.PreviousPath = ""
+		}
+	}
+	return files, nil
+}
+
+// splitDiffGitPaths splits the "a/<old> b/<new>" part of a "diff --git" line. Paths containing
+// " b/" are ambiguous here and are corrected from the ---/+++ or rename header lines.
+func splitDiffGitPaths(paths string) (string, string) {
+	// Git quotes paths with special characters, e.g. "a/caf\303\251.md".
+	if strings.HasPrefix(paths, `"`) || strings.HasSuffix(paths, `"`) {
+		var oldPath, newPath string
+		if strings.HasPrefix(paths, `"`) {
+			end := closingQuote(paths)
+			oldPath, newPath = paths[:end+1], strings.TrimPrefix(paths[end+1:], " ")
+		} else {
+			idx := strings.LastIndex(paths, ` "`)
+			oldPath, newPath = paths[:max(idx, 0)], paths[idx+1:]
+		}
+		return strings.TrimPrefix(unquoteDiffPath(oldPath), "a/"), strings.TrimPrefix(unquoteDiffPath(newPath), "b/")
+	}
+	if idx := strings.Index(paths, " b/"); idx >= 0 {
+		return strings.TrimPrefix(paths[:idx], "a/"), paths[idx+len(" b/"):]
+	}
+	return "", paths
+}
+
+// closingQuote returns the index of the quote that ends the quoted string at the start of s, or
+// the last index of s if the quote is not closed.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(s) - 1
+}
+
+// unquoteDiffPath decodes a path that git wrote as a C-style quoted string, whose escapes are
+// a subset of Go's. Paths that are not quoted are returned unchanged.
+func unquoteDiffPath(path string) string {
+	if len(path) < 2 || path[0] != '"' || path[len(path)-1] != '"' {
+		return path
+	}
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return path
+	}
+	return unquoted
+}
+
+// getPullRequestDiffFiles fetches the diff of a pull request and parses it into per-file diffs.
+func getPullRequestDiffFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) ([]*fileDiff, *github.Response, error) {
+	raw, resp, err := client.PullRequests.GetRaw(ctx, owner, repo, pullNumber, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		return nil, resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, resp, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, resp, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	files, err := parsePullRequestDiff(raw)
+	if err != nil {
+		return nil, resp, err
+	}
+	return files, resp, nil
+}
+
+// maxReviewDiffFilePages bounds the pages of changed files fetched when the diff of a pull
+// request is unavailable. GitHub lists at most 3000 files of a pull request.
+const maxReviewDiffFilePages = 30
+
+// getReviewCommentDiffs returns the per-file diffs that review comment positions are checked
+// against, indexed by path. It returns nil when no diff is available, in which case positions
+// are left for GitHub to check.
+func getReviewCommentDiffs(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) map[string]*fileDiff {
+	files, _, err := getPullRequestFileDiffs(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return nil
+	}
+	return diffFilesByPath(files)
+}
+
+// getPullRequestFileDiffs returns the per-file diffs of a pull request in diff order. GitHub
+// refuses to render the diff of very large pull requests, so the patches of the changed files
+// are used when the diff is unavailable.
+func getPullRequestFileDiffs(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) ([]*fileDiff, *github.Response, error) {
+	if files, _, err := getPullRequestDiffFiles(ctx, client, owner, repo, pullNumber); err == nil {
+		return files, nil, nil
+	}
+
+	var diffs []*fileDiff
+	opts := &github.ListOptions{PerPage: 100}
+	for range maxReviewDiffFilePages {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+
+		for _, file := range files {
+			diff := &fileDiff{
+				Path:    file.GetFilename(),
+				Status:  file.GetStatus(),
+				Unknown: file.GetPatch() == "",
+			}
+			if file.GetStatus() == "renamed" {
+				diff.PreviousPath = file.GetPreviousFilename()
+			}
+			if !diff.Unknown {
+				if diff.Hunks, err = parsePatch(file.GetPatch()); err != nil {
+					diff.Unknown = true
+				}
+			}
+			diffs = append(diffs, diff)
+		}
+		if resp.NextPage == 0 {
+			return diffs, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+	// More files are changed than can be listed, so a missing path proves nothing.
+	return nil, nil, fmt.Errorf("pull request #%d changes more files than can be listed", pullNumber)
+}
+
+// diffFilesByPath indexes per-file diffs by their path in the head of the pull request.
+func diffFilesByPath(files []*fileDiff) map[string]*fileDiff {
+	diffs := make(map[string]*fileDiff, len(files))
+	for _, file := range files {
+		diffs[file.Path] = file
+	}
+	return diffs
+}
+
+// validateDiffPosition checks that a single or multi-line review comment position exists in
+// the diff of a pull request. startLine is 0 for single line comments.
+func validateDiffPosition(diffs map[string]*fileDiff, path string, line int, side string, startLine int, startSide string) error {
+	diff, ok := diffs[path]
+	if !ok {
+		return fmt.Errorf("%s is not changed in the pull request", path)
+	}
+	if diff.Unknown {
+		// The patch is too large or binary; leave the position for GitHub to check.
+		return nil
+	}
+	if diff.Binary {
+		return fmt.Errorf("%s is a binary file, so line comments cannot be added to it", path)
+	}
+	if len(diff.Hunks) == 0 {
+		return fmt.Errorf("%s has no changed lines, so line comments cannot be added to it", path)
+	}
+	if line < 1 {
+		return fmt.Errorf("line must be a positive number")
+	}
+	if startLine != 0 && startSide == side && startLine > line {
+		return fmt.Errorf("startLine %d must not be after line %d", startLine, line)
+	}
+
+	for _, hunk := range diff.Hunks {
+		if !hunk.hasLine(side, line) {
+			continue
+		}
+		if startLine != 0 && !hunk.hasLine(startSide, startLine) {
+			return fmt.Errorf("startLine %d (%s) and line %d (%s) of %s must be in the same diff hunk %s", startLine, startSide, line, side, path, hunk.Header)
+		}
+		return nil
+	}
+
+	ranges := diff.commentableLines(side)
+	if len(ranges) == 0 {
+		return fmt.Errorf("line %d is not part of the diff of %s: there are no %s lines to comment on", line, path, side)
+	}
+	return fmt.Errorf("line %d is not part of the diff of %s on the %s side; commentable lines are %s", line, path, side, strings.Join(ranges, ", "))
+}
+
+// DiffHunksFile is the output type for the hunks of a file returned by get_diff_hunks.
+// PatchUnavailable is set when GitHub did not return the patch of the file, so its hunks are
+// not known.
+type DiffHunksFile struct {
+	Path             string              `json:"path"`
+	PreviousPath     string              `json:"previous_path,omitempty"`
+	Status           string              `json:"status"`
+	Binary           bool                `json:"binary,omitempty"`
+	PatchUnavailable bool                `json:"patch_unavailable,omitempty"`
+	CommentableLines map[string][]string `json:"commentable_lines"`
+	Hunks            []DiffHunkEntry     `json:"hunks"`
+}
+
+// DiffHunkEntry is a hunk of a file diff together with its index within the file.
+type DiffHunkEntry struct {
+	Index int `json:"index"`
+	diffHunk
+}
+
+// paginateDiffHunks returns one page of hunks across all files, grouped by file. Files without
+// hunks, such as binary files, take up one entry so that they are still listed.
+func paginateDiffHunks(files []*fileDiff, page, perPage int) ([]DiffHunksFile, bool) {
+	type hunkRef struct {
+		file int
+		hunk int
+	}
+	var refs []hunkRef
+	for i, file := range files {
+		if len(file.Hunks) == 0 {
+			refs = append(refs, hunkRef{file: i, hunk: -1})
+			continue
+		}
+		for j := range file.Hunks {
+			refs = append(refs, hunkRef{file: i, hunk: j})
+		}
+	}
+
+	start := (page - 1) * perPage
+	if start < 0 || start >= len(refs) {
+		return []DiffHunksFile{}, false
+	}
+	end := min(start+perPage, len(refs))
+
+	result := []DiffHunksFile{}
+	for _, ref := range refs[start:end] {
+		file := files[ref.file]
+		if len(result) == 0 || result[len(result)-1].Path != file.Path {
+			result = append(result, DiffHunksFile{
+				Path:             file.Path,
+				PreviousPath:     file.PreviousPath,
+				Status:           file.Status,
+				Binary:           file.Binary,
+				PatchUnavailable: file.Unknown,
+				CommentableLines: map[string][]string{
+					diffSideLeft:  file.commentableLines(diffSideLeft),
+					diffSideRight: file.commentableLines(diffSideRight),
+				},
+				Hunks: []DiffHunkEntry{},
+			})
+		}
+		if ref.hunk >= 0 {
+			entry := &result[len(result)-1]
+			entry.Hunks = append(entry.Hunks, DiffHunkEntry{Index: ref.hunk, diffHunk: file.Hunks[ref.hunk]})
+		}
+	}
+	return result, end < len(refs)
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParsePatch(t *testing.T) {
+	patch := "@@ -1,3 +1,4 @@ package main\n" +
+		" import \"fmt\"\n" +
+		"-var a = 1\n" +
+		"+var a = 2\n" +
+		"+var b = 3\n" +
+		" func main() {}\n" +
+		"@@ -20 +21,0 @@\n" +
+		"-removed\n" +
+		"\\ No newline at end of file"
+
+	hunks, err := parsePatch(patch)
+	require.NoError(t, err)
+	require.Len(t, hunks, 2)
+
+	assert.Equal(t, "@@ -1,3 +1,4 @@ package main", hunks[0].Header)
+	assert.Equal(t, []diffLine{
+		{Type: diffLineContext, OldLine: 1, NewLine: 1, Content: "import \"fmt\""},
+		{Type: diffLineDelete, OldLine: 2, Content: "var a = 1"},
+		{Type: diffLineAdd, NewLine: 2, Content: "var a = 2"},
+		{Type: diffLineAdd, NewLine: 3, Content: "var b = 3"},
+		{Type: diffLineContext, OldLine: 3, NewLine: 4, Content: "func main() {}"},
+	}, hunks[0].Lines)
+
+	assert.Equal(t, 20, hunks[1].OldStart)
+	assert.Equal(t, 1, hunks[1].OldLines)
+	assert.Equal(t, 21, hunks[1].NewStart)
+	assert.Equal(t, 0, hunks[1].NewLines)
+	assert.Equal(t, []diffLine{{Type: diffLineDelete, OldLine: 20, Content: "removed"}}, hunks[1].Lines)
+
+	_, err = parsePatch("@@ invalid @@\n+line")
+	assert.Error(t, err)
+
+	// The blank context line lost its leading space; the trailing newline is not a line.
+	hunks, err = parsePatch("@@ -1,3 +1,3 @@\n a\n\n-b\n+B\n")
+	require.NoError(t, err)
+	require.Len(t, hunks, 1)
+	assert.Equal(t, []diffLine{
+		{Type: diffLineContext, OldLine: 1, NewLine: 1, Content: "a"},
+		{Type: diffLineContext, OldLine: 2, NewLine: 2},
+		{Type: diffLineDelete, OldLine: 3, Content: "b"},
+		{Type: diffLineAdd, NewLine: 3, Content: "B"},
+	}, hunks[0].Lines)
+}
+
+func Test_ParsePullRequestDiff(t *testing.T) {
+	raw := "diff --git a/main.go b/main.go\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" package main\n" +
+		"-// old\n" +
+		"+// new\n" +
+		"@@ -10 +10 @@ func main() {\n" +
+		"-\tfoo()\n" +
+		"+\tbar()\n" +
+		"diff --git a/new.go b/new.go\n" +
+		"new file mode 100644\n" +
+		"index 0000000..8a4f5c3\n" +
+		"--- /dev/null\n" +
+		"+++ b/new.go\n" +
+		"@@ -0,0 +1 @@\n" +
+		"+package main\n" +
+		"diff --git a/old.go b/old.go\n" +
+		"deleted file mode 100644\n" +
+		"index 8a4f5c3..0000000\n" +
+		"--- a/old.go\n" +
+		"+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-package main\n" +
+		"diff --git a/docs/a b/c.md b/docs/d.md\n" +
+		"similarity index 100%\n" +
+		"rename from docs/a b/c.md\n" +
+		"rename to docs/d.md\n" +
+		"diff --git a/logo.png b/logo.png\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"Binary files a/logo.png and b/logo.png differ\n" +
+		"diff --git \"a/caf\\303\\251.md\" \"b/caf\\303\\251.md\"\n" +
+		"index 5d6e7b2..8a4f5c3 100644\n" +
+		"--- \"a/caf\\303\\251.md\"\n" +
+		"+++ \"b/caf\\303\\251.md\"\n" +
+		"@@ -1 +1 @@\n" +
+		"-old\n" +
+		"+new\n" +
+		"diff --git a/notes.txt \"b/tab\\there.txt\"\n" +
+		"similarity index 100%\n" +
+		"rename from notes.txt\n" +
+		"rename to \"tab\\there.txt\"\n" +
+		"diff --git \"a/sp ace \\\"1\\\".png\" \"b/sp ace \\\"1\\\".png\"\n" +
+		"Binary files differ\n"
+
+	files, err := parsePullRequestDiff(raw)
+	require.NoError(t, err)
+	require.Len(t, files, 8)
+
+	assert.Equal(t, "main.go", files[0].Path)
+	assert.Equal(t, "modified", files[0].Status)
+	assert.Empty(t, files[0].PreviousPath)
+	require.Len(t, files[0].Hunks, 2)
+	assert.Equal(t, []string{"1-2", "10"}, files[0].commentableLines(diffSideRight))
+	assert.Equal(t, []diffLine{
+		{Type: diffLineDelete, OldLine: 10, Content: "\tfoo()"},
+		{Type: diffLineAdd, NewLine: 10, Content: "\tbar()"},
+	}, files[0].Hunks[1].Lines)
+
+	assert.Equal(t, "new.go", files[1].Path)
+	assert.Equal(t, "added", files[1].Status)
+	assert.Empty(t, files[1].commentableLines(diffSideLeft))
+	assert.Equal(t, []string{"1"}, files[1].commentableLines(diffSideRight))
+
+	assert.Equal(t, "old.go", files[2].Path)
+	assert.Equal(t, "removed", files[2].Status)
+	assert.Equal(t, []string{"1"}, files[2].commentableLines(diffSideLeft))
+
+	assert.Equal(t, "docs/d.md", files[3].Path)
+	assert.Equal(t, "docs/a b/c.md", files[3].PreviousPath)
+	assert.Equal(t, "renamed", files[3].Status)
+	assert.Empty(t, files[3].Hunks)
+
+	assert.Equal(t, "logo.png", files[4].Path)
+	assert.True(t, files[4].Binary)
+	assert.Empty(t, files[4].Hunks)
+
+	// Git quotes paths with special characters.
+	assert.Equal(t, "café.md", files[5].Path)
+	assert.Empty(t, files[5].PreviousPath)
+	require.Len(t, files[5].Hunks, 1)
+
+	assert.Equal(t, "tab\there.txt", files[6].Path)
+	assert.Equal(t, "notes.txt", files[6].PreviousPath)
+	assert.Equal(t, "renamed", files[6].Status)
+
+	assert.Equal(t, `sp ace "1".png`, files[7].Path)
+	assert.True(t, files[7].Binary)
+}
+
+func Test_ValidateDiffPosition(t *testing.T) {
+	hunks, err := parsePatch("@@ -1,3 +1,4 @@\n a\n-b\n+c\n+d\n e\n@@ -20 +21,0 @@\n-removed")
+	require.NoError(t, err)
+	diffs := map[string]*fileDiff{
+		"file.go":   {Path: "file.go", Status: "modified", Hunks: hunks},
+		"logo.png":  {Path: "logo.png", Status: "modified", Binary: true},
+		"moved.txt": {Path: "moved.txt", PreviousPath: "original.txt", Status: "renamed"},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		line        int
+		side        string
+		startLine   int
+		startSide   string
+		expectedErr string
+	}{
+		{name: "added line", path: "file.go", line: 3, side: diffSideRight},
+		{name: "context line on the left", path: "file.go", line: 3, side: diffSideLeft},
+		{name: "removed line of deletion only hunk", path: "file.go", line: 20, side: diffSideLeft},
+		{name: "multi-line range in one hunk", path: "file.go", line: 4, side: diffSideRight, startLine: 1, startSide: diffSideRight},
+		{name: "multi-line range across sides", path: "file.go", line: 3, side: diffSideRight, startLine: 2, startSide: diffSideLeft},
+		{
+			name: "line outside the diff", path: "file.go", line: 10, side: diffSideRight,
+			expectedErr: "line 10 is not part of the diff of file.go on the RIGHT side; commentable lines are 1-4",
+		},
+		{
+			name: "range across hunks", path: "file.go", line: 20, side: diffSideLeft, startLine: 1, startSide: diffSideRight,
+			expectedErr: "startLine 1 (RIGHT) and line 20 (LEFT) of file.go must be in the same diff hunk @@ -20 +21,0 @@",
+		},
+		{
+			name: "start after end", path: "file.go", line: 2, side: diffSideRight, startLine: 4, startSide: diffSideRight,
+			expectedErr: "startLine 4 must not be after line 2",
+		},
+		{
+			name: "binary file", path: "logo.png", line: 1, side: diffSideRight,
+			expectedErr: "logo.png is a binary file, so line comments cannot be added to it",
+		},
+		{
+			name: "renamed file without changes", path: "moved.txt", line: 1, side: diffSideRight,
+			expectedErr: "moved.txt has no changed lines, so line comments cannot be added to it",
+		},
+		{
+			name: "file not in pull request", path: "other.go", line: 1, side: diffSideRight,
+			expectedErr: "other.go is not changed in the pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDiffPosition(diffs, tc.path, tc.line, tc.side, tc.startLine, tc.startSide)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func Test_PaginateDiffHunks(t *testing.T) {
+	hunks, err := parsePatch("@@ -1 +1 @@\n-a\n+b\n@@ -10 +10 @@\n-c\n+d")
+	require.NoError(t, err)
+	files := []*fileDiff{
+		{Path: "a.go", Status: "modified", Hunks: hunks},
+		{Path: "logo.png", Status: "added", Binary: true},
+		{Path: "b.go", Status: "modified", Hunks: hunks[:1]},
+	}
+
+	page, hasNextPage := paginateDiffHunks(files, 1, 2)
+	assert.True(t, hasNextPage)
+	require.Len(t, page, 1)
+	assert.Equal(t, "a.go", page[0].Path)
+	require.Len(t, page[0].Hunks, 2)
+	assert.Equal(t, 1, page[0].Hunks[1].Index)
+	assert.Equal(t, map[string][]string{diffSideLeft: {"1", "10"}, diffSideRight: {"1", "10"}}, page[0].CommentableLines)
+
+	page, hasNextPage = paginateDiffHunks(files, 2, 2)
+	assert.False(t, hasNextPage)
+	require.Len(t, page, 2)
+	assert.Equal(t, "logo.png", page[0].Path)
+	assert.True(t, page[0].Binary)
+	assert.Empty(t, page[0].Hunks)
+	assert.Equal(t, "b.go", page[1].Path)
+	require.Len(t, page[1].Hunks, 1)
+	assert.Equal(t, 0, page[1].Hunks[0].Index)
+
+	page, hasNextPage = paginateDiffHunks(files, 3, 2)
+	assert.False(t, hasNextPage)
+	assert.Empty(t, page)
+}
@@ -0,0 +1,618 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetBranchRules creates a tool to get the effective rules that apply to a branch.
+func GetBranchRules(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name:        "get_branch_rules",
+			Description: t("TOOL_GET_BRANCH_RULES_DESCRIPTION", "Get all the active rules that apply to a branch, from repository, organization and enterprise rulesets. Use this before pushing or merging to understand which rules (required reviews, status checks, linear history, etc.) may block the operation."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_BRANCH_RULES_USER_TITLE", "Get rules for a branch"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"branch": {
+						Type:        "string",
+						Description: "Branch name",
+					},
+				},
+				Required: []string{"owner", "repo", "branch"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := RequiredParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			// The go-github BranchRules type groups rules by type and has no JSON tags for
+			// marshalling, so we request the flat list of rules directly.
+			u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?page=%d&per_page=%d", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(branch), pagination.Page, pagination.PerPage)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var rules []MinimalBranchRule
+			resp, err := client.Do(ctx, req, &rules)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get rules for branch", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(rules)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// RulesetRead creates a tool to list and get repository or organization rulesets.
+func RulesetRead(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "ruleset_read",
+			Description: t("TOOL_RULESET_READ_DESCRIPTION", "List or get rulesets for a repository, or for an organization when 'repo' is omitted."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_RULESET_READ_USER_TITLE", "Read rulesets"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The read operation to perform:
+- 'list': list rulesets (without their rules).
+- 'get': get a single ruleset, including its conditions, rules and bypass actors. Requires 'ruleset_id'.`,
+						Enum: []any{"list", "get"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner, or organization name when 'repo' is omitted",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. Omit to read organization-level rulesets.",
+					},
+					"ruleset_id": {
+						Type:        "number",
+						Description: "Ruleset ID. Required for 'get'.",
+					},
+					"include_parents": {
+						Type:        "boolean",
+						Description: "For repository rulesets, include rulesets configured at the organization or enterprise level that apply to the repository",
+						Default:     json.RawMessage(`true`),
+					},
+				},
+				Required: []string{"method", "owner"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo, scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := OptionalParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			includeParents, err := OptionalBoolParamWithDefault(args, "include_parents", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var (
+				ruleset any
+				resp    *github.Response
+			)
+			switch method {
+			case "list":
+				pagination, err := OptionalPaginationParams(args)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				listOptions := github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage}
+				if repo == "" {
+					ruleset, resp, err = client.Organizations.GetAllRepositoryRulesets(ctx, owner, &listOptions)
+				} else {
+					ruleset, resp, err = client.Repositories.GetAllRulesets(ctx, owner, repo, &github.RepositoryListRulesetsOptions{
+						IncludesParents: github.Ptr(includeParents),
+						ListOptions:     listOptions,
+					})
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list rulesets", resp, err), nil, nil
+				}
+			case "get":
+				rulesetID, err := RequiredBigInt(args, "ruleset_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if repo == "" {
+					ruleset, resp, err = client.Organizations.GetRepositoryRuleset(ctx, owner, rulesetID)
+				} else {
+					ruleset, resp, err = client.Repositories.GetRuleset(ctx, owner, repo, rulesetID, includeParents)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get ruleset", resp, err), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: list, get", method)), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(ruleset)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// RulesetWrite creates a tool to create, update and delete repository or organization rulesets.
+func RulesetWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "ruleset_write",
+			Description: t("TOOL_RULESET_WRITE_DESCRIPTION", "Create, update or delete a ruleset for a repository, or for an organization when 'repo' is omitted."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_RULESET_WRITE_USER_TITLE", "Write rulesets"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'create': create a new ruleset from 'ruleset'.
+- 'update': update the ruleset 'ruleset_id'. Top-level fields provided in 'ruleset' replace the existing ones; other fields are kept.
+- 'delete': delete the ruleset 'ruleset_id'.`,
+						Enum: []any{"create", "update", "delete"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner, or organization name when 'repo' is omitted",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name. Omit to manage organization-level rulesets.",
+					},
+					"ruleset_id": {
+						Type:        "number",
+						Description: "Ruleset ID. Required for 'update' and 'delete'.",
+					},
+					"ruleset": {
+						Type:        "object",
+						Description: "Ruleset definition as accepted by the GitHub REST API, with fields such as 'name', 'target' (branch, tag or push), 'enforcement' (disabled, active or evaluate), 'bypass_actors', 'conditions' and 'rules'. Required for 'create' and 'update'.",
+					},
+				},
+				Required: []string{"method", "owner"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo, scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := OptionalParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			rulesetArg, err := OptionalParam[map[string]any](args, "ruleset")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "create":
+				if len(rulesetArg) == 0 {
+					return utils.NewToolResultError("ruleset is required for create"), nil, nil
+				}
+				ruleset, err := decodeRuleset(rulesetArg)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				var created *github.RepositoryRuleset
+				var resp *github.Response
+				if repo == "" {
+					created, resp, err = client.Organizations.CreateRepositoryRuleset(ctx, owner, *ruleset)
+				} else {
+					created, resp, err = client.Repositories.CreateRuleset(ctx, owner, repo, *ruleset)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create ruleset", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(created)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+
+			case "update":
+				rulesetID, err := RequiredBigInt(args, "ruleset_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if len(rulesetArg) == 0 {
+					return utils.NewToolResultError("ruleset is required for update"), nil, nil
+				}
+
+				var existing *github.RepositoryRuleset
+				var resp *github.Response
+				if repo == "" {
+					existing, resp, err = client.Organizations.GetRepositoryRuleset(ctx, owner, rulesetID)
+				} else {
+					existing, resp, err = client.Repositories.GetRuleset(ctx, owner, repo, rulesetID, false)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get ruleset", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				ruleset, err := mergeRuleset(existing, rulesetArg)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				var updated *github.RepositoryRuleset
+				if repo == "" {
+					updated, resp, err = client.Organizations.UpdateRepositoryRuleset(ctx, owner, rulesetID, *ruleset)
+				} else {
+					updated, resp, err = client.Repositories.UpdateRuleset(ctx, owner, repo, rulesetID, *ruleset)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update ruleset", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(updated)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+
+			case "delete":
+				rulesetID, err := RequiredBigInt(args, "ruleset_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				var resp *github.Response
+				if repo == "" {
+					resp, err = client.Organizations.DeleteRepositoryRuleset(ctx, owner, rulesetID)
+				} else {
+					resp, err = client.Repositories.DeleteRuleset(ctx, owner, repo, rulesetID)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete ruleset", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("ruleset %d deleted successfully", rulesetID)), nil, nil
+
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: create, update, delete", method)), nil, nil
+			}
+		},
+	)
+}
+
+// GetBranchProtection creates a tool to get the classic branch protection of a branch.
+func GetBranchProtection(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "get_branch_protection",
+			Description: t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the classic branch protection settings of a branch. Rules from rulesets are not included; use 'get_branch_rules' for those."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"branch": {
+						Type:        "string",
+						Description: "Branch name",
+					},
+				},
+				Required: []string{"owner", "repo", "branch"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := RequiredParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if err != nil {
+				if errors.Is(err, github.ErrBranchNotProtected) {
+					return utils.NewToolResultText(fmt.Sprintf("branch '%s' has no classic branch protection", branch)), nil, nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get branch protection", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result, err := utils.NewToolResultJSON(protection)
+			if err != nil {
+				return nil, nil, err
+			}
+			return result, nil, nil
+		},
+	)
+}
+
+// BranchProtectionWrite creates a tool to update or remove the classic branch protection of a branch.
+func BranchProtectionWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepoAdmin,
+		mcp.Tool{
+			Name:        "branch_protection_write",
+			Description: t("TOOL_BRANCH_PROTECTION_WRITE_DESCRIPTION", "Update or remove the classic branch protection of a branch."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_BRANCH_PROTECTION_WRITE_USER_TITLE", "Write branch protection"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'update': replace the branch protection with 'protection'.
+- 'delete': remove the branch protection.`,
+						Enum: []any{"update", "delete"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"branch": {
+						Type:        "string",
+						Description: "Branch name",
+					},
+					"protection": {
+						Type:        "object",
+						Description: "Branch protection as accepted by the GitHub REST API. 'required_status_checks', 'enforce_admins', 'required_pull_request_reviews' and 'restrictions' must be present (use null to disable). Required for 'update'.",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "branch"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := RequiredOwnerRepo(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := RequiredParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "update":
+				protectionArg, err := OptionalParam[map[string]any](args, "protection")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if len(protectionArg) == 0 {
+					return utils.NewToolResultError("protection is required for update"), nil, nil
+				}
+				raw, err := json.Marshal(protectionArg)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal protection: %w", err)
+				}
+				var preq github.ProtectionRequest
+				if err := json.Unmarshal(raw, &preq); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("invalid protection: %v", err)), nil, nil
+				}
+
+				protection, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, &preq)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update branch protection", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(protection)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+
+			case "delete":
+				resp, err := client.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove branch protection", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+					}
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to remove branch protection", resp, body), nil, nil
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("branch protection removed from '%s'", branch)), nil, nil
+
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: update, delete", method)), nil, nil
+			}
+		},
+	)
+}
+
+// decodeRuleset converts a ruleset tool argument into a RepositoryRuleset.
+func decodeRuleset(arg map[string]any) (*github.RepositoryRuleset, error) {
+	raw, err := json.Marshal(arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ruleset: %w", err)
+	}
+	var ruleset github.RepositoryRuleset
+	if err := json.Unmarshal(raw, &ruleset); err != nil {
+		return nil, fmt.Errorf("invalid ruleset: %w", err)
+	}
+	return &ruleset, nil
+}
+
+// mergeRuleset overlays the top-level fields of a ruleset tool argument onto an existing ruleset.
+func mergeRuleset(existing *github.RepositoryRuleset, arg map[string]any) (*github.RepositoryRuleset, error) {
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal existing ruleset: %w", err)
+	}
+	merged := map[string]any{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal existing ruleset: %w", err)
+	}
+	for k, v := range arg {
+		merged[k] = v
+	}
+	return decodeRuleset(merged)
+}
+
+// ruleViolationMessage appends the repository rule violations reported in a GitHub API
+// error payload to message. The message is returned unchanged if err does not describe
+// a rule violation.
+//
+// Rule violations are reported as a "Repository rule violations found" message followed
+// by one line per violated rule, and sometimes as individual entries in "errors".
+func ruleViolationMessage(message string, err error, branch string) string {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || !strings.Contains(strings.ToLower(ghErr.Message), "rule violation") {
+		return message
+	}
+
+	var violations []string
+	lines := strings.Split(ghErr.Message, "\n")
+	for _, line := range lines[1:] {
+		if line = strings.TrimSpace(line); line != "" {
+			violations = append(violations, line)
+		}
+	}
+	for _, e := range ghErr.Errors {
+		if e.Message != "" {
+			violations = append(violations, e.Message)
+		}
+	}
+
+	target := "the target branch"
+	if branch != "" {
+		target = fmt.Sprintf("branch '%s'", branch)
+	}
+	if len(violations) == 0 {
+		return fmt.Sprintf("%s: blocked by repository rules on %s. Use get_branch_rules to see the rules that apply", message, target)
+	}
+	return fmt.Sprintf("%s: blocked by repository rules on %s (%s). Use get_branch_rules to see the rules that apply", message, target, strings.Join(violations, "; "))
+}
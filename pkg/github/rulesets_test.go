@@ -0,0 +1,843 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetBranchRules(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetBranchRules(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "get_branch_rules", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "branch")
+	assert.Contains(t, schema.Properties, "page")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch"})
+
+	mockRules := []map[string]any{
+		{
+			"type":                "pull_request",
+			"ruleset_source_type": "Repository",
+			"ruleset_source":      "owner/repo",
+			"ruleset_id":          42,
+			"parameters": map[string]any{
+				"required_approving_review_count": 1,
+			},
+		},
+		{
+			"type":                "non_fast_forward",
+			"ruleset_source_type": "Organization",
+			"ruleset_source":      "owner",
+			"ruleset_id":          7,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRules  []MinimalBranchRule
+	}{
+		{
+			name: "returns rules for branch",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesBranchesByOwnerByRepoByBranch,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRules),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedRules: []MinimalBranchRule{
+				{
+					Type:              "pull_request",
+					RulesetSourceType: "Repository",
+					RulesetSource:     "owner/repo",
+					RulesetID:         42,
+					Parameters:        json.RawMessage(`{"required_approving_review_count":1}`),
+				},
+				{
+					Type:              "non_fast_forward",
+					RulesetSourceType: "Organization",
+					RulesetSource:     "owner",
+					RulesetID:         7,
+				},
+			},
+		},
+		{
+			name: "escapes the branch name",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesBranchesByOwnerByRepoByBranch,
+					func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/rules/branches/fix%231%3Fv%25", r.URL.EscapedPath())
+						assert.Equal(t, "30", r.URL.Query().Get("per_page"))
+						mockResponse(t, http.StatusOK, []map[string]any{})(w, r)
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "fix#1?v%",
+			},
+			expectedRules: []MinimalBranchRule{},
+		},
+		{
+			name:         "missing branch",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: branch",
+		},
+		{
+			name: "request fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get rules for branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var rules []MinimalBranchRule
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &rules))
+			require.Len(t, rules, len(tc.expectedRules))
+			for i, expected := range tc.expectedRules {
+				assert.Equal(t, expected.Type, rules[i].Type)
+				assert.Equal(t, expected.RulesetSourceType, rules[i].RulesetSourceType)
+				assert.Equal(t, expected.RulesetSource, rules[i].RulesetSource)
+				assert.Equal(t, expected.RulesetID, rules[i].RulesetID)
+				if expected.Parameters != nil {
+					assert.JSONEq(t, string(expected.Parameters), string(rules[i].Parameters))
+				} else {
+					assert.Empty(t, rules[i].Parameters)
+				}
+			}
+		})
+	}
+}
+
+func Test_RulesetRead(t *testing.T) {
+	// Verify tool definition once
+	serverTool := RulesetRead(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "ruleset_read", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "method")
+	assert.Contains(t, schema.Properties, "ruleset_id")
+	assert.Contains(t, schema.Properties, "include_parents")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner"})
+	assert.Equal(t, []string{"repo", "admin:org"}, serverTool.RequiredScopes)
+
+	mockRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementActive,
+	}
+	mockOrgRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(7)),
+		Name:        "org wide",
+		Enforcement: github.RulesetEnforcementEvaluate,
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedRulesets []*github.RepositoryRuleset
+	}{
+		{
+			name: "list repository rulesets",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "true",
+						"page":             "1",
+						"per_page":         "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryRuleset{mockRuleset, mockOrgRuleset}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "list",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectedRulesets: []*github.RepositoryRuleset{mockRuleset, mockOrgRuleset},
+		},
+		{
+			name: "list organization rulesets",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsRulesetsByOrg, []*github.RepositoryRuleset{mockOrgRuleset}),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "list",
+				"owner":  "owner",
+			},
+			expectedRulesets: []*github.RepositoryRuleset{mockOrgRuleset},
+		},
+		{
+			name: "get repository ruleset",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesetsByOwnerByRepoByRulesetID,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "false",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":          "get",
+				"owner":           "owner",
+				"repo":            "repo",
+				"ruleset_id":      float64(42),
+				"include_parents": false,
+			},
+			expectedRulesets: []*github.RepositoryRuleset{mockRuleset},
+		},
+		{
+			name: "get organization ruleset",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsRulesetsByOrgByRulesetID, mockOrgRuleset),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "get",
+				"owner":      "owner",
+				"ruleset_id": float64(7),
+			},
+			expectedRulesets: []*github.RepositoryRuleset{mockOrgRuleset},
+		},
+		{
+			name:         "get without ruleset_id",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "get",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: ruleset_id",
+		},
+		{
+			name: "get fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposRulesetsByOwnerByRepoByRulesetID,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "get",
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get ruleset",
+		},
+		{
+			name:         "unknown method",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "remove",
+				"owner":  "owner",
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown method: remove",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.requestArgs["method"] == "get" {
+				var ruleset github.RepositoryRuleset
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ruleset))
+				assert.Equal(t, tc.expectedRulesets[0].GetID(), ruleset.GetID())
+				assert.Equal(t, tc.expectedRulesets[0].Name, ruleset.Name)
+				return
+			}
+
+			var rulesets []*github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &rulesets))
+			require.Len(t, rulesets, len(tc.expectedRulesets))
+			for i, expected := range tc.expectedRulesets {
+				assert.Equal(t, expected.GetID(), rulesets[i].GetID())
+				assert.Equal(t, expected.Name, rulesets[i].Name)
+				assert.Equal(t, expected.Enforcement, rulesets[i].Enforcement)
+			}
+		})
+	}
+}
+
+func Test_RulesetWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := RulesetWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "ruleset_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, schema.Properties, "ruleset")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner"})
+
+	existingRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementEvaluate,
+		Rules: &github.RepositoryRulesetRules{
+			Deletion: &github.EmptyRuleParameters{},
+		},
+	}
+	updatedRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementActive,
+		Rules: &github.RepositoryRulesetRules{
+			Deletion: &github.EmptyRuleParameters{},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedID     int64
+	}{
+		{
+			name: "create repository ruleset",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"rules": []interface{}{
+							map[string]interface{}{"type": "deletion"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, updatedRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+				"ruleset": map[string]interface{}{
+					"name":        "protect main",
+					"target":      "branch",
+					"enforcement": "active",
+					"rules": []interface{}{
+						map[string]interface{}{"type": "deletion"},
+					},
+				},
+			},
+			expectedID: 42,
+		},
+		{
+			name: "update keeps fields that are not provided",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposRulesetsByOwnerByRepoByRulesetID, existingRuleset),
+				WithRequestMatchHandler(
+					PutReposRulesetsByOwnerByRepoByRulesetID,
+					expectRequestBody(t, map[string]interface{}{
+						"id":          float64(42),
+						"name":        "protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"rules": []interface{}{
+							map[string]interface{}{"type": "deletion"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+				"ruleset": map[string]interface{}{
+					"enforcement": "active",
+				},
+			},
+			expectedID: 42,
+		},
+		{
+			name: "delete repository ruleset",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposRulesetsByOwnerByRepoByRulesetID,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "delete",
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			},
+			expectedText: "ruleset 42 deleted successfully",
+		},
+		{
+			name:         "create without ruleset",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "ruleset is required for create",
+		},
+		{
+			name: "create fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "create",
+				"owner":   "owner",
+				"repo":    "repo",
+				"ruleset": map[string]interface{}{"name": "x"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create ruleset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var ruleset github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ruleset))
+			assert.Equal(t, tc.expectedID, ruleset.GetID())
+		})
+	}
+}
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetBranchProtection(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch"})
+
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   true,
+			Contexts: &[]string{"ci"},
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "returns branch protection",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposBranchesProtectionByOwnerByRepoByBranch, mockProtection),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+		},
+		{
+			name: "branch not protected",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedText: "branch 'main' has no classic branch protection",
+		},
+		{
+			name: "request fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var protection github.Protection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &protection))
+			assert.True(t, protection.GetRequiredStatusChecks().Strict)
+			assert.True(t, protection.GetEnforceAdmins().Enabled)
+		})
+	}
+}
+
+func Test_BranchProtectionWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := BranchProtectionWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "branch_protection_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, schema.Properties, "protection")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "branch"})
+
+	mockProtection := &github.Protection{
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "update branch protection",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"required_status_checks":        nil,
+						"required_pull_request_reviews": nil,
+						"enforce_admins":                true,
+						"restrictions":                  nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockProtection),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "update",
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"protection": map[string]interface{}{
+					"required_status_checks":        nil,
+					"required_pull_request_reviews": nil,
+					"enforce_admins":                true,
+					"restrictions":                  nil,
+				},
+			},
+		},
+		{
+			name: "delete branch protection",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					DeleteReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "delete",
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedText: "branch protection removed from 'main'",
+		},
+		{
+			name:         "update without protection",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "update",
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "protection is required for update",
+		},
+		{
+			name: "update fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PutReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Upgrade to GitHub Pro"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":     "update",
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "main",
+				"protection": map[string]interface{}{"enforce_admins": true},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update branch protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var protection github.Protection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &protection))
+			assert.True(t, protection.GetEnforceAdmins().Enabled)
+		})
+	}
+}
+
+func Test_RuleViolationMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		branch   string
+		expected string
+	}{
+		{
+			name: "violations listed in message",
+			err: &github.ErrorResponse{
+				Message: "Repository rule violations found\n\nRequired status check \"ci\" is expected.\n\n",
+			},
+			branch:   "main",
+			expected: "failed to push: blocked by repository rules on branch 'main' (Required status check \"ci\" is expected.). Use get_branch_rules to see the rules that apply",
+		},
+		{
+			name: "violations listed in errors",
+			err: &github.ErrorResponse{
+				Message: "Repository rule violations found",
+				Errors: []github.Error{
+					{Message: "Changes must be made through a pull request."},
+					{Message: "At least 1 approving review is required."},
+				},
+			},
+			expected: "failed to push: blocked by repository rules on the target branch (Changes must be made through a pull request.; At least 1 approving review is required.). Use get_branch_rules to see the rules that apply",
+		},
+		{
+			name:     "no violation details",
+			err:      &github.ErrorResponse{Message: "Repository rule violations found"},
+			branch:   "main",
+			expected: "failed to push: blocked by repository rules on branch 'main'. Use get_branch_rules to see the rules that apply",
+		},
+		{
+			name:     "other API error",
+			err:      &github.ErrorResponse{Message: "Not Found"},
+			expected: "failed to push",
+		},
+		{
+			name:     "non API error",
+			err:      errors.New("connection reset"),
+			expected: "failed to push",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ruleViolationMessage("failed to push", tc.err, tc.branch))
+		})
+	}
+}
@@ -40,6 +40,16 @@ var (
 		Default:     true,
 		Icon:        "repo",
 	}
+	ToolsetMetadataRepoAdmin = inventory.ToolsetMetadata{
+		ID:          "repo_admin",
+		Description: "GitHub Repository administration tools for settings, visibility, archiving and transfers",
+		Icon:        "repo",
+	}
+	ToolsetMetadataAccess = inventory.ToolsetMetadata{
+		ID:          "access",
+		Description: "GitHub Repository access tools for collaborators, invitations and team permissions",
+		Icon:        "people",
+	}
 	ToolsetMetadataGit = inventory.ToolsetMetadata{
 		ID:          "git",
 		Description: "GitHub Git API related tools for low-level Git operations",
@@ -122,6 +132,16 @@ var (
 		Description: "GitHub Stargazers related tools",
 		Icon:        "star",
 	}
+	ToolsetMetadataWebhooks = inventory.ToolsetMetadata{
+		ID:          "webhooks",
+		Description: "GitHub Webhook management and delivery inspection tools",
+		Icon:        "bell",
+	}
+	ToolsetMetadataMilestones = inventory.ToolsetMetadata{
+		ID:          "milestones",
+		Description: "GitHub Milestones related tools, including progress reports",
+		Icon:        "issue-opened",
+	}
 	ToolsetMetadataDynamic = inventory.ToolsetMetadata{
 		ID:          "dynamic",
 		Description: "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.",
@@ -168,6 +188,7 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		SearchCode(t),
 		GetCommit(t),
 		ListBranches(t),
+		GetBranchRules(t),
 		ListTags(t),
 		GetTag(t),
 		ListReleases(t),
@@ -183,16 +204,45 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		StarRepository(t),
 		UnstarRepository(t),
 
+		// Repository administration tools
+		UpdateRepositorySettings(t),
+		SetRepositoryFeatures(t),
+		SetRepositoryVisibility(t),
+		ArchiveRepository(t),
+		TransferRepository(t),
+		CreateRepositoryFromTemplate(t),
+		RulesetRead(t),
+		RulesetWrite(t),
+		GetBranchProtection(t),
+		BranchProtectionWrite(t),
+
+		// Repository access tools
+		ListRepositoryCollaborators(t),
+		RepositoryCollaboratorWrite(t),
+		ListRepositoryInvitations(t),
+		RepositoryInvitationWrite(t),
+		ListRepositoryTeams(t),
+		TeamRepositoryPermissionWrite(t),
+		ListPushAccess(t),
+
 		// Git tools
 		GetRepositoryTree(t),
 
 		// Issue tools
 		IssueRead(t),
 		SearchIssues(t),
+		FindSimilarIssues(t),
 		ListIssues(t),
 		ListIssueTypes(t),
+		ListIssueTemplates(t),
 		IssueWrite(t),
+		BulkIssueWrite(t),
 		AddIssueComment(t),
+		IssueCommentWrite(t),
+		ReactionWrite(t),
+		MinimizeComment(t),
+		IssueLifecycleWrite(t),
+		IssueDependencyWrite(t),
 		AssignCopilotToIssue(t),
 		SubIssueWrite(t),
 
@@ -201,19 +251,34 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 
 		// Organization tools
 		SearchOrgs(t),
+		ListOrgMembers(t),
+		ListOrgOutsideCollaborators(t),
+		OrgMembershipWrite(t),
+		ListOrgTeams(t),
+		TeamWrite(t),
+		TeamMembershipWrite(t),
+		OrgCustomPropertiesRead(t),
 
 		// Pull request tools
 		PullRequestRead(t),
 		ListPullRequests(t),
 		SearchPullRequests(t),
 		MergePullRequest(t),
+		PullRequestMergeAutomation(t),
 		UpdatePullRequestBranch(t),
 		CreatePullRequest(t),
+		CreatePullRequestFromPatch(t),
 		UpdatePullRequest(t),
+		SetPullRequestDraft(t),
+		GetPullRequestStack(t),
+		UpdatePullRequestStack(t),
+		PullRequestReviewers(t),
 		RequestCopilotReview(t),
+		GetCopilotReview(t),
 		PullRequestReviewWrite(t),
 		AddCommentToPendingReview(t),
 		AddReplyToPullRequestComment(t),
+		PullRequestReviewThreads(t),
 
 		// Code security tools
 		GetCodeScanningAlert(t),
@@ -295,6 +360,16 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		GetLabelForLabelsToolset(t),
 		ListLabels(t),
 		LabelWrite(t),
+
+		// Webhook tools
+		WebhookRead(t),
+		WebhookWrite(t),
+		WebhookDeliveryRead(t),
+		RedeliverWebhookDelivery(t),
+
+		// Milestone tools
+		MilestoneRead(t),
+		MilestoneWrite(t),
 	}
 }
 
@@ -9,16 +9,27 @@ func generateContextToolsetInstructions(_ *inventory.Inventory) string {
 	return "Always call 'get_me' first to understand current user permissions and context."
 }
 
-func generateIssuesToolsetInstructions(_ *inventory.Inventory) string {
-	return `## Issues
+func generateIssuesToolsetInstructions(inv *inventory.Inventory) string {
+	instructions := `## Issues
 
-Check 'list_issue_types' first for organizations to use proper issue types. Use 'search_issues' before creating new issues to avoid duplicates. Always set 'state_reason' when closing issues.`
+Check 'list_issue_types' first for organizations to use proper issue types. Use 'find_similar_issues' before creating new issues to avoid duplicates, or set 'check_similar' on 'issue_write'. Check 'list_issue_templates' before creating an issue and pass the matching 'template' and 'template_fields' to 'issue_write' so required fields and default labels are applied. Always set 'state_reason' when closing issues. To apply the same change to many issues, use 'bulk_issue_write', previewing the selected issues with 'dry_run' first. Hide spam or off-topic comments with 'minimize_comment' instead of deleting them. Check 'get_dependencies' in 'issue_read' before starting work on an issue, and record the order of work with 'issue_dependency_write'. Use 'get_sub_issue_tree' in 'issue_read' to see the progress of an epic across all levels of its sub-issues, and 'get_parent_chain' to find the epic an issue belongs to.`
+
+	if inv.HasToolset("milestones") {
+		instructions += ` Use 'milestone_read' to find the number of a milestone before setting 'milestone' on an issue.`
+	}
+	return instructions
 }
 
 func generatePullRequestsToolsetInstructions(inv *inventory.Inventory) string {
 	instructions := `## Pull Requests
 
-PR review workflow: Always use 'pull_request_review_write' with method 'create' to create a pending review, then 'add_comment_to_pending_review' to add comments, and finally 'pull_request_review_write' with method 'submit_pending' to submit the review for complex reviews with line-specific comments.`
+PR review workflow: For reviews with line-specific comments, prefer a single 'pull_request_review_write' call with method 'create', an 'event' and all inline 'comments'; comments are validated against the diff and nothing is created if any is invalid. Use 'pull_request_read' method 'get_diff_hunks' to find the lines that can be commented on. Alternatively, use method 'create' without 'event' to create a pending review, then 'add_comment_to_pending_review' to add comments, and finally 'pull_request_review_write' with method 'submit_pending' to submit the review. When reviewing again, pass the 'commit_id' of your last review from 'get_reviews' as 'base_sha' to 'get_diff' or 'get_files' to only see the changes since then.
+
+Addressing review feedback: get thread IDs with 'pull_request_read' method 'get_review_comments', then use 'pull_request_review_threads' to reply to or resolve them. After pushing fixes, method 'resolve_outdated' resolves your own threads that no longer apply.
+
+Before merging, use 'pull_request_read' method 'get_merge_readiness' and address everything listed in 'blocked_because'. Instead of waiting for checks to pass, use 'pull_request_merge_automation' to enable auto-merge or add the pull request to the merge queue. When code owner reviews are required, use 'pull_request_read' method 'get_code_owners' and pass its 'reviewers' and 'team_reviewers' to 'pull_request_reviewers'.
+
+Stacked pull requests: use 'get_pull_request_stack' to see every layer of a stack in order. After a layer is merged, use 'update_pull_request_stack' method 'retarget_children' so the layers above it target its base, then method 'update_branches' to bring the stack up to date.`
 
 	if inv.HasToolset("repos") {
 		instructions += `
@@ -39,6 +50,10 @@ func generateProjectsToolsetInstructions(_ *inventory.Inventory) string {
 
 Workflow: 1) list_project_fields (get field IDs), 2) list_project_items (with pagination), 3) optional updates.
 
+Managing projects: 'projects_write' methods that act on an item or field take the 'id' returned by list_project_items or list_project_fields. When replacing single select options with 'update_field', pass the 'id' of every option to keep, or its value is cleared on all items.
+
+Many items: to change the same fields on many items, use 'projects_write' method 'bulk_update_project_items' with a filter 'query' instead of one 'update_project_item' call per item. For board-style overviews, use 'projects_list' method 'summarize_project_items' with 'group_by' set to the Status field ID instead of listing every item.
+
 Field usage:
 	- Call list_project_fields first to understand available fields and get IDs/types before filtering.
 	- Use EXACT returned field names (case-insensitive match). Don't invent names or IDs.
@@ -0,0 +1,695 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// redactedSecret replaces secret values in webhook delivery payloads.
+const redactedSecret = "[REDACTED]"
+
+// WebhookDeliveryMessage is the request or response half of a webhook delivery.
+type WebhookDeliveryMessage struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload json.RawMessage   `json:"payload,omitempty"`
+}
+
+// WebhookDeliveryDetail is the output type for a single webhook delivery, including
+// the request sent by GitHub and the response served by the receiving endpoint.
+type WebhookDeliveryDetail struct {
+	MinimalHookDelivery
+	Request  *WebhookDeliveryMessage `json:"request,omitempty"`
+	Response *WebhookDeliveryMessage `json:"response,omitempty"`
+}
+
+// webhookTargetSchema adds the owner and repo properties shared by all webhook tools.
+func webhookTargetSchema(properties map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	properties["owner"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository owner, or the organization login when managing organization webhooks",
+	}
+	properties["repo"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository name. Omit to manage the webhooks of the 'owner' organization instead.",
+	}
+	return properties
+}
+
+// webhookTargetParams returns the owner and the optional repo of a webhook tool call.
+// An empty repo means the call targets an organization webhook.
+func webhookTargetParams(args map[string]any) (string, string, error) {
+	owner, err := RequiredParam[string](args, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := OptionalParam[string](args, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// webhookTargetName describes the owner of a webhook for use in messages.
+func webhookTargetName(owner, repo string) string {
+	if repo == "" {
+		return fmt.Sprintf("organization %s", owner)
+	}
+	return fmt.Sprintf("%s/%s", owner, repo)
+}
+
+// WebhookRead creates a tool to list and get repository and organization webhooks.
+func WebhookRead(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataWebhooks,
+		mcp.Tool{
+			Name:        "webhook_read",
+			Description: t("TOOL_WEBHOOK_READ_DESCRIPTION", "List or get the webhooks of a repository or organization. Webhook secrets are never returned; 'has_secret' reports whether one is configured."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_WEBHOOK_READ_USER_TITLE", "Read webhooks"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: webhookTargetSchema(map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The read operation to perform:
+- 'list': list the webhooks of the repository or organization.
+- 'get': get a single webhook by 'hook_id'.`,
+						Enum: []any{"list", "get"},
+					},
+					"hook_id": {
+						Type:        "number",
+						Description: "The ID of the webhook. Required for 'get'.",
+					},
+				}),
+				Required: []string{"method", "owner"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo, scopes.ReadRepoHook, scopes.AdminOrgHook},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := webhookTargetParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			hookID, err := OptionalIntParam(args, "hook_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "list":
+				opts := &github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				}
+				var hooks []*github.Hook
+				var resp *github.Response
+				if repo == "" {
+					hooks, resp, err = client.Organizations.ListHooks(ctx, owner, opts)
+				} else {
+					hooks, resp, err = client.Repositories.ListHooks(ctx, owner, repo, opts)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list webhooks", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				minimalHooks := make([]MinimalWebhook, 0, len(hooks))
+				for _, hook := range hooks {
+					minimalHooks = append(minimalHooks, convertToMinimalWebhook(hook))
+				}
+
+				result, err := utils.NewToolResultJSON(minimalHooks)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "get":
+				if hookID == 0 {
+					return utils.NewToolResultError("hook_id is required for get"), nil, nil
+				}
+				hook, resp, err := getWebhook(ctx, client, owner, repo, int64(hookID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get webhook", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalWebhook(hook))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: list, get", method)), nil, nil
+			}
+		},
+	)
+}
+
+// WebhookWrite creates a tool to create, update and delete repository and organization webhooks.
+func WebhookWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataWebhooks,
+		mcp.Tool{
+			Name:        "webhook_write",
+			Description: t("TOOL_WEBHOOK_WRITE_DESCRIPTION", "Create, update or delete a webhook of a repository or organization. Updates only change the fields that are provided; an existing secret is kept unless a new one is given. Webhook secrets are never returned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_WEBHOOK_WRITE_USER_TITLE", "Manage webhooks"),
+				ReadOnlyHint:    false,
+				DestructiveHint: github.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: webhookTargetSchema(map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform:
+- 'create': create a webhook delivering to 'url'.
+- 'update': change the configuration, events or active state of the webhook 'hook_id'.
+- 'delete': delete the webhook 'hook_id'.`,
+						Enum: []any{"create", "update", "delete"},
+					},
+					"hook_id": {
+						Type:        "number",
+						Description: "The ID of the webhook. Required for 'update' and 'delete'.",
+					},
+					"url": {
+						Type:        "string",
+						Description: "The URL payloads are delivered to. Required for 'create'.",
+					},
+					"content_type": {
+						Type:        "string",
+						Description: "The media type used to serialize payloads. Defaults to json for 'create'.",
+						Enum:        []any{"json", "form"},
+					},
+					"secret": {
+						Type:        "string",
+						Description: "Secret used to sign payloads with the X-Hub-Signature-256 header. Use an empty string with 'update' to remove the secret.",
+					},
+					"insecure_ssl": {
+						Type:        "boolean",
+						Description: "Skip TLS certificate verification when delivering payloads. Not recommended.",
+					},
+					"events": {
+						Type:        "array",
+						Description: "Events that trigger the webhook, e.g. ['push', 'pull_request']. Use ['*'] for all events. Defaults to ['push'] for 'create'.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"active": {
+						Type:        "boolean",
+						Description: "Whether payloads are delivered. Defaults to true for 'create'.",
+					},
+				}),
+				Required: []string{"method", "owner"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo, scopes.AdminRepoHook, scopes.AdminOrgHook},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := webhookTargetParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			hookID, err := OptionalIntParam(args, "hook_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			config, err := webhookConfigFromArgs(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			events, err := OptionalStringArrayParam(args, "events")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			active, activeSet, err := OptionalParamOK[bool](args, "active")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "create":
+				if config.GetURL() == "" {
+					return utils.NewToolResultError("url is required for create"), nil, nil
+				}
+				if config.ContentType == nil {
+					config.ContentType = github.Ptr("json")
+				}
+				if len(events) == 0 {
+					events = []string{"push"}
+				}
+				if !activeSet {
+					active = true
+				}
+				hook := &github.Hook{
+					Name:   github.Ptr("web"),
+					Config: config,
+					Events: events,
+					Active: github.Ptr(active),
+				}
+
+				var created *github.Hook
+				var resp *github.Response
+				if repo == "" {
+					created, resp, err = client.Organizations.CreateHook(ctx, owner, hook)
+				} else {
+					created, resp, err = client.Repositories.CreateHook(ctx, owner, repo, hook)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create webhook", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalWebhook(created))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "update":
+				if hookID == 0 {
+					return utils.NewToolResultError("hook_id is required for update"), nil, nil
+				}
+				configSet := *config != github.HookConfig{}
+				if !configSet && len(events) == 0 && !activeSet {
+					return utils.NewToolResultError("at least one of url, content_type, secret, insecure_ssl, events or active is required for update"), nil, nil
+				}
+
+				// The config endpoint only changes the provided fields, so an existing
+				// secret survives updates that do not set a new one.
+				if configSet {
+					var resp *github.Response
+					if repo == "" {
+						_, resp, err = client.Organizations.EditHookConfiguration(ctx, owner, int64(hookID), config)
+					} else {
+						_, resp, err = client.Repositories.EditHookConfiguration(ctx, owner, repo, int64(hookID), config)
+					}
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update webhook configuration", resp, err), nil, nil
+					}
+					_ = resp.Body.Close()
+				}
+
+				var hook *github.Hook
+				var resp *github.Response
+				if len(events) > 0 || activeSet {
+					edit := &github.Hook{Events: events}
+					if activeSet {
+						edit.Active = github.Ptr(active)
+					}
+					if repo == "" {
+						hook, resp, err = client.Organizations.EditHook(ctx, owner, int64(hookID), edit)
+					} else {
+						hook, resp, err = client.Repositories.EditHook(ctx, owner, repo, int64(hookID), edit)
+					}
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update webhook", resp, err), nil, nil
+					}
+				} else {
+					hook, resp, err = getWebhook(ctx, client, owner, repo, int64(hookID))
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get updated webhook", resp, err), nil, nil
+					}
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := utils.NewToolResultJSON(convertToMinimalWebhook(hook))
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "delete":
+				if hookID == 0 {
+					return utils.NewToolResultError("hook_id is required for delete"), nil, nil
+				}
+				var resp *github.Response
+				if repo == "" {
+					resp, err = client.Organizations.DeleteHook(ctx, owner, int64(hookID))
+				} else {
+					resp, err = client.Repositories.DeleteHook(ctx, owner, repo, int64(hookID))
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete webhook", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to read response body", err), nil, nil
+					}
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to delete webhook", resp, body), nil, nil
+				}
+
+				return utils.NewToolResultText(fmt.Sprintf("webhook %d deleted from %s", hookID, webhookTargetName(owner, repo))), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: create, update, delete", method)), nil, nil
+			}
+		},
+	)
+}
+
+// WebhookDeliveryRead creates a tool to list and inspect the deliveries of a webhook.
+func WebhookDeliveryRead(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataWebhooks,
+		mcp.Tool{
+			Name:        "webhook_delivery_read",
+			Description: t("TOOL_WEBHOOK_DELIVERY_READ_DESCRIPTION", "List the recent deliveries of a repository or organization webhook with their status codes, or get a single delivery with the request and response headers and payloads. Secret values in payloads are redacted."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_WEBHOOK_DELIVERY_READ_USER_TITLE", "Read webhook deliveries"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithCursorPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: webhookTargetSchema(map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The read operation to perform:
+- 'list': list recent deliveries of the webhook, newest first.
+- 'get': get a single delivery by 'delivery_id', including headers and payloads.`,
+						Enum: []any{"list", "get"},
+					},
+					"hook_id": {
+						Type:        "number",
+						Description: "The ID of the webhook",
+					},
+					"delivery_id": {
+						Type:        "number",
+						Description: "The ID of the delivery. Required for 'get'.",
+					},
+					"failed_only": {
+						Type:        "boolean",
+						Description: "For 'list', only return deliveries that did not receive a 2xx response",
+					},
+				}),
+				Required: []string{"method", "owner", "hook_id"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo, scopes.ReadRepoHook, scopes.AdminOrgHook},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, repo, err := webhookTargetParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			hookID, err := RequiredBigInt(args, "hook_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			deliveryID, err := OptionalIntParam(args, "delivery_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			failedOnly, err := OptionalParam[bool](args, "failed_only")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "list":
+				opts := &github.ListCursorOptions{
+					Cursor:  pagination.After,
+					PerPage: pagination.PerPage,
+				}
+				var deliveries []*github.HookDelivery
+				var resp *github.Response
+				if repo == "" {
+					deliveries, resp, err = client.Organizations.ListHookDeliveries(ctx, owner, hookID, opts)
+				} else {
+					deliveries, resp, err = client.Repositories.ListHookDeliveries(ctx, owner, repo, hookID, opts)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list webhook deliveries", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				minimalDeliveries := make([]MinimalHookDelivery, 0, len(deliveries))
+				for _, delivery := range deliveries {
+					if failedOnly && delivery.GetStatusCode() >= 200 && delivery.GetStatusCode() < 300 {
+						continue
+					}
+					minimalDeliveries = append(minimalDeliveries, convertToMinimalHookDelivery(delivery))
+				}
+
+				response := map[string]any{
+					"deliveries": minimalDeliveries,
+					"pageInfo": map[string]any{
+						"hasNextPage": resp.Cursor != "",
+						"endCursor":   resp.Cursor,
+					},
+				}
+
+				result, err := utils.NewToolResultJSON(response)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			case "get":
+				if deliveryID == 0 {
+					return utils.NewToolResultError("delivery_id is required for get"), nil, nil
+				}
+				var delivery *github.HookDelivery
+				var resp *github.Response
+				if repo == "" {
+					delivery, resp, err = client.Organizations.GetHookDelivery(ctx, owner, hookID, int64(deliveryID))
+				} else {
+					delivery, resp, err = client.Repositories.GetHookDelivery(ctx, owner, repo, hookID, int64(deliveryID))
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get webhook delivery", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				detail := WebhookDeliveryDetail{
+					MinimalHookDelivery: convertToMinimalHookDelivery(delivery),
+				}
+				if delivery.Request != nil {
+					detail.Request = &WebhookDeliveryMessage{
+						Headers: delivery.Request.Headers,
+						Payload: redactWebhookPayload(delivery.Request.RawPayload),
+					}
+				}
+				if delivery.Response != nil {
+					detail.Response = &WebhookDeliveryMessage{
+						Headers: delivery.Response.Headers,
+						Payload: redactWebhookPayload(delivery.Response.RawPayload),
+					}
+				}
+
+				result, err := utils.NewToolResultJSON(detail)
+				if err != nil {
+					return nil, nil, err
+				}
+				return result, nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s. Supported methods are: list, get", method)), nil, nil
+			}
+		},
+	)
+}
+
+// RedeliverWebhookDelivery creates a tool to redeliver a webhook delivery.
+func RedeliverWebhookDelivery(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataWebhooks,
+		mcp.Tool{
+			Name:        "redeliver_webhook_delivery",
+			Description: t("TOOL_REDELIVER_WEBHOOK_DELIVERY_DESCRIPTION", "Redeliver a webhook delivery of a repository or organization, typically one that failed. The redelivery shows up as a new delivery."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REDELIVER_WEBHOOK_DELIVERY_USER_TITLE", "Redeliver webhook delivery"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: webhookTargetSchema(map[string]*jsonschema.Schema{
+					"hook_id": {
+						Type:        "number",
+						Description: "The ID of the webhook",
+					},
+					"delivery_id": {
+						Type:        "number",
+						Description: "The ID of the delivery to redeliver",
+					},
+				}),
+				Required: []string{"owner", "hook_id", "delivery_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo, scopes.AdminRepoHook, scopes.AdminOrgHook},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, err := webhookTargetParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			hookID, err := RequiredBigInt(args, "hook_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			deliveryID, err := RequiredBigInt(args, "delivery_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				_, resp, err = client.Organizations.RedeliverHookDelivery(ctx, owner, hookID, deliveryID)
+			} else {
+				_, resp, err = client.Repositories.RedeliverHookDelivery(ctx, owner, repo, hookID, deliveryID)
+			}
+			message := fmt.Sprintf("redelivery of delivery %d for webhook %d on %s requested", deliveryID, hookID, webhookTargetName(owner, repo))
+			if err != nil {
+				// Redelivery is queued and acknowledged with 202 Accepted.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return utils.NewToolResultText(message), nil, nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to redeliver webhook delivery", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return utils.NewToolResultText(message), nil, nil
+		},
+	)
+}
+
+// getWebhook fetches a repository webhook, or an organization webhook when repo is empty.
+func getWebhook(ctx context.Context, client *github.Client, owner, repo string, hookID int64) (*github.Hook, *github.Response, error) {
+	if repo == "" {
+		return client.Organizations.GetHook(ctx, owner, hookID)
+	}
+	return client.Repositories.GetHook(ctx, owner, repo, hookID)
+}
+
+// webhookConfigFromArgs builds a webhook configuration from the provided tool arguments.
+// Fields that are not provided are left nil so that updates leave them unchanged, except that an
+// empty secret is kept so that updates can remove the secret.
+func webhookConfigFromArgs(args map[string]any) (*github.HookConfig, error) {
+	config := &github.HookConfig{}
+	for field, target := range map[string]**string{
+		"url":          &config.URL,
+		"content_type": &config.ContentType,
+		"secret":       &config.Secret,
+	} {
+		value, ok, err := OptionalParamOK[string](args, field)
+		if err != nil {
+			return nil, err
+		}
+		// An empty secret removes the existing one; other empty fields are ignored.
+		if ok && (value != "" || field == "secret") {
+			*target = github.Ptr(value)
+		}
+	}
+	insecureSSL, ok, err := OptionalParamOK[bool](args, "insecure_ssl")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if insecureSSL {
+			config.InsecureSSL = github.Ptr("1")
+		} else {
+			config.InsecureSSL = github.Ptr("0")
+		}
+	}
+	return config, nil
+}
+
+// redactWebhookPayload returns the payload with the value of every "secret" key replaced,
+// so webhook secrets embedded in payloads (for example in ping events) are never echoed.
+// Payloads that are not JSON objects or arrays, such as plain text response bodies, are
+// returned unchanged.
+func redactWebhookPayload(raw *json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	var payload any
+	if err := json.Unmarshal(*raw, &payload); err != nil {
+		return *raw
+	}
+	redacted, err := json.Marshal(redactSecrets(payload))
+	if err != nil {
+		return *raw
+	}
+	return redacted
+}
+
+func redactSecrets(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if strings.EqualFold(key, "secret") {
+				v[key] = redactedSecret
+				continue
+			}
+			v[key] = redactSecrets(nested)
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			v[i] = redactSecrets(nested)
+		}
+		return v
+	case string:
+		// Response bodies are JSON encoded strings that may themselves contain JSON.
+		var inner any
+		if (strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[")) && json.Unmarshal([]byte(v), &inner) == nil {
+			if encoded, err := json.Marshal(redactSecrets(inner)); err == nil {
+				return string(encoded)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
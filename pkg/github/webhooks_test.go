@@ -0,0 +1,847 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WebhookRead(t *testing.T) {
+	// Verify tool definition once
+	serverTool := WebhookRead(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "webhook_read", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "hook_id")
+	assert.Contains(t, schema.Properties, "page")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner"})
+
+	mockHook := &github.Hook{
+		ID:     github.Ptr(int64(1)),
+		Name:   github.Ptr("web"),
+		Active: github.Ptr(true),
+		Events: []string{"push", "pull_request"},
+		Config: &github.HookConfig{
+			URL:         github.Ptr("https://example.com/webhook"),
+			ContentType: github.Ptr("json"),
+			InsecureSSL: github.Ptr("0"),
+			Secret:      github.Ptr("********"),
+		},
+	}
+	mockHookWithoutSecret := &github.Hook{
+		ID:     github.Ptr(int64(2)),
+		Name:   github.Ptr("web"),
+		Active: github.Ptr(false),
+		Events: []string{"*"},
+		Config: &github.HookConfig{
+			URL: github.Ptr("https://example.com/other"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedHooks  []MinimalWebhook
+		expectedHook   *MinimalWebhook
+	}{
+		{
+			name: "lists repository webhooks",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposHooksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Hook{mockHook, mockHookWithoutSecret}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "list",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectedHooks: []MinimalWebhook{
+				{
+					ID:          1,
+					Name:        "web",
+					Active:      true,
+					Events:      []string{"push", "pull_request"},
+					URL:         "https://example.com/webhook",
+					ContentType: "json",
+					InsecureSSL: "0",
+					HasSecret:   true,
+				},
+				{
+					ID:     2,
+					Name:   "web",
+					Events: []string{"*"},
+					URL:    "https://example.com/other",
+				},
+			},
+		},
+		{
+			name: "lists organization webhooks when repo is omitted",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetOrgsHooksByOrg, []*github.Hook{mockHookWithoutSecret}),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "list",
+				"owner":  "org",
+			},
+			expectedHooks: []MinimalWebhook{convertToMinimalWebhook(mockHookWithoutSecret)},
+		},
+		{
+			name: "gets repository webhook",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposHooksByOwnerByRepoByHookID, mockHook),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "get",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectedHook: &MinimalWebhook{
+				ID:          1,
+				Name:        "web",
+				Active:      true,
+				Events:      []string{"push", "pull_request"},
+				URL:         "https://example.com/webhook",
+				ContentType: "json",
+				InsecureSSL: "0",
+				HasSecret:   true,
+			},
+		},
+		{
+			name:         "get requires hook_id",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "get",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "hook_id is required for get",
+		},
+		{
+			name: "list fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposHooksByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "list",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhooks",
+		},
+		{
+			name:         "unknown method",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "ping",
+				"owner":  "owner",
+			},
+			expectError:    true,
+			expectedErrMsg: "unknown method: ping",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, "********")
+
+			if tc.expectedHook != nil {
+				var hook MinimalWebhook
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &hook))
+				assert.Equal(t, *tc.expectedHook, hook)
+				return
+			}
+			var hooks []MinimalWebhook
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &hooks))
+			assert.Equal(t, tc.expectedHooks, hooks)
+		})
+	}
+}
+
+func Test_WebhookWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := WebhookWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "webhook_write", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, schema.Properties, "secret")
+	assert.Contains(t, schema.Properties, "events")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner"})
+
+	// GitHub obfuscates the secret in responses; the tool must not echo either form.
+	mockHook := &github.Hook{
+		ID:     github.Ptr(int64(1)),
+		Name:   github.Ptr("web"),
+		Active: github.Ptr(true),
+		Events: []string{"push"},
+		Config: &github.HookConfig{
+			URL:         github.Ptr("https://example.com/webhook"),
+			ContentType: github.Ptr("json"),
+			InsecureSSL: github.Ptr("0"),
+			Secret:      github.Ptr("********"),
+		},
+	}
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedHook   *MinimalWebhook
+	}{
+		{
+			name: "creates repository webhook with defaults",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "web",
+						"config": map[string]interface{}{
+							"url":          "https://example.com/webhook",
+							"content_type": "json",
+							"secret":       "s3cr3t",
+						},
+						"events": []interface{}{"push"},
+						"active": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockHook),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+				"url":    "https://example.com/webhook",
+				"secret": "s3cr3t",
+			},
+			expectedHook: &MinimalWebhook{
+				ID:          1,
+				Name:        "web",
+				Active:      true,
+				Events:      []string{"push"},
+				URL:         "https://example.com/webhook",
+				ContentType: "json",
+				InsecureSSL: "0",
+				HasSecret:   true,
+			},
+		},
+		{
+			name: "creates organization webhook",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostOrgsHooksByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "web",
+						"config": map[string]interface{}{
+							"url":          "https://example.com/webhook",
+							"content_type": "form",
+							"insecure_ssl": "1",
+						},
+						"events": []interface{}{"*"},
+						"active": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockHook),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":       "create",
+				"owner":        "org",
+				"url":          "https://example.com/webhook",
+				"content_type": "form",
+				"insecure_ssl": true,
+				"events":       []interface{}{"*"},
+				"active":       false,
+			},
+			expectedHook: &MinimalWebhook{
+				ID:          1,
+				Name:        "web",
+				Active:      true,
+				Events:      []string{"push"},
+				URL:         "https://example.com/webhook",
+				ContentType: "json",
+				InsecureSSL: "0",
+				HasSecret:   true,
+			},
+		},
+		{
+			name:         "create requires url",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "url is required for create",
+		},
+		{
+			name: "updates configuration only through the config endpoint",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposHooksConfigByOwnerByRepoByHookID,
+					expectRequestBody(t, map[string]interface{}{
+						"url": "https://example.com/new",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockHook.Config),
+					),
+				),
+				WithRequestMatch(GetReposHooksByOwnerByRepoByHookID, mockHook),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "update",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+				"url":     "https://example.com/new",
+			},
+			expectedHook: &MinimalWebhook{
+				ID:          1,
+				Name:        "web",
+				Active:      true,
+				Events:      []string{"push"},
+				URL:         "https://example.com/webhook",
+				ContentType: "json",
+				InsecureSSL: "0",
+				HasSecret:   true,
+			},
+		},
+		{
+			name: "removes the secret when it is set to an empty string",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposHooksConfigByOwnerByRepoByHookID,
+					expectRequestBody(t, map[string]interface{}{
+						"secret": "",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.HookConfig{URL: mockHook.Config.URL}),
+					),
+				),
+				WithRequestMatch(GetReposHooksByOwnerByRepoByHookID, &github.Hook{
+					ID:     mockHook.ID,
+					Name:   mockHook.Name,
+					Active: mockHook.Active,
+					Events: mockHook.Events,
+					Config: &github.HookConfig{URL: mockHook.Config.URL},
+				}),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "update",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+				"secret":  "",
+			},
+			expectedHook: &MinimalWebhook{
+				ID:     1,
+				Name:   "web",
+				Active: true,
+				Events: []string{"push"},
+				URL:    "https://example.com/webhook",
+			},
+		},
+		{
+			name: "updates events and active state",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PatchReposHooksByOwnerByRepoByHookID,
+					expectRequestBody(t, map[string]interface{}{
+						"events": []interface{}{"push", "release"},
+						"active": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockHook),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "update",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+				"events":  []interface{}{"push", "release"},
+				"active":  false,
+			},
+			expectedHook: &MinimalWebhook{
+				ID:          1,
+				Name:        "web",
+				Active:      true,
+				Events:      []string{"push"},
+				URL:         "https://example.com/webhook",
+				ContentType: "json",
+				InsecureSSL: "0",
+				HasSecret:   true,
+			},
+		},
+		{
+			name:         "update requires a change",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method":  "update",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of url, content_type, secret, insecure_ssl, events or active is required for update",
+		},
+		{
+			name: "deletes repository webhook",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteReposHooksByOwnerByRepoByHookID, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "delete",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectedText: "webhook 1 deleted from owner/repo",
+		},
+		{
+			name: "deletes organization webhook",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(DeleteOrgsHooksByOrgByHookID, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "delete",
+				"owner":   "org",
+				"hook_id": float64(1),
+			},
+			expectedText: "webhook 1 deleted from organization org",
+		},
+		{
+			name: "create fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposHooksByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method": "create",
+				"owner":  "owner",
+				"repo":   "repo",
+				"url":    "https://example.com/webhook",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create webhook",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, "s3cr3t")
+			assert.NotContains(t, textContent.Text, "********")
+
+			if tc.expectedHook != nil {
+				var hook MinimalWebhook
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &hook))
+				assert.Equal(t, *tc.expectedHook, hook)
+				return
+			}
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_WebhookDeliveryRead(t *testing.T) {
+	// Verify tool definition once
+	serverTool := WebhookDeliveryRead(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "webhook_delivery_read", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "delivery_id")
+	assert.Contains(t, schema.Properties, "failed_only")
+	assert.Contains(t, schema.Properties, "after")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "hook_id"})
+
+	mockDeliveries := []*github.HookDelivery{
+		{
+			ID:         github.Ptr(int64(10)),
+			GUID:       github.Ptr("guid-10"),
+			Redelivery: github.Ptr(false),
+			Duration:   github.Ptr(0.27),
+			Status:     github.Ptr("OK"),
+			StatusCode: github.Ptr(200),
+			Event:      github.Ptr("push"),
+		},
+		{
+			ID:         github.Ptr(int64(11)),
+			GUID:       github.Ptr("guid-11"),
+			Redelivery: github.Ptr(true),
+			Status:     github.Ptr("Invalid HTTP Response: 500"),
+			StatusCode: github.Ptr(500),
+			Event:      github.Ptr("pull_request"),
+			Action:     github.Ptr("opened"),
+		},
+	}
+
+	requestPayload := json.RawMessage(`{"zen":"Keep it simple.","hook":{"config":{"url":"https://example.com/webhook","secret":"********"}}}`)
+	responsePayload := json.RawMessage(`"{\"ok\":false,\"secret\":\"s3cr3t\"}"`)
+	mockDelivery := &github.HookDelivery{
+		ID:         github.Ptr(int64(11)),
+		GUID:       github.Ptr("guid-11"),
+		StatusCode: github.Ptr(500),
+		Event:      github.Ptr("ping"),
+		Request: &github.HookRequest{
+			Headers:    map[string]string{"X-GitHub-Event": "ping"},
+			RawPayload: &requestPayload,
+		},
+		Response: &github.HookResponse{
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			RawPayload: &responsePayload,
+		},
+	}
+
+	listWithCursor := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/hooks/1/deliveries?cursor=v1_11&per_page=2>; rel="next"`)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(mockDeliveries)
+	})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedDeliveries []MinimalHookDelivery
+		expectedCursor     string
+	}{
+		{
+			name: "lists deliveries with next cursor",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposHooksDeliveriesByOwnerByRepoByHookID,
+					expectQueryParams(t, map[string]string{
+						"cursor":   "v1_9",
+						"per_page": "2",
+					}).andThen(listWithCursor),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "list",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+				"perPage": float64(2),
+				"after":   "v1_9",
+			},
+			expectedDeliveries: []MinimalHookDelivery{
+				convertToMinimalHookDelivery(mockDeliveries[0]),
+				convertToMinimalHookDelivery(mockDeliveries[1]),
+			},
+			expectedCursor: "v1_11",
+		},
+		{
+			name: "lists only failed deliveries",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatch(GetReposHooksDeliveriesByOwnerByRepoByHookID, mockDeliveries),
+			),
+			requestArgs: map[string]interface{}{
+				"method":      "list",
+				"owner":       "owner",
+				"repo":        "repo",
+				"hook_id":     float64(1),
+				"failed_only": true,
+			},
+			expectedDeliveries: []MinimalHookDelivery{
+				{
+					ID:         11,
+					GUID:       "guid-11",
+					Redelivery: true,
+					Status:     "Invalid HTTP Response: 500",
+					StatusCode: 500,
+					Event:      "pull_request",
+					Action:     "opened",
+				},
+			},
+		},
+		{
+			name:         "get requires delivery_id",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"method":  "get",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "delivery_id is required for get",
+		},
+		{
+			name: "list fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					GetReposHooksDeliveriesByOwnerByRepoByHookID,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"method":  "list",
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhook deliveries",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Deliveries []MinimalHookDelivery `json:"deliveries"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedDeliveries, response.Deliveries)
+			assert.Equal(t, tc.expectedCursor, response.PageInfo.EndCursor)
+			assert.Equal(t, tc.expectedCursor != "", response.PageInfo.HasNextPage)
+		})
+	}
+
+	t.Run("gets organization delivery with secrets redacted", func(t *testing.T) {
+		client := github.NewClient(NewMockedHTTPClient(
+			WithRequestMatch(GetOrgsHooksDeliveriesByOrgByHookIDByDeliveryID, mockDelivery),
+		))
+		deps := BaseDeps{
+			Client: client,
+		}
+		handler := serverTool.Handler(deps)
+
+		request := createMCPRequest(map[string]interface{}{
+			"method":      "get",
+			"owner":       "org",
+			"hook_id":     float64(1),
+			"delivery_id": float64(11),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		textContent := getTextResult(t, result)
+		assert.NotContains(t, textContent.Text, "********")
+		assert.NotContains(t, textContent.Text, "s3cr3t")
+
+		var detail WebhookDeliveryDetail
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &detail))
+		assert.Equal(t, int64(11), detail.ID)
+		assert.Equal(t, 500, detail.StatusCode)
+		require.NotNil(t, detail.Request)
+		assert.Equal(t, "ping", detail.Request.Headers["X-GitHub-Event"])
+		assert.JSONEq(t, `{"zen":"Keep it simple.","hook":{"config":{"url":"https://example.com/webhook","secret":"[REDACTED]"}}}`, string(detail.Request.Payload))
+		require.NotNil(t, detail.Response)
+		assert.Equal(t, "application/json", detail.Response.Headers["Content-Type"])
+		assert.JSONEq(t, `"{\"ok\":false,\"secret\":\"[REDACTED]\"}"`, string(detail.Response.Payload))
+	})
+}
+
+func Test_RedeliverWebhookDelivery(t *testing.T) {
+	// Verify tool definition once
+	serverTool := RedeliverWebhookDelivery(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "redeliver_webhook_delivery", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "hook_id", "delivery_id"})
+
+	accepted := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "redelivers repository delivery",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIDByDeliveryID, accepted),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"hook_id":     float64(1),
+				"delivery_id": float64(11),
+			},
+			expectedText: "redelivery of delivery 11 for webhook 1 on owner/repo requested",
+		},
+		{
+			name: "redelivers organization delivery",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(PostOrgsHooksDeliveriesAttemptsByOrgByHookIDByDeliveryID, accepted),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "org",
+				"hook_id":     float64(1),
+				"delivery_id": float64(11),
+			},
+			expectedText: "redelivery of delivery 11 for webhook 1 on organization org requested",
+		},
+		{
+			name:         "requires delivery_id",
+			mockedClient: NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: delivery_id",
+		},
+		{
+			name: "redelivery fails",
+			mockedClient: NewMockedHTTPClient(
+				WithRequestMatchHandler(
+					PostReposHooksDeliveriesAttemptsByOwnerByRepoByHookIDByDeliveryID,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"hook_id":     float64(1),
+				"delivery_id": float64(11),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to redeliver webhook delivery",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			deps := BaseDeps{
+				Client: client,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -234,8 +234,7 @@ func (c *RepoAccessCache) queryRepoAccessInfo(ctx context.Context, username, own
 	for _, edge := range query.Repository.Collaborators.Edges {
 		login := string(edge.Node.Login)
 		if strings.EqualFold(login, username) {
-			permission := string(edge.Permission)
-			hasPush = permission == "WRITE" || permission == "ADMIN" || permission == "MAINTAIN"
+			hasPush = HasPushPermission(string(edge.Permission))
 			break
 		}
 	}
@@ -250,6 +249,17 @@ func (c *RepoAccessCache) queryRepoAccessInfo(ctx context.Context, username, own
 	}, nil
 }
 
+// HasPushPermission reports whether a GraphQL RepositoryPermission value grants
+// push access to a repository.
+func HasPushPermission(permission string) bool {
+	switch strings.ToUpper(permission) {
+	case "WRITE", "MAINTAIN", "ADMIN":
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *RepoAccessCache) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
 	if c == nil || c.logger == nil {
 		return
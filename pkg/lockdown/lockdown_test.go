@@ -110,3 +110,12 @@ func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
 	require.True(t, info.HasPushAccess)
 	require.EqualValues(t, 2, transport.CallCount())
 }
+
+func TestHasPushPermission(t *testing.T) {
+	for _, permission := range []string{"WRITE", "MAINTAIN", "ADMIN", "write"} {
+		require.True(t, HasPushPermission(permission), permission)
+	}
+	for _, permission := range []string{"READ", "TRIAGE", ""} {
+		require.False(t, HasPushPermission(permission), permission)
+	}
+}
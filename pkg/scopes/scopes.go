@@ -55,6 +55,18 @@ const (
 
 	// WritePackages grants write access to packages
 	WritePackages Scope = "write:packages"
+
+	// ReadRepoHook grants read access to repository webhooks
+	ReadRepoHook Scope = "read:repo_hook"
+
+	// WriteRepoHook grants read and write access to repository webhooks
+	WriteRepoHook Scope = "write:repo_hook"
+
+	// AdminRepoHook grants full control of repository webhooks
+	AdminRepoHook Scope = "admin:repo_hook"
+
+	// AdminOrgHook grants full control of organization webhooks
+	AdminOrgHook Scope = "admin:org_hook"
 )
 
 // ScopeHierarchy defines parent-child relationships between scopes.
@@ -67,6 +79,8 @@ var ScopeHierarchy = map[Scope][]Scope{
 	Project:       {ReadProject},
 	WritePackages: {ReadPackages},
 	User:          {ReadUser, UserEmail},
+	AdminRepoHook: {WriteRepoHook, ReadRepoHook},
+	WriteRepoHook: {ReadRepoHook},
 }
 
 // ScopeSet represents a set of OAuth scopes.
@@ -83,6 +83,11 @@ func TestExpandScopes(t *testing.T) {
 			required: []Scope{ReadUser},
 			expected: []string{"read:user", "user"},
 		},
+		{
+			name:     "read:repo_hook also accepts write:repo_hook and admin:repo_hook (parents)",
+			required: []Scope{ReadRepoHook},
+			expected: []string{"admin:repo_hook", "read:repo_hook", "write:repo_hook"},
+		},
 		{
 			name:     "multiple scopes combine correctly",
 			required: []Scope{PublicRepo, ReadOrg},